@@ -0,0 +1,100 @@
+// Accrual system simulator for local development. Serves 'GET /api/orders/{number}' with scripted responses.
+//
+// Usage example:
+//
+//	accrualmock -a localhost:8081 -s 'REGISTERED,PROCESSING,PROCESSED:500' -r 60 -t 30 -f 10 -c scripts.json
+//
+// scripts.json maps order numbers to their scripts: {"12345678903": "PROCESSING,INVALID"}.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/fake"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func main() {
+	hostAddr := flag.String("a", "localhost:8081", "server endpoint")
+	rateLimit := flag.Int("r", 0, "requests per minute limit(0 - unlimited)")
+	retryAfter := flag.Int("t", 60, "'Retry-After' value in seconds for requests over limit")
+	latency := flag.Int("d", 0, "response delay in milliseconds")
+	failEvery := flag.Int("f", 0, "every n-th request fails with 500(0 - no failures)")
+	defaultScript := flag.String("s", "REGISTERED,PROCESSING,PROCESSED:500", "script for unknown orders(empty - 204 for unknown orders)")
+	scriptsPath := flag.String("c", "", "JSON file with orders scripts")
+	logLevel := flag.String("l", "info", "log level")
+	flag.Parse()
+
+	log, err := logger.CreateZapLogger(*logLevel)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "failed to create logger: %v", err)
+		return
+	}
+	defer log.Sync()
+
+	cfg := fake.Config{
+		RateLimit:  *rateLimit,
+		RetryAfter: *retryAfter,
+		Latency:    time.Duration(*latency) * time.Millisecond,
+		FailEvery:  *failEvery,
+	}
+	if *defaultScript != "" {
+		if cfg.DefaultScript, err = fake.ParseScript(*defaultScript); err != nil {
+			log.Info("failed to parse default script: %v", err)
+			return
+		}
+	}
+
+	simulator := fake.Create(cfg, log)
+	if *scriptsPath != "" {
+		if err = registerScripts(simulator, *scriptsPath); err != nil {
+			log.Info("failed to load orders scripts: %v", err)
+			return
+		}
+	}
+
+	server := &http.Server{
+		Addr:    *hostAddr,
+		Handler: simulator.Route(),
+	}
+
+	go func() {
+		log.Info("accrual simulator is launching with Host setting: %s", *hostAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Info("accrual simulator refused to start with error: %v", err)
+		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	<-sigCh
+
+	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(ctxShutdown); err != nil {
+		log.Info("accrual simulator shutdown failed: %v", err)
+	}
+}
+
+func registerScripts(simulator *fake.Server, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read scripts file: %w", err)
+	}
+
+	scripts := fake.Scripts{}
+	if err = json.Unmarshal(raw, &scripts); err != nil {
+		return fmt.Errorf("parse scripts file: %w", err)
+	}
+
+	return simulator.RegisterScripts(scripts)
+}
@@ -2,28 +2,42 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/accrual"
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
 	"github.com/erupshis/bonusbridge/internal/accrual/client"
+	"github.com/erupshis/bonusbridge/internal/accrual/ratelimiter"
 	"github.com/erupshis/bonusbridge/internal/accrual/workerspool"
+	"github.com/erupshis/bonusbridge/internal/admin"
 	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/auth/hasher"
 	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	sessionsStorage "github.com/erupshis/bonusbridge/internal/auth/sessions/storage"
 	"github.com/erupshis/bonusbridge/internal/auth/users/data"
-	postgresUsers "github.com/erupshis/bonusbridge/internal/auth/users/managers"
 	"github.com/erupshis/bonusbridge/internal/bonuses"
+	"github.com/erupshis/bonusbridge/internal/bonuses/reconciler"
 	bonusesStorage "github.com/erupshis/bonusbridge/internal/bonuses/storage"
-	postgresBonuses "github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
 	"github.com/erupshis/bonusbridge/internal/config"
 	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/health"
+	idempotencyStorage "github.com/erupshis/bonusbridge/internal/idempotency/storage"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders"
+	ordersData "github.com/erupshis/bonusbridge/internal/orders/data"
 	ordersStorage "github.com/erupshis/bonusbridge/internal/orders/storage"
-	postgresOrders "github.com/erupshis/bonusbridge/internal/orders/storage/managers"
+	"github.com/erupshis/bonusbridge/internal/outbox"
+	"github.com/erupshis/bonusbridge/internal/outbox/sinks"
+	"github.com/erupshis/bonusbridge/internal/stream"
+	"github.com/erupshis/bonusbridge/internal/webhooks"
+	webhooksStorage "github.com/erupshis/bonusbridge/internal/webhooks/storage"
+	"github.com/erupshis/bonusbridge/internal/webhooks/target"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -41,54 +55,130 @@ func main() {
 	ctxWithCancel, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	databaseConn, err := db.CreateConnection(ctxWithCancel, cfg, log)
+	//storage.
+	managers, err := createStorageManagers(ctxWithCancel, cfg, log)
 	if err != nil {
-		log.Info("failed to connect to users database: %v", err)
+		log.Info("failed to create storage: %v", err)
+		return
 	}
 
 	//authentication.
-	usersStorage := postgresUsers.Create(databaseConn, log)
-	jwtGenerator := jwtgenerator.Create(cfg.JWTKey, 2, log)
-	authController := auth.CreateController(usersStorage, jwtGenerator, log)
+	usersStorage := managers.users
+	jwtGenerator := jwtgenerator.Create(cfg.JWTKey, cfg.TokenExp, log)
+	if cfg.JWTKeysDir != "" {
+		// tokens issued with HS256 key before rotation are accepted only if operator explicitly set the cutoff.
+		var legacyUntil time.Time
+		if cfg.JWTLegacyUntil != "" {
+			if legacyUntil, err = time.Parse(time.RFC3339, cfg.JWTLegacyUntil); err != nil {
+				log.Info("failed to parse JWT legacy key cutoff: %v", err)
+				return
+			}
+		}
+
+		keyring, err := jwtgenerator.LoadKeyring(cfg.JWTKeysDir, cfg.JWTActiveKeyID, cfg.JWTKey, legacyUntil)
+		if err != nil {
+			log.Info("failed to load JWT keys: %v", err)
+			return
+		}
+		jwtGenerator = jwtgenerator.CreateWithKeyring(keyring, cfg.TokenExp, log)
+	}
+	sessionsStrg := sessionsStorage.Create(managers.sessions, jwtGenerator, cfg.RefreshTokenExp, log)
+	passwordHasher, err := hasher.Create(cfg.PasswordHashAlgorithm, cfg.PasswordHashCost, log)
+	if err != nil {
+		log.Info("failed to create password hasher: %v", err)
+		return
+	}
+	authController := auth.CreateController(usersStorage, sessionsStrg, jwtGenerator, passwordHasher, log)
+
+	//idempotency keys.
+	idempotencyStrg := idempotencyStorage.Create(managers.idempotency, cfg.IdempotencyKeyTTL, log)
+
+	//users events streaming.
+	streamBroker := stream.CreateBroker(16, log)
+	// outbox events are relayed by one instance only, PostgreSQL notifications deliver them to streams of every instance.
+	// SQLite and in-memory storages serve single instance, events are passed to its broker directly.
+	var streamSink sinks.BaseSink = streamBroker
+	var streamNotifier *stream.Notifier
+	if managers.databaseConn != nil && managers.databaseConn.Dialect == db.DialectPostgres {
+		streamNotifier = stream.CreateNotifier(managers.databaseConn, streamBroker, log)
+		streamNotifier.Run(ctxWithCancel)
+		streamSink = streamNotifier
+	}
 
 	//orders.
-	ordersManager := postgresOrders.Create(databaseConn, log)
-	ordersStrg := ordersStorage.Create(ordersManager, log)
-	ordersController := orders.CreateController(ordersStrg, log)
+	ordersStrg := ordersStorage.Create(managers.orders, log)
+	ordersController := orders.CreateController(ordersStrg, idempotencyStrg, streamBroker, log)
 
 	//bonuses.
-	bonusesManager := postgresBonuses.Create(databaseConn, log)
-	bonusesStrg := bonusesStorage.Create(bonusesManager, log)
-	bonusesController := bonuses.CreateController(bonusesStrg, log)
+	bonusesStrg := bonusesStorage.Create(managers.bonuses, log)
+	bonusesController := bonuses.CreateController(bonusesStrg, idempotencyStrg, log)
+	balancesReconciler := reconciler.Create(bonusesStrg, log)
+	balancesReconciler.Run(ctxWithCancel, cfg.BalanceReconcileInterval)
+
+	//support staff.
+	adminController := admin.CreateController(usersStorage, ordersStrg, bonusesStrg, log)
 
 	//accrual(orders update) system.
-	workersPool := workerspool.Create(4, log)
-	defer workersPool.CloseJobsChan()
-	defer workersPool.CloseResultsChan()
+	workersPool := workerspool.Create[*ordersData.Order](ctxWithCancel, cfg.AccrualWorkers, 50, workerspool.PolicyReject, log)
 
-	requestClient := client.CreateDefault(log)
-	accrualController := accrual.CreateController(ordersStrg, bonusesStrg, requestClient, workersPool, cfg, log)
+	accrualBreaker := breaker.Create(cfg.AccrualBreakerThreshold, time.Duration(cfg.AccrualBreakerTimeout)*time.Second, log)
+	requestClient := client.CreateWithBreaker(client.CreateDefault(time.Duration(cfg.AccrualTimeout)*time.Second, log), accrualBreaker, log)
+	requestsLimiter := ratelimiter.Create(cfg.AccrualRateLimit, 1, log)
+	accrualController := accrual.CreateController(ordersStrg, bonusesStrg, requestClient, workersPool, requestsLimiter, accrualBreaker, cfg, log)
+	healthController := health.CreateController(accrualBreaker, requestsLimiter, workersPool, log)
 	accrualController.Run(ctxWithCancel, 5)
 
+	//webhooks.
+	webhooksStrg := webhooksStorage.Create(managers.webhooks, log)
+	webhooksController := webhooks.CreateController(webhooksStrg, log)
+	webhooksDispatcher := webhooks.CreateDispatcher(webhooksStrg, target.CreateClient(5*time.Second), 100, log)
+	webhooksDispatcher.Run(ctxWithCancel, 1)
+
+	//events outbox relay.
+	outboxRelay := outbox.CreateRelay(managers.outbox, sinks.CreateFanOutSink(
+		streamSink,
+		webhooks.CreateSink(webhooksStrg, log),
+	), 100, log)
+	outboxRelay.Run(ctxWithCancel, 1)
+
 	//controllers mounting.
 	router := chi.NewRouter()
 	router.Use(log.LogHandler)
 
 	router.Mount("/api/user/register", authController.RouteRegister())
 	router.Mount("/api/user/login", authController.RouteLoginer())
+	router.Mount("/api/user/token/refresh", authController.RouteRefresher())
+	router.Mount("/.well-known/jwks.json", authController.RouteJWKS())
+	router.Mount("/api/health", healthController.Route())
 
 	router.Group(func(r chi.Router) {
 		r.Use(authController.AuthorizeUser(data.RoleUser))
 
+		r.Mount("/api/user/logout", authController.RouteLogouter())
 		r.Mount("/api/user/orders", ordersController.Route())
 		r.Mount("/api/user/balance", bonusesController.RouteBonuses())
 		r.Mount("/api/user/withdrawals", bonusesController.RouteWithdrawals())
+		r.Mount("/api/user/webhooks", webhooksController.Route())
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(authController.AuthorizeUser(data.RoleAdmin))
+
+		r.Mount("/api/admin/users", adminController.RouteUsers())
+		r.Mount("/api/admin/orders", adminController.RouteOrders())
 	})
 
 	//server launch.
+	server := &http.Server{
+		Addr:    cfg.HostAddr,
+		Handler: router,
+	}
+	// events streams are long-living requests, they have to be closed to let server stop.
+	server.RegisterOnShutdown(streamBroker.Close)
+
 	go func() {
 		log.Info("server is launching with Host setting: %s", cfg.HostAddr)
-		if err := http.ListenAndServe(cfg.HostAddr, router); err != nil {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Info("server refused to start with error: %v", err)
 		}
 	}()
@@ -96,4 +186,41 @@ func main() {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	<-sigCh
+
+	log.Info("shutdown is started with grace period '%d' seconds", cfg.ShutdownTimeout)
+	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(ctxShutdown); err != nil {
+		log.Info("server shutdown failed: %v", err)
+	}
+
+	if err := accrualController.Shutdown(ctxShutdown); err != nil {
+		log.Info("accrual interaction shutdown failed: %v", err)
+	}
+
+	// background tasks finish their current transactions before database is closed.
+	if err := outboxRelay.Shutdown(ctxShutdown); err != nil {
+		log.Info("outbox relay shutdown failed: %v", err)
+	}
+	if err := webhooksDispatcher.Shutdown(ctxShutdown); err != nil {
+		log.Info("webhooks dispatcher shutdown failed: %v", err)
+	}
+	if err := balancesReconciler.Shutdown(ctxShutdown); err != nil {
+		log.Info("balances reconciler shutdown failed: %v", err)
+	}
+	if streamNotifier != nil {
+		if err := streamNotifier.Shutdown(ctxShutdown); err != nil {
+			log.Info("stream notifier shutdown failed: %v", err)
+		}
+	}
+
+	// background tasks and requests left after grace period are interrupted.
+	cancel()
+
+	if managers.databaseConn != nil {
+		if err := managers.databaseConn.Close(); err != nil {
+			log.Info("database connection closing failed: %v", err)
+		}
+	}
+	log.Info("shutdown is finished")
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	sessionsManagers "github.com/erupshis/bonusbridge/internal/auth/sessions/managers"
+	usersManagers "github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	bonusesManagers "github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
+	"github.com/erupshis/bonusbridge/internal/config"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	idempotencyManagers "github.com/erupshis/bonusbridge/internal/idempotency/managers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	ordersManagers "github.com/erupshis/bonusbridge/internal/orders/storage/managers"
+	outboxManagers "github.com/erupshis/bonusbridge/internal/outbox/managers"
+	webhooksManagers "github.com/erupshis/bonusbridge/internal/webhooks/managers"
+)
+
+// storageManagers managers of selected storage backend.
+type storageManagers struct {
+	users       usersManagers.BaseUsersManager
+	sessions    sessionsManagers.BaseSessionsManager
+	idempotency idempotencyManagers.BaseIdempotencyManager
+	orders      ordersManagers.BaseOrdersManager
+	bonuses     bonusesManagers.BaseBonusesManager
+	outbox      outboxManagers.BaseOutboxManager
+	webhooks    webhooksManagers.BaseWebhooksManager
+
+	// databaseConn is nil for in-memory storage.
+	databaseConn *db.Conn
+}
+
+// createStorageManagers creates managers of storage backend from config.
+func createStorageManagers(ctx context.Context, cfg config.Config, log logger.BaseLogger) (*storageManagers, error) {
+	switch cfg.Storage {
+	case config.StorageMemory:
+		log.Info("[main:createStorageManagers] data is stored in memory and will be lost on restart")
+		store := memory.Create()
+		return &storageManagers{
+			users:       usersManagers.CreateInMemory(store, log),
+			sessions:    sessionsManagers.CreateInMemory(store, log),
+			idempotency: idempotencyManagers.CreateInMemory(store, log),
+			orders:      ordersManagers.CreateInMemory(store, log),
+			bonuses:     bonusesManagers.CreateInMemory(store, log),
+			outbox:      outboxManagers.CreateInMemory(store, log),
+			webhooks:    webhooksManagers.CreateInMemory(store, log),
+		}, nil
+	case config.StoragePostgres:
+		databaseConn, err := db.CreateConnection(ctx, cfg, log)
+		if err != nil {
+			log.Info("failed to connect to users database: %v", err)
+		}
+
+		return &storageManagers{
+			users:        usersManagers.Create(databaseConn, log),
+			sessions:     sessionsManagers.Create(databaseConn, log),
+			idempotency:  idempotencyManagers.Create(databaseConn, log),
+			orders:       ordersManagers.Create(databaseConn, log),
+			bonuses:      bonusesManagers.Create(databaseConn, log),
+			outbox:       outboxManagers.Create(databaseConn, log),
+			webhooks:     webhooksManagers.Create(databaseConn, log),
+			databaseConn: databaseConn,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage '%s'", cfg.Storage)
+	}
+}
@@ -0,0 +1,2 @@
+--PASSWORDS ARE STORED AS HASHES(argon2id PHC string is longer than bcrypt's 60 symbols).
+ALTER TABLE users ALTER COLUMN password TYPE VARCHAR(255);
@@ -0,0 +1,21 @@
+--MATERIALIZED USERS BALANCES
+CREATE TABLE IF NOT EXISTS balances
+(
+    user_id INTEGER PRIMARY KEY REFERENCES users(id),
+    current NUMERIC(12,2) NOT NULL DEFAULT 0,
+    withdrawn NUMERIC(12,2) NOT NULL DEFAULT 0,
+    accrued NUMERIC(12,2) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+--BACKFILL FROM BONUSES LEDGER
+INSERT INTO balances(user_id, current, withdrawn, accrued)
+SELECT b.user_id,
+       COALESCE(SUM(b.count), 0),
+       COALESCE(-SUM(b.count) FILTER (WHERE w.id IS NOT NULL), 0),
+       COALESCE(SUM(b.count) FILTER (WHERE o.id IS NOT NULL), 0)
+FROM bonuses b
+         LEFT JOIN withdrawals w ON w.bonus_id = b.id
+         LEFT JOIN orders o ON o.bonus_id = b.id
+GROUP BY b.user_id
+ON CONFLICT (user_id) DO NOTHING;
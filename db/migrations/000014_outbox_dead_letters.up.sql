@@ -0,0 +1,8 @@
+--OUTBOX EVENTS FAILED TO BE PUBLISHED TOO MANY TIMES
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMP WITH TIME ZONE;
+
+DROP INDEX IF EXISTS outbox_unpublished_idx;
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox(id) WHERE published_at IS NULL AND dead_lettered_at IS NULL;
+
+--PUBLISHED EVENTS PRUNING
+CREATE INDEX IF NOT EXISTS outbox_published_idx ON outbox(published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,7 @@
+--REFRESH TOKENS REPLACED BY ROTATION, THEIR REUSE REVOKES SESSION
+CREATE TABLE IF NOT EXISTS superseded_refresh_tokens
+(
+    refresh_token_hash VARCHAR(64) PRIMARY KEY,
+    session_id INTEGER REFERENCES sessions(id) ON DELETE CASCADE NOT NULL,
+    superseded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
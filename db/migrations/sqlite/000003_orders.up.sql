@@ -0,0 +1,24 @@
+--ORDERS STATUS
+CREATE TABLE IF NOT EXISTS statuses
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    status VARCHAR(15) NOT NULL UNIQUE
+);
+
+INSERT INTO statuses(status)
+VALUES ('NEW'),
+       ('PROCESSING'),
+       ('INVALID'),
+       ('PROCESSED'),
+       ('UNDEFINED');
+
+--ORDERS ITSELF(numbers are kept as text: long numbers lose precision with numeric affinity)
+CREATE TABLE IF NOT EXISTS orders
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    num TEXT NOT NULL UNIQUE,
+    status_id SMALLINT REFERENCES statuses(id) NOT NULL,
+    user_id INTEGER REFERENCES users(id) NOT NULL,
+    bonus_id INTEGER UNIQUE REFERENCES bonuses(id) NOT NULL,
+    uploaded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS withdrawals
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER REFERENCES users(id) NOT NULL,
+    order_num TEXT NOT NULL UNIQUE,
+    bonus_id INTEGER UNIQUE REFERENCES bonuses(id) NOT NULL,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
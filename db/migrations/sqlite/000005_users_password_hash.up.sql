@@ -0,0 +1,3 @@
+--PASSWORDS ARE STORED AS HASHES(argon2id PHC string is longer than bcrypt's 60 symbols).
+--SQLite doesn't restrict VARCHAR length, so users table is left as is.
+SELECT 1;
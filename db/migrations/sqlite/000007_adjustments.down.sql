@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS adjustments;
+DROP TABLE IF EXISTS adjustment_reasons;
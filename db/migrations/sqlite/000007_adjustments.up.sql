@@ -0,0 +1,25 @@
+--ADJUSTMENT REASONS
+CREATE TABLE IF NOT EXISTS adjustment_reasons
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reason VARCHAR(15) NOT NULL UNIQUE
+);
+
+INSERT INTO adjustment_reasons(reason)
+VALUES ('GOODWILL'),
+       ('COMPENSATION'),
+       ('CORRECTION');
+
+--MANUAL BONUSES ADJUSTMENTS
+CREATE TABLE IF NOT EXISTS adjustments
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER REFERENCES users(id) NOT NULL,
+    bonus_id INTEGER UNIQUE REFERENCES bonuses(id) NOT NULL,
+    operator_id INTEGER REFERENCES users(id) NOT NULL,
+    reason_id SMALLINT REFERENCES adjustment_reasons(id) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS adjustments_user_id_idx ON adjustments(user_id);
@@ -0,0 +1,16 @@
+--ACCRUAL POLLING SCHEDULE
+CREATE TABLE IF NOT EXISTS accrual_jobs
+(
+    order_id INTEGER PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS accrual_jobs_next_attempt_idx ON accrual_jobs(next_attempt_at);
+
+--orders without final status(INVALID, PROCESSED) are still waiting for accrual calculation.
+INSERT INTO accrual_jobs(order_id)
+SELECT id
+FROM orders
+WHERE status_id NOT IN (SELECT id FROM statuses WHERE status IN ('INVALID', 'PROCESSED'))
+ON CONFLICT DO NOTHING;
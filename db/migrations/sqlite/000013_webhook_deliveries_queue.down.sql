@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS webhook_deliveries_next_attempt_idx;
+DROP INDEX IF EXISTS webhook_deliveries_event_idx;
+ALTER TABLE webhook_deliveries DROP COLUMN next_attempt_at;
+ALTER TABLE webhook_deliveries DROP COLUMN payload;
+ALTER TABLE outbox DROP COLUMN locked_until;
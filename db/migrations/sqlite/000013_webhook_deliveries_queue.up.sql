@@ -0,0 +1,13 @@
+--OUTBOX EVENTS CLAIMED BY RELAY
+ALTER TABLE outbox ADD COLUMN locked_until TIMESTAMP;
+
+--WEBHOOKS DELIVERIES QUEUE
+ALTER TABLE webhook_deliveries ADD COLUMN payload BLOB NOT NULL DEFAULT x'';
+ALTER TABLE webhook_deliveries ADD COLUMN next_attempt_at TIMESTAMP;
+
+--relay could log the same event delivery more than once before, the latest record is kept.
+DELETE FROM webhook_deliveries
+WHERE id NOT IN (SELECT MAX(id) FROM webhook_deliveries GROUP BY webhook_id, event_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_event_idx ON webhook_deliveries(webhook_id, event_id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_next_attempt_idx ON webhook_deliveries(next_attempt_at) WHERE next_attempt_at IS NOT NULL;
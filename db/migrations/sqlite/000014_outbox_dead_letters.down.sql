@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS outbox_published_idx;
+
+DROP INDEX IF EXISTS outbox_unpublished_idx;
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox(id) WHERE published_at IS NULL;
+
+ALTER TABLE outbox DROP COLUMN dead_lettered_at;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS superseded_refresh_tokens;
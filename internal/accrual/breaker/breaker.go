@@ -0,0 +1,151 @@
+// Package breaker circuit breaker of accrual system requests.
+// Breaker opens after threshold consecutive failures, rejects requests for open timeout and lets single probe
+// request through afterwards. Successful probe closes breaker, failed one opens it again.
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+var ErrOpen = fmt.Errorf("circuit breaker is open")
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+// Stats breaker's state snapshot.
+type Stats struct {
+	State               State
+	ConsecutiveFailures int
+	TotalFailures       int64
+	Rejected            int64
+	OpenedAt            time.Time
+	LastError           string
+}
+
+type Breaker struct {
+	mu sync.Mutex
+
+	threshold   int
+	openTimeout time.Duration
+
+	stats   Stats
+	probing bool
+
+	now func() time.Time
+	log logger.BaseLogger
+}
+
+// Create creates breaker which opens after threshold consecutive failures for openTimeout.
+func Create(threshold int, openTimeout time.Duration, log logger.BaseLogger) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &Breaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		stats:       Stats{State: StateClosed},
+		now:         time.Now,
+		log:         log,
+	}
+}
+
+// Allow checks whether request may be performed. Returns ErrOpen if request is rejected.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case StateOpen:
+		b.stats.Rejected++
+		return ErrOpen
+	case StateHalfOpen:
+		if b.probing {
+			b.stats.Rejected++
+			return ErrOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Success registers successful request.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stats.State != StateClosed {
+		b.log.Info("[accrual:Breaker:Success] accrual system has recovered, breaker is closed")
+	}
+
+	b.probing = false
+	b.stats.State = StateClosed
+	b.stats.ConsecutiveFailures = 0
+}
+
+// Failure registers failed request.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stats.ConsecutiveFailures++
+	b.stats.TotalFailures++
+	if err != nil {
+		b.stats.LastError = err.Error()
+	}
+
+	if b.probing || b.stats.ConsecutiveFailures >= b.threshold {
+		if b.stats.State == StateClosed {
+			b.log.Info("[accrual:Breaker:Failure] breaker is opened after '%d' failures, last error: %v", b.stats.ConsecutiveFailures, err)
+		}
+
+		b.probing = false
+		b.stats.State = StateOpen
+		b.stats.OpenedAt = b.now()
+	}
+}
+
+// Ignore releases allowed request without result(e.g. interrupted by context), so the next probe is allowed.
+func (b *Breaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State returns current breaker's state.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state()
+}
+
+// Stats returns breaker's state snapshot.
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.stats
+	stats.State = b.state()
+	return stats
+}
+
+// state returns open breaker as half-open after open timeout expiration.
+func (b *Breaker) state() State {
+	if b.stats.State == StateOpen && b.now().Sub(b.stats.OpenedAt) >= b.openTimeout {
+		return StateHalfOpen
+	}
+
+	return b.stats.State
+}
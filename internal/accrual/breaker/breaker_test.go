@@ -0,0 +1,57 @@
+package breaker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	now := time.Now()
+	b := Create(2, time.Minute, log)
+	b.now = func() time.Time { return now }
+
+	// single failure doesn't open breaker, success resets failures counter.
+	assert.NoError(t, b.Allow())
+	b.Failure(fmt.Errorf("first error"))
+	b.Success()
+	b.Failure(fmt.Errorf("second error"))
+	assert.Equal(t, StateClosed, b.State())
+
+	b.Failure(fmt.Errorf("third error"))
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	stats := b.Stats()
+	assert.Equal(t, 2, stats.ConsecutiveFailures)
+	assert.Equal(t, int64(3), stats.TotalFailures)
+	assert.Equal(t, int64(1), stats.Rejected)
+	assert.Equal(t, now, stats.OpenedAt)
+	assert.Equal(t, "third error", stats.LastError)
+
+	// single probe is allowed after open timeout, failed probe opens breaker again.
+	now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+	b.Failure(fmt.Errorf("probe error"))
+	assert.Equal(t, StateOpen, b.State())
+
+	// interrupted probe doesn't block the next one.
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Ignore()
+	assert.NoError(t, b.Allow())
+
+	// successful probe closes breaker.
+	b.Success()
+	assert.Equal(t, StateClosed, b.State())
+	assert.NoError(t, b.Allow())
+	assert.NoError(t, b.Allow())
+}
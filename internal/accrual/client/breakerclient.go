@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+)
+
+type breakerClient struct {
+	client  BaseClient
+	breaker *breaker.Breaker
+	log     logger.BaseLogger
+}
+
+// CreateWithBreaker wraps client with circuit breaker. Connection errors and 5xx responses are counted as failures,
+// requests are rejected with breaker.ErrOpen while accrual system is considered down.
+func CreateWithBreaker(client BaseClient, breaker *breaker.Breaker, log logger.BaseLogger) BaseClient {
+	return &breakerClient{
+		client:  client,
+		breaker: breaker,
+		log:     log,
+	}
+}
+
+func (c *breakerClient) RequestCalculationResult(ctx context.Context, host string, order *data.Order) (ResponseStatus, Throttling, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return http.StatusServiceUnavailable, Throttling{}, fmt.Errorf("request to accrual system: %w", err)
+	}
+
+	respStatus, throttling, err := c.client.RequestCalculationResult(ctx, host, order)
+	switch {
+	case errors.Is(err, context.Canceled):
+		c.breaker.Ignore()
+	case errors.Is(err, ErrConnection):
+		c.breaker.Failure(err)
+	case respStatus >= http.StatusInternalServerError:
+		c.breaker.Failure(fmt.Errorf("accrual system responded with status '%d'", respStatus))
+	default:
+		c.breaker.Success()
+	}
+
+	return respStatus, throttling, err
+}
@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/accrual/client"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakerClient_RequestCalculationResult(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockBaseClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusTooManyRequests), client.Throttling{RetryAfter: 1}, nil),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusServiceUnavailable), client.Throttling{}, fmt.Errorf("client request: %w", context.Canceled)),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusInternalServerError), client.Throttling{}, nil),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusServiceUnavailable), client.Throttling{}, fmt.Errorf("client request: %w", client.ErrConnection)),
+	)
+
+	accrualBreaker := breaker.Create(2, time.Minute, log)
+	c := client.CreateWithBreaker(mockClient, accrualBreaker, log)
+
+	tests := []struct {
+		name        string
+		wantErrOpen bool
+		wantState   breaker.State
+	}{
+		{name: "throttled response is not a failure", wantState: breaker.StateClosed},
+		{name: "interrupted request is not a failure", wantState: breaker.StateClosed},
+		{name: "5xx response is a failure", wantState: breaker.StateClosed},
+		{name: "connection error is a failure", wantState: breaker.StateOpen},
+		{name: "request is rejected by open breaker", wantErrOpen: true, wantState: breaker.StateOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := c.RequestCalculationResult(context.Background(), "", &data.Order{})
+			if tt.wantErrOpen {
+				assert.ErrorIs(t, err, breaker.ErrOpen)
+			} else {
+				assert.NotErrorIs(t, err, breaker.ErrOpen)
+			}
+			assert.Equal(t, tt.wantState, accrualBreaker.State())
+		})
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
@@ -14,27 +17,36 @@ import (
 
 const url = "/api/orders/"
 
+// defRetryAfter pause for 'Too Many Requests' response without valid 'Retry-After' header.
+const defRetryAfter RetryInterval = 60
+
+var rateLimitRegexp = regexp.MustCompile(`No more than (\d+) requests per minute allowed`)
+
 type defaultClient struct {
 	client *http.Client
 	log    logger.BaseLogger
 }
 
-func CreateDefault(log logger.BaseLogger) BaseClient {
+// CreateDefault creates client with timeout for the whole request including response body reading.
+func CreateDefault(timeout time.Duration, log logger.BaseLogger) BaseClient {
 	return &defaultClient{
-		client: &http.Client{},
+		client: &http.Client{Timeout: timeout},
 		log:    log,
 	}
 }
 
-func (c *defaultClient) RequestCalculationResult(ctx context.Context, host string, order *data.Order) (ResponseStatus, RetryInterval, error) {
+func (c *defaultClient) RequestCalculationResult(ctx context.Context, host string, order *data.Order) (ResponseStatus, Throttling, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host+url+order.Number, nil)
 	if err != nil {
-		return http.StatusInternalServerError, 0, fmt.Errorf("create request to loyalty system: %w", err)
+		return http.StatusInternalServerError, Throttling{}, fmt.Errorf("create request to loyalty system: %w", err)
 	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return http.StatusInternalServerError, 0, fmt.Errorf("client request: %w", err)
+		if errors.Is(err, context.Canceled) {
+			return http.StatusInternalServerError, Throttling{}, fmt.Errorf("client request: %w", err)
+		}
+		return http.StatusServiceUnavailable, Throttling{}, fmt.Errorf("client request: %w: %w", ErrConnection, err)
 	}
 	defer func() {
 		if err = resp.Body.Close(); err != nil {
@@ -42,28 +54,49 @@ func (c *defaultClient) RequestCalculationResult(ctx context.Context, host strin
 		}
 	}()
 
-	pause := 0
+	bufResp := bytes.Buffer{}
+	_, err = bufResp.ReadFrom(resp.Body)
+	if err != nil {
+		return http.StatusInternalServerError, Throttling{}, fmt.Errorf("read response body: %w", err)
+	}
+
 	if resp.StatusCode == http.StatusTooManyRequests {
-		pauseStr := resp.Header.Get("Retry-After")
-		pause, err = strconv.Atoi(pauseStr)
+		throttling, err := parseThrottling(resp.Header.Get("Retry-After"), bufResp.String())
 		if err != nil {
-			return http.StatusInternalServerError, 0, fmt.Errorf("parse 'Retry-After' header value: %w", err)
+			// throttled response is still valid, it mustn't be counted as accrual system failure.
+			c.log.Info("[accrual:defaultClient:RequestCalculationResult] %v, pause for default '%d' seconds", err, throttling.RetryAfter)
 		}
+
+		return http.StatusTooManyRequests, throttling, nil
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return ResponseStatus(resp.StatusCode), RetryInterval(pause), nil
+		return ResponseStatus(resp.StatusCode), Throttling{}, nil
 	}
 
-	bufResp := bytes.Buffer{}
-	_, err = bufResp.ReadFrom(resp.Body)
-	if err != nil {
-		return http.StatusInternalServerError, 0, fmt.Errorf("read response body: %w", err)
+	if err = json.Unmarshal(bufResp.Bytes(), order); err != nil {
+		return http.StatusInternalServerError, Throttling{}, fmt.Errorf("parse response body: %w", err)
 	}
 
-	if err = json.Unmarshal(bufResp.Bytes(), order); err != nil {
-		return http.StatusInternalServerError, 0, fmt.Errorf("parse response body: %w", err)
+	return ResponseStatus(resp.StatusCode), Throttling{}, nil
+}
+
+// parseThrottling extracts pause from 'Retry-After' header and allowed rate from body('No more than N requests per minute allowed').
+// Missing or invalid header is reported with error, pause falls back to defRetryAfter then.
+func parseThrottling(retryAfter string, body string) (Throttling, error) {
+	res := Throttling{RetryAfter: defRetryAfter}
+	if matches := rateLimitRegexp.FindStringSubmatch(body); len(matches) == 2 {
+		res.RequestsPerMinute, _ = strconv.Atoi(matches[1])
+	}
+
+	pause, err := strconv.Atoi(retryAfter)
+	if err != nil {
+		return res, fmt.Errorf("parse 'Retry-After' header value: %w", err)
+	}
+	if pause < 0 {
+		return res, fmt.Errorf("negative 'Retry-After' header value '%d'", pause)
 	}
 
-	return ResponseStatus(resp.StatusCode), 0, nil
+	res.RetryAfter = RetryInterval(pause)
+	return res, nil
 }
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultClient_RequestCalculationResult(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case url + "1":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"order":"1","status":"PROCESSED","accrual":500}`))
+		case url + "2":
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("No more than 10 requests per minute allowed"))
+		case url + "3":
+			w.Header().Set("Retry-After", "soon")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("No more than 10 requests per minute allowed"))
+		case url + "5":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		number         string
+		wantStatus     ResponseStatus
+		wantThrottling Throttling
+		wantOrder      data.Order
+		wantErr        bool
+	}{
+		{
+			name:       "processed order",
+			number:     "1",
+			wantStatus: http.StatusOK,
+			wantOrder:  data.Order{Number: "1", Status: "PROCESSED", Accrual: money.New(500, 0)},
+		},
+		{
+			name:           "too many requests",
+			number:         "2",
+			wantStatus:     http.StatusTooManyRequests,
+			wantThrottling: Throttling{RetryAfter: 60, RequestsPerMinute: 10},
+			wantOrder:      data.Order{Number: "2"},
+		},
+		{
+			name:           "invalid retry after",
+			number:         "3",
+			wantStatus:     http.StatusTooManyRequests,
+			wantThrottling: Throttling{RetryAfter: defRetryAfter, RequestsPerMinute: 10},
+			wantOrder:      data.Order{Number: "3"},
+		},
+		{
+			name:           "missing retry after",
+			number:         "5",
+			wantStatus:     http.StatusTooManyRequests,
+			wantThrottling: Throttling{RetryAfter: defRetryAfter},
+			wantOrder:      data.Order{Number: "5"},
+		},
+		{
+			name:       "unregistered order",
+			number:     "4",
+			wantStatus: http.StatusNoContent,
+			wantOrder:  data.Order{Number: "4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := CreateDefault(time.Second, log)
+			order := data.Order{Number: tt.number}
+
+			status, throttling, err := c.RequestCalculationResult(context.Background(), ts.URL, &order)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantThrottling, throttling)
+			assert.Equal(t, tt.wantOrder, order)
+		})
+	}
+}
+
+func TestDefaultClient_RequestCalculationResultThrottledWithBreaker(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "soon")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	// throttled responses with invalid 'Retry-After' header don't open breaker.
+	accrualBreaker := breaker.Create(2, time.Minute, log)
+	c := CreateWithBreaker(CreateDefault(time.Second, log), accrualBreaker, log)
+	for i := 0; i < 3; i++ {
+		status, throttling, err := c.RequestCalculationResult(context.Background(), ts.URL, &data.Order{Number: "1"})
+		require.NoError(t, err)
+		assert.Equal(t, ResponseStatus(http.StatusTooManyRequests), status)
+		assert.Equal(t, defRetryAfter, throttling.RetryAfter)
+	}
+	assert.Equal(t, breaker.StateClosed, accrualBreaker.State())
+}
+
+func TestDefaultClient_RequestCalculationResultConnectionError(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	c := CreateDefault(50*time.Millisecond, log)
+	status, _, err := c.RequestCalculationResult(context.Background(), ts.URL, &data.Order{Number: "1"})
+	assert.ErrorIs(t, err, ErrConnection, "timeout is treated as connection error")
+	assert.Equal(t, ResponseStatus(http.StatusServiceUnavailable), status)
+}
@@ -2,13 +2,24 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/erupshis/bonusbridge/internal/orders/data"
 )
 
+// ErrConnection accrual system is unreachable or doesn't respond in time.
+var ErrConnection = fmt.Errorf("accrual system connection failed")
+
 type ResponseStatus int
 type RetryInterval int
 
+// Throttling accrual system limits reported with 'Too Many Requests' response.
+type Throttling struct {
+	RetryAfter        RetryInterval // RetryAfter pause in seconds from 'Retry-After' header.
+	RequestsPerMinute int           // RequestsPerMinute allowed requests rate from response body, zero if missing.
+}
+
+//go:generate mockgen -destination=../../../mocks/mock_BaseClient.go -package=mocks github.com/erupshis/bonusbridge/internal/accrual/client BaseClient
 type BaseClient interface {
-	RequestCalculationResult(ctx context.Context, host string, order *data.Order) (ResponseStatus, RetryInterval, error)
+	RequestCalculationResult(ctx context.Context, host string, order *data.Order) (ResponseStatus, Throttling, error)
 }
@@ -7,33 +7,66 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
 	"github.com/erupshis/bonusbridge/internal/accrual/client"
+	"github.com/erupshis/bonusbridge/internal/accrual/ratelimiter"
+	"github.com/erupshis/bonusbridge/internal/accrual/statemachine"
 	"github.com/erupshis/bonusbridge/internal/accrual/workerspool"
 	bonusesStorage "github.com/erupshis/bonusbridge/internal/bonuses/storage"
 	"github.com/erupshis/bonusbridge/internal/config"
-	"github.com/erupshis/bonusbridge/internal/db/queries"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
 	ordersStorage "github.com/erupshis/bonusbridge/internal/orders/storage"
 )
 
+const (
+	// claimLease time while claimed order is hidden from other instances. Job is returned to schedule after expiration
+	// if instance fails before order rescheduling.
+	claimLease = 2 * time.Minute
+	// maxBackoffShift limits exponential growth of backoff to prevent duration overflow.
+	maxBackoffShift = 20
+	// maxThrottledRetries count of order's request repeats after accrual system's 'Too Many Requests' response.
+	maxThrottledRetries = 3
+	// updatesFlushInterval max time accrual results wait in buffer before saving.
+	updatesFlushInterval = 200 * time.Millisecond
+	// updatesBatchSize max count of accrual results saved in single transaction.
+	updatesBatchSize = 50
+	// slowLatency average accrual system latency which is treated as its overload, workers are not added above it.
+	slowLatency = time.Second
+)
+
 type Controller struct {
 	ordersStorage  ordersStorage.BaseOrdersStorage
 	bonusesStorage bonusesStorage.BaseBonusesStorage
 
 	client client.BaseClient
 
-	workersPool *workerspool.Pool
+	workersPool *workerspool.Pool[*data.Order]
+	minWorkers  int
+	maxWorkers  int
+	latency     *latencyTracker
+	limiter     *ratelimiter.Limiter
+	breaker     *breaker.Breaker
 
 	accrualAddr string
 
+	backoffBase time.Duration
+	maxBackoff  time.Duration
+	maxAge      time.Duration
+
+	stop          chan struct{}
+	schedulerDone chan struct{}
+	updatesDone   chan struct{}
+
 	log logger.BaseLogger
 }
 
 func CreateController(ordersStorage ordersStorage.BaseOrdersStorage,
 	bonusesStorage bonusesStorage.BaseBonusesStorage,
 	client client.BaseClient,
-	workersPool *workerspool.Pool,
+	workersPool *workerspool.Pool[*data.Order],
+	limiter *ratelimiter.Limiter,
+	breaker *breaker.Breaker,
 	cfg config.Config,
 	baseLogger logger.BaseLogger) Controller {
 	return Controller{
@@ -41,19 +74,64 @@ func CreateController(ordersStorage ordersStorage.BaseOrdersStorage,
 		bonusesStorage: bonusesStorage,
 		client:         client,
 		workersPool:    workersPool,
+		minWorkers:     cfg.AccrualWorkers,
+		maxWorkers:     cfg.AccrualMaxWorkers,
+		latency:        &latencyTracker{},
+		limiter:        limiter,
+		breaker:        breaker,
 		accrualAddr:    cfg.AccrualAddr,
+		maxBackoff:     time.Duration(cfg.AccrualMaxBackoff) * time.Second,
+		maxAge:         time.Duration(cfg.AccrualMaxAge) * time.Hour,
+		stop:           make(chan struct{}),
+		schedulerDone:  make(chan struct{}),
+		updatesDone:    make(chan struct{}),
 		log:            baseLogger,
 	}
 }
 
 func (c *Controller) Run(ctx context.Context, requestInterval int) {
 	c.log.Info("[accrual:Controller:Run] start interaction with loyalty system, requests interval '%d' seconds", requestInterval)
+	c.backoffBase = time.Duration(requestInterval) * time.Second
 
 	go c.requestCalculationsResult(ctx, time.Duration(requestInterval))
 	go c.updateOrders(ctx)
 }
 
+// Shutdown stops scheduling of new jobs, waits for in-flight jobs and saves their results till ctx is done.
+// Run's context has to stay alive till Shutdown returns, its cancellation interrupts in-flight requests.
+func (c *Controller) Shutdown(ctx context.Context) error {
+	c.log.Info("[accrual:Controller:Shutdown] stop scheduling new jobs")
+	close(c.stop)
+	if err := waitDone(ctx, c.schedulerDone); err != nil {
+		return fmt.Errorf("stop jobs scheduling: %w", err)
+	}
+
+	c.log.Info("[accrual:Controller:Shutdown] wait for in-flight jobs")
+	if err := c.workersPool.Shutdown(ctx); err != nil {
+		return fmt.Errorf("finish in-flight jobs: %w", err)
+	}
+
+	c.log.Info("[accrual:Controller:Shutdown] flush orders updates")
+	if err := waitDone(ctx, c.updatesDone); err != nil {
+		return fmt.Errorf("flush orders updates: %w", err)
+	}
+
+	c.log.Info("[accrual:Controller:Shutdown] accrual interaction has been stopped")
+	return nil
+}
+
+func waitDone(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
 func (c *Controller) requestCalculationsResult(ctx context.Context, requestInterval time.Duration) {
+	defer close(c.schedulerDone)
+
 	ticker := time.NewTicker(requestInterval * time.Second)
 	defer ticker.Stop()
 
@@ -62,88 +140,247 @@ func (c *Controller) requestCalculationsResult(ctx context.Context, requestInter
 		case <-ctx.Done():
 			c.log.Info("[accrual:Controller:requestCalculationsResult] requests task is stopping by context")
 			return
+		case <-c.stop:
+			c.log.Info("[accrual:Controller:requestCalculationsResult] requests task is stopped")
+			return
 		case <-ticker.C:
-			c.processOrders(ctx, c.workersPool)
+			c.resizeWorkers()
+			c.processOrders(ctx)
 		}
 	}
 }
 
-func (c *Controller) processOrders(ctx context.Context, workersPool *workerspool.Pool) {
-	orders, err := c.ordersStorage.GetOrders(ctx, map[string]interface{}{queries.Custom: fmt.Sprintf("orders.status_id <= %d", data.StatusInvalid)})
+func (c *Controller) processOrders(ctx context.Context) {
+	if c.breaker.State() == breaker.StateOpen {
+		c.log.Info("[accrual:Controller:processOrders] polling is paused, accrual system is unavailable")
+		return
+	}
+
+	// orders are claimed for free queue places only, so scheduling never waits for workers.
+	stats := c.workersPool.Stats()
+	freeSlots := stats.QueueCapacity - stats.QueueLength
+	if freeSlots <= 0 {
+		c.log.Info("[accrual:Controller:processOrders] jobs queue is full, '%d' jobs are waiting", stats.QueueLength)
+		return
+	}
+
+	jobs, err := c.ordersStorage.ClaimAccrualJobs(ctx, freeSlots, claimLease)
 	if err != nil {
-		c.log.Info("[accrual:Controller:requestCalculationsResult] failed to get orders with PROCESSING status: %v", err)
+		c.log.Info("[accrual:Controller:processOrders] failed to claim orders for polling: %v", err)
 		return
 	}
 
-	for i := 0; i < len(orders); i++ {
-		c.addJobForWorkers(ctx, workersPool, orders[i])
+	for i := 0; i < len(jobs); i++ {
+		select {
+		case <-c.stop:
+			// jobs left are returned to schedule after claim lease expiration.
+			return
+		default:
+			if err = c.workersPool.Submit(ctx, c.createJob(jobs[i])); err != nil {
+				c.log.Info("[accrual:Controller:processOrders] failed to submit order '%s' polling: %v", jobs[i].Order.Number, err)
+				return
+			}
+		}
 	}
 }
 
-func (c *Controller) addJobForWorkers(ctx context.Context, workersPool *workerspool.Pool, order data.Order) {
-	workersPool.AddJob(func() (*data.Order, error) {
-		respStatus, pause, err := c.client.RequestCalculationResult(ctx, c.accrualAddr, &order)
+// createJob creates job polling order's calculation result. Job returns order if its status has to be updated.
+func (c *Controller) createJob(job data.AccrualJob) workerspool.Job[*data.Order] {
+	return func(ctx context.Context) (*data.Order, error) {
+		order := job.Order
+		respStatus, throttling, err := c.requestCalculation(ctx, &order)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
+				// claim lease expires and order is polled again later.
 				c.log.Info("[accrual:Controller:requestCalculationsResult] requests task was interrupted: %v", err)
 				return nil, nil
 			}
 
+			if errors.Is(err, breaker.ErrOpen) {
+				// accrual system is down, order's attempt isn't counted, it is polled again after lease expiration.
+				c.log.Info("[accrual:Controller:requestCalculationsResult] request for order '%s' is rejected: %v", order.Number, err)
+				return nil, nil
+			}
+
 			c.log.Info("[accrual:Controller:requestCalculationsResult] failed ('%d') to get calculation from loyalty system for order '%v': %v", respStatus, order, err)
+			c.rescheduleJob(ctx, &job, 0)
 			return nil, fmt.Errorf("request to accrual system: %w", err)
 		}
 
-		if needPauseRequests(respStatus, pause) {
-			c.pauseRequest(ctx, pause)
-			return nil, fmt.Errorf("request skipped, accrual was overload: %w", err)
-		} else if respStatus == http.StatusOK {
-			return &order, nil
+		accrualStatus := order.Status
+		switch respStatus {
+		case http.StatusOK:
+		case http.StatusNoContent:
+			accrualStatus = statemachine.NotRegistered
+		case http.StatusTooManyRequests:
+			c.rescheduleJob(ctx, &job, time.Duration(throttling.RetryAfter)*time.Second)
+			return nil, fmt.Errorf("request skipped, accrual is overloaded")
+		default:
+			c.rescheduleJob(ctx, &job, 0)
+			return nil, fmt.Errorf("request to accrual finished with status '%d'", respStatus)
 		}
 
-		return nil, fmt.Errorf("request to accrual finished with status '%d' and error: %v", respStatus, err)
-	})
+		nextStatus, err := statemachine.Next(job.Order.Status, accrualStatus)
+		if err != nil {
+			c.log.Info("[accrual:Controller:requestCalculationsResult] order '%s' update is rejected: %v", order.Number, err)
+			c.rescheduleJob(ctx, &job, 0)
+			return nil, fmt.Errorf("apply accrual status: %w", err)
+		}
+
+		order.Status = nextStatus
+		if nextStatus != statemachine.Processed {
+			order.Accrual = job.Order.Accrual
+		}
+
+		if !data.IsFinalStatus(data.GetOrderStatusID(nextStatus)) {
+			c.rescheduleJob(ctx, &job, 0)
+			if nextStatus == job.Order.Status {
+				return nil, nil
+			}
+		}
+
+		return &order, nil
+	}
 }
 
-func needPauseRequests(respStatus client.ResponseStatus, pause client.RetryInterval) bool {
-	return respStatus == http.StatusTooManyRequests && pause != 0
+// resizeWorkers adapts workers count to accrual latency. Queue backlog gets extra worker while accrual responds fast,
+// workers are removed when accrual slows down or some of them are idle.
+func (c *Controller) resizeWorkers() {
+	stats := c.workersPool.Stats()
+	latency := c.latency.Average()
+
+	workers := stats.Workers
+	switch {
+	case latency > slowLatency:
+		workers--
+	case stats.QueueLength > 0:
+		workers++
+	case stats.InFlight < int64(stats.Workers):
+		workers--
+	}
+
+	workers = max(min(workers, c.maxWorkers), c.minWorkers, 1)
+
+	if workers != stats.Workers {
+		c.log.Info("[accrual:Controller:resizeWorkers] accrual average latency '%v', queue length '%d'", latency, stats.QueueLength)
+		c.workersPool.Resize(workers)
+	}
 }
 
-func (c *Controller) pauseRequest(ctx context.Context, interval client.RetryInterval) {
-	c.log.Info("[accrual:Controller:pauseRequest] start request pause '%d' duration", interval)
-	timer := time.NewTimer(time.Duration(interval) * time.Second)
+// rescheduleJob postpones next order's poll with exponential backoff, but not less than minDelay.
+// Orders older than max age are removed from polling schedule.
+func (c *Controller) rescheduleJob(ctx context.Context, job *data.AccrualJob, minDelay time.Duration) {
+	if c.maxAge > 0 && time.Since(job.Order.UploadedAt) > c.maxAge {
+		c.log.Info("[accrual:Controller:rescheduleJob] order '%s' polling is stopped, it exceeds max age '%v'", job.Order.Number, c.maxAge)
+		if err := c.ordersStorage.DeleteAccrualJob(ctx, int64(job.Order.ID)); err != nil {
+			c.log.Info("[accrual:Controller:rescheduleJob] failed to remove order '%s' from polling: %v", job.Order.Number, err)
+		}
+		return
+	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			c.log.Info("[accrual:Controller:pauseRequest] pause has been stopped by context")
-			return
-		case <-timer.C:
-			c.log.Info("[accrual:Controller:pauseRequest] pause has been finished")
-			return
+	delay := c.backoff(job.Attempts)
+	if delay < minDelay {
+		delay = minDelay
+	}
+
+	if err := c.ordersStorage.RescheduleAccrualJob(ctx, job, delay); err != nil {
+		c.log.Info("[accrual:Controller:rescheduleJob] failed to reschedule order '%s' polling: %v", job.Order.Number, err)
+	}
+}
+
+// backoff returns pause before the next poll after attempts unsuccessful ones.
+func (c *Controller) backoff(attempts int) time.Duration {
+	if attempts > maxBackoffShift {
+		attempts = maxBackoffShift
+	}
+
+	delay := c.backoffBase << attempts
+	if c.maxBackoff > 0 && delay > c.maxBackoff {
+		delay = c.maxBackoff
+	}
+
+	return delay
+}
+
+// requestCalculation requests order's calculation result within shared requests rate limit.
+// Throttled request is repeated after pause requested by accrual system.
+func (c *Controller) requestCalculation(ctx context.Context, order *data.Order) (client.ResponseStatus, client.Throttling, error) {
+	var respStatus client.ResponseStatus
+	var throttling client.Throttling
+	var err error
+	for i := 0; i <= maxThrottledRetries; i++ {
+		if err = c.limiter.Wait(ctx); err != nil {
+			return http.StatusInternalServerError, client.Throttling{}, fmt.Errorf("wait for requests limiter: %w", err)
 		}
+
+		requestStart := time.Now()
+		respStatus, throttling, err = c.client.RequestCalculationResult(ctx, c.accrualAddr, order)
+		c.latency.Observe(time.Since(requestStart))
+		if err != nil || respStatus != http.StatusTooManyRequests {
+			return respStatus, throttling, err
+		}
+
+		c.throttle(throttling)
+	}
+
+	return respStatus, throttling, nil
+}
+
+// throttle adapts requests limiter of all workers to accrual system's limits.
+func (c *Controller) throttle(throttling client.Throttling) {
+	c.log.Info("[accrual:Controller:throttle] accrual system is overloaded, retry after '%d' seconds, allowed rate '%d' per minute",
+		throttling.RetryAfter, throttling.RequestsPerMinute)
+
+	if throttling.RequestsPerMinute > 0 {
+		c.limiter.SetRate(throttling.RequestsPerMinute)
 	}
+	c.limiter.Pause(time.Duration(throttling.RetryAfter) * time.Second)
 }
 
 func (c *Controller) updateOrders(ctx context.Context) {
-	chIn := c.workersPool.GetResultChan()
+	defer close(c.updatesDone)
 
+	chIn := c.workersPool.Results()
+	ticker := time.NewTicker(updatesFlushInterval)
+	defer ticker.Stop()
+
+	// results are buffered for short window and saved in single transaction.
+	batch := make([]data.Order, 0, updatesBatchSize)
 	for {
 		select {
 		case <-ctx.Done():
 			c.log.Info("[accrual:Controller:updateOrders] update orders task is stopping by context")
 			return
+		case <-ticker.C:
+			batch = c.saveOrders(ctx, batch)
 		case order, ok := <-chIn:
 			if !ok {
+				c.saveOrders(ctx, batch)
 				c.log.Info("[accrual:Controller:updateOrders] stop action. channel was closed.")
 				return
 			}
 
-			orderStatusID := data.GetOrderStatusID(order.Status)
-			if orderStatusID > data.StatusProcessing {
-				if err := c.ordersStorage.UpdateOrder(ctx, order); err != nil {
-					c.log.Info("[accrual:Controller:updateOrders] error occurred during order '%v' update in db: %v", order, err)
-				}
+			if order == nil {
+				continue
+			}
+
+			batch = append(batch, *order)
+			if len(batch) >= updatesBatchSize {
+				batch = c.saveOrders(ctx, batch)
 			}
 		}
 	}
 }
+
+// saveOrders writes buffered orders in storage and returns new empty buffer.
+// Orders of failed batch stay in polling schedule and are requested again after claim lease expiration.
+func (c *Controller) saveOrders(ctx context.Context, orders []data.Order) []data.Order {
+	if len(orders) == 0 {
+		return orders
+	}
+
+	if err := c.ordersStorage.UpdateOrders(ctx, orders); err != nil {
+		c.log.Info("[accrual:Controller:saveOrders] error occurred during '%d' orders update in db: %v", len(orders), err)
+	}
+	return make([]data.Order, 0, updatesBatchSize)
+}
@@ -0,0 +1,293 @@
+package accrual
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/accrual/client"
+	"github.com/erupshis/bonusbridge/internal/accrual/ratelimiter"
+	"github.com/erupshis/bonusbridge/internal/accrual/workerspool"
+	"github.com/erupshis/bonusbridge/internal/config"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController_processOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	processed := data.AccrualJob{Order: data.Order{ID: 1, Number: "1", Status: "NEW", UploadedAt: now}}
+	processing := data.AccrualJob{Order: data.Order{ID: 2, Number: "2", Status: "NEW", UploadedAt: now}, Attempts: 2}
+	failed := data.AccrualJob{Order: data.Order{ID: 3, Number: "3", Status: "PROCESSING", UploadedAt: now}, Attempts: 10}
+	expired := data.AccrualJob{Order: data.Order{ID: 4, Number: "4", Status: "PROCESSING", UploadedAt: now.Add(-2 * time.Hour)}}
+
+	mockClient := mocks.NewMockBaseClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusTooManyRequests), client.Throttling{RequestsPerMinute: 6000}, nil),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, order *data.Order) (client.ResponseStatus, client.Throttling, error) {
+				order.Status = "PROCESSED"
+				order.Accrual = money.New(500, 0)
+				return http.StatusOK, client.Throttling{}, nil
+			}),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, order *data.Order) (client.ResponseStatus, client.Throttling, error) {
+				order.Status = "PROCESSING"
+				return http.StatusOK, client.Throttling{}, nil
+			}),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusInternalServerError), client.Throttling{}, fmt.Errorf("client error")),
+		mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(client.ResponseStatus(http.StatusNoContent), client.Throttling{}, nil),
+	)
+
+	done := make(chan struct{}, 3)
+	reschedule := func(_ context.Context, _ *data.AccrualJob, _ time.Duration) { done <- struct{}{} }
+	remove := func(_ context.Context, _ int64) { done <- struct{}{} }
+
+	mockStorage := mocks.NewMockBaseOrdersStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().ClaimAccrualJobs(gomock.Any(), 10, claimLease).
+			Return([]data.AccrualJob{processed, processing, failed, expired}, nil),
+		mockStorage.EXPECT().RescheduleAccrualJob(gomock.Any(), &processing, 20*time.Second).Do(reschedule).Return(nil),
+		mockStorage.EXPECT().RescheduleAccrualJob(gomock.Any(), &failed, time.Minute).Do(reschedule).Return(nil),
+		mockStorage.EXPECT().DeleteAccrualJob(gomock.Any(), int64(4)).Do(remove).Return(nil),
+	)
+
+	workersPool := workerspool.Create[*data.Order](context.Background(), 1, 10, workerspool.PolicyReject, log)
+	defer func() {
+		_ = workersPool.Shutdown(context.Background())
+	}()
+
+	limiter := ratelimiter.Create(0, 1, log)
+	c := &Controller{
+		ordersStorage: mockStorage,
+		client:        mockClient,
+		workersPool:   workersPool,
+		latency:       &latencyTracker{},
+		limiter:       limiter,
+		breaker:       breaker.Create(1, time.Minute, log),
+		backoffBase:   5 * time.Second,
+		maxBackoff:    time.Minute,
+		maxAge:        time.Hour,
+		log:           log,
+	}
+	go c.processOrders(context.Background())
+
+	wantResults := []data.Order{
+		{ID: 1, Number: "1", Status: "PROCESSED", Accrual: money.New(500, 0), UploadedAt: now},
+		// status change of not finished order is saved too.
+		{ID: 2, Number: "2", Status: "PROCESSING", UploadedAt: now},
+	}
+	for _, want := range wantResults {
+		select {
+		case order := <-workersPool.Results():
+			assert.Equal(t, want, *order)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "order is missing in results")
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "orders are not rescheduled")
+		}
+	}
+
+	// throttled request adapts limiter to accrual system's rate.
+	assert.Equal(t, 6000, limiter.RequestsPerMinute())
+}
+
+func TestController_backoff(t *testing.T) {
+	c := &Controller{
+		backoffBase: 5 * time.Second,
+		maxBackoff:  10 * time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt", attempts: 0, want: 5 * time.Second},
+		{name: "exponential growth", attempts: 3, want: 40 * time.Second},
+		{name: "limited by max backoff", attempts: 10, want: 10 * time.Minute},
+		{name: "huge attempts count", attempts: 1000, want: 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.backoff(tt.attempts))
+		})
+	}
+}
+
+func TestController_processOrdersWithOpenBreaker(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accrualBreaker := breaker.Create(1, time.Minute, log)
+	accrualBreaker.Failure(fmt.Errorf("connection refused"))
+
+	// orders aren't claimed while accrual system is down.
+	mockStorage := mocks.NewMockBaseOrdersStorage(ctrl)
+	mockStorage.EXPECT().ClaimAccrualJobs(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	c := &Controller{
+		ordersStorage: mockStorage,
+		breaker:       accrualBreaker,
+		log:           log,
+	}
+	c.processOrders(context.Background())
+}
+
+func TestController_Shutdown(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	job := data.AccrualJob{Order: data.Order{ID: 1, Number: "1", Status: "PROCESSING", UploadedAt: time.Now()}}
+
+	requested := make(chan struct{})
+	mockClient := mocks.NewMockBaseClient(ctrl)
+	mockClient.EXPECT().RequestCalculationResult(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, order *data.Order) (client.ResponseStatus, client.Throttling, error) {
+			close(requested)
+			time.Sleep(200 * time.Millisecond)
+			order.Status = "PROCESSED"
+			return http.StatusOK, client.Throttling{}, nil
+		})
+
+	mockStorage := mocks.NewMockBaseOrdersStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().ClaimAccrualJobs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]data.AccrualJob{job}, nil),
+		// in-flight job result is saved during shutdown.
+		mockStorage.EXPECT().UpdateOrders(gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	c := CreateController(mockStorage, nil, mockClient, workerspool.Create[*data.Order](context.Background(), 1, 10, workerspool.PolicyReject, log), ratelimiter.Create(0, 1, log),
+		breaker.Create(1, time.Minute, log), config.Config{}, log)
+	c.Run(context.Background(), 1)
+
+	select {
+	case <-requested:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "job wasn't started")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, c.Shutdown(ctx))
+}
+
+func TestController_resizeWorkers(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	tests := []struct {
+		name        string
+		workers     int
+		queueLength int
+		latency     time.Duration
+		want        int
+	}{
+		{name: "backlog with fast accrual", workers: 2, queueLength: 3, latency: 100 * time.Millisecond, want: 3},
+		{name: "backlog limited by max workers", workers: 4, queueLength: 3, latency: 100 * time.Millisecond, want: 4},
+		{name: "slow accrual", workers: 3, queueLength: 3, latency: 2 * time.Second, want: 2},
+		{name: "idle workers", workers: 3, queueLength: 0, latency: 100 * time.Millisecond, want: 2},
+		{name: "limited by min workers", workers: 1, queueLength: 0, latency: 2 * time.Second, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			workersPool := workerspool.Create[*data.Order](context.Background(), tt.workers, 10, workerspool.PolicyReject, log)
+			defer func() {
+				close(release)
+				_ = workersPool.Shutdown(context.Background())
+			}()
+
+			// queue is filled while workers are stopped by resize waiting for release.
+			if tt.queueLength > 0 {
+				for i := 0; i < tt.workers+tt.queueLength; i++ {
+					require.NoError(t, workersPool.Submit(context.Background(), func(_ context.Context) (*data.Order, error) {
+						<-release
+						return nil, nil
+					}))
+				}
+				require.Eventually(t, func() bool {
+					return workersPool.Stats().QueueLength == tt.queueLength
+				}, 5*time.Second, 10*time.Millisecond)
+			}
+
+			c := &Controller{
+				workersPool: workersPool,
+				minWorkers:  1,
+				maxWorkers:  4,
+				latency:     &latencyTracker{},
+				log:         log,
+			}
+			c.latency.Observe(tt.latency)
+			c.resizeWorkers()
+
+			assert.Equal(t, tt.want, workersPool.Stats().Workers)
+		})
+	}
+}
+
+func TestController_updateOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orders := []data.Order{
+		{ID: 1, Number: "1", Status: "PROCESSED", Accrual: money.New(500, 0)},
+		{ID: 2, Number: "2", Status: "INVALID"},
+	}
+
+	// results are saved by single batch.
+	mockStorage := mocks.NewMockBaseOrdersStorage(ctrl)
+	mockStorage.EXPECT().UpdateOrders(gomock.Any(), orders).Return(nil)
+
+	workersPool := workerspool.Create[*data.Order](context.Background(), 1, 10, workerspool.PolicyReject, log)
+	for i := range orders {
+		order := orders[i]
+		require.NoError(t, workersPool.Submit(context.Background(), func(_ context.Context) (*data.Order, error) {
+			return &order, nil
+		}))
+	}
+	// nil result means order doesn't need update.
+	require.NoError(t, workersPool.Submit(context.Background(), func(_ context.Context) (*data.Order, error) {
+		return nil, nil
+	}))
+	require.NoError(t, workersPool.Shutdown(context.Background()))
+
+	c := &Controller{
+		ordersStorage: mockStorage,
+		workersPool:   workersPool,
+		updatesDone:   make(chan struct{}),
+		log:           log,
+	}
+	c.updateOrders(context.Background())
+}
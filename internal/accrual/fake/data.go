@@ -0,0 +1,23 @@
+package fake
+
+import (
+	"github.com/erupshis/bonusbridge/internal/money"
+)
+
+// Accrual system order statuses.
+const (
+	StatusRegistered = "REGISTERED"
+	StatusProcessing = "PROCESSING"
+	StatusInvalid    = "INVALID"
+	StatusProcessed  = "PROCESSED"
+)
+
+//go:generate easyjson -all data.go
+type Response struct {
+	Order   string       `json:"order"`
+	Status  string       `json:"status"`
+	Accrual money.Amount `json:"accrual,omitempty"`
+}
+
+// Scripts orders responses scripts in format of ParseScript keyed by order number.
+type Scripts map[string]string
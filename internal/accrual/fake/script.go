@@ -0,0 +1,47 @@
+package fake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erupshis/bonusbridge/internal/money"
+)
+
+var ErrInvalidScript = fmt.Errorf("invalid order script")
+
+// Step order's state returned by one request. Order moves to the next step with every request, the last step is repeated.
+type Step struct {
+	Status  string
+	Accrual money.Amount
+}
+
+// ParseScript parses comma separated steps 'STATUS[:accrual]', e.g. 'REGISTERED,PROCESSING,PROCESSED:500'.
+// Accrual is allowed for PROCESSED status only.
+func ParseScript(script string) ([]Step, error) {
+	var steps []Step
+	for _, rawStep := range strings.Split(script, ",") {
+		status, rawAccrual, hasAccrual := strings.Cut(strings.TrimSpace(rawStep), ":")
+
+		step := Step{Status: strings.ToUpper(status)}
+		switch step.Status {
+		case StatusRegistered, StatusProcessing, StatusInvalid:
+			if hasAccrual {
+				return nil, fmt.Errorf("%w: accrual in '%s' step", ErrInvalidScript, rawStep)
+			}
+		case StatusProcessed:
+			if hasAccrual {
+				accrual, err := money.Parse(rawAccrual)
+				if err != nil {
+					return nil, fmt.Errorf("%w: accrual in '%s' step: %v", ErrInvalidScript, rawStep, err)
+				}
+				step.Accrual = accrual
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown status in '%s' step", ErrInvalidScript, rawStep)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    []Step
+		wantErr bool
+	}{
+		{
+			name:   "full script",
+			script: "REGISTERED, processing,PROCESSED:500.5",
+			want: []Step{
+				{Status: StatusRegistered},
+				{Status: StatusProcessing},
+				{Status: StatusProcessed, Accrual: money.New(500, 50)},
+			},
+		},
+		{
+			name:   "invalid order",
+			script: "INVALID",
+			want:   []Step{{Status: StatusInvalid}},
+		},
+		{
+			name:    "unknown status",
+			script:  "REGISTERED,DONE",
+			wantErr: true,
+		},
+		{
+			name:    "accrual for not processed order",
+			script:  "INVALID:10",
+			wantErr: true,
+		},
+		{
+			name:    "invalid accrual",
+			script:  "PROCESSED:ten",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScript(tt.script)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidScript)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
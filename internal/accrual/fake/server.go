@@ -0,0 +1,182 @@
+// Package fake accrual system simulator implementing 'GET /api/orders/{number}' protocol with scripted responses.
+// Used by cmd/accrualmock for local development and as httptest.Server in tests.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+const urlParamNumber = "number"
+
+// Config simulator's behaviour.
+type Config struct {
+	RateLimit     int           // RateLimit requests per minute, exceeded requests get 429 response. Zero - unlimited.
+	RetryAfter    int           // RetryAfter 'Retry-After' header value in seconds for 429 responses.
+	Latency       time.Duration // Latency delay before every response.
+	FailEvery     int           // FailEvery every n-th request gets 500 response. Zero - no failures.
+	DefaultScript []Step        // DefaultScript script for unknown orders. Unknown orders get 204 response if it is empty.
+}
+
+type order struct {
+	script   []Step
+	step     int
+	requests int
+}
+
+type Server struct {
+	mu sync.Mutex
+
+	cfg    Config
+	orders map[string]*order
+
+	requests    int
+	windowStart time.Time
+	windowCount int
+
+	now func() time.Time
+	log logger.BaseLogger
+}
+
+func Create(cfg Config, log logger.BaseLogger) *Server {
+	return &Server{
+		cfg:    cfg,
+		orders: make(map[string]*order),
+		now:    time.Now,
+		log:    log,
+	}
+}
+
+// CreateTestServer creates simulator and starts it as httptest.Server. Server has to be closed by caller.
+func CreateTestServer(cfg Config, log logger.BaseLogger) (*Server, *httptest.Server) {
+	s := Create(cfg, log)
+	return s, httptest.NewServer(s.Route())
+}
+
+func (s *Server) Route() *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/api/orders/{"+urlParamNumber+"}", s.getOrder)
+	return r
+}
+
+// Register sets order's responses script. Script is restarted for already registered order.
+func (s *Server) Register(number string, script ...Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.orders[number] = &order{script: script}
+}
+
+// RegisterScripts registers orders with scripts in ParseScript format.
+func (s *Server) RegisterScripts(scripts Scripts) error {
+	for number, rawScript := range scripts {
+		script, err := ParseScript(rawScript)
+		if err != nil {
+			return fmt.Errorf("register order '%s': %w", number, err)
+		}
+
+		s.Register(number, script...)
+	}
+
+	return nil
+}
+
+// Requests returns count of order requests answered according to its script.
+func (s *Server) Requests(number string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o, ok := s.orders[number]; ok {
+		return o.requests
+	}
+	return 0
+}
+
+func (s *Server) getOrder(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.Latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(s.cfg.Latency):
+		}
+	}
+
+	number := chi.URLParam(r, urlParamNumber)
+	statusCode, resp := s.nextResponse(number)
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		w.Header().Set("Retry-After", strconv.Itoa(s.cfg.RetryAfter))
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprintf(w, "No more than %d requests per minute allowed", s.cfg.RateLimit)
+	case http.StatusOK:
+		respBody, err := json.Marshal(resp)
+		if err != nil {
+			s.log.Info("[accrual:fake:getOrder] failed convert response to JSON: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(respBody)
+	default:
+		w.WriteHeader(statusCode)
+	}
+
+	s.log.Info("[accrual:fake:getOrder] order '%s' request finished with status '%d'", number, statusCode)
+}
+
+// nextResponse applies rate limit and failures injection and moves order's script to the next step.
+func (s *Server) nextResponse(number string) (int, *Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cfg.RateLimit > 0 {
+		now := s.now()
+		if now.Sub(s.windowStart) >= time.Minute {
+			s.windowStart = now
+			s.windowCount = 0
+		}
+
+		if s.windowCount >= s.cfg.RateLimit {
+			return http.StatusTooManyRequests, nil
+		}
+		s.windowCount++
+	}
+
+	s.requests++
+	if s.cfg.FailEvery > 0 && s.requests%s.cfg.FailEvery == 0 {
+		return http.StatusInternalServerError, nil
+	}
+
+	o, ok := s.orders[number]
+	if !ok {
+		if len(s.cfg.DefaultScript) == 0 {
+			return http.StatusNoContent, nil
+		}
+
+		o = &order{script: s.cfg.DefaultScript}
+		s.orders[number] = o
+	}
+
+	o.requests++
+	if len(o.script) == 0 {
+		return http.StatusNoContent, nil
+	}
+
+	step := o.script[o.step]
+	if o.step < len(o.script)-1 {
+		o.step++
+	}
+
+	return http.StatusOK, &Response{Order: number, Status: step.Status, Accrual: step.Accrual}
+}
@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/client"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	simulator, ts := CreateTestServer(Config{RateLimit: 6, RetryAfter: 30, FailEvery: 5}, log)
+	defer ts.Close()
+
+	require.NoError(t, simulator.RegisterScripts(Scripts{"1": "REGISTERED,PROCESSING,PROCESSED:500"}))
+	simulator.Register("2", Step{Status: StatusInvalid})
+
+	accrualClient := client.CreateDefault(time.Second, log)
+	tests := []struct {
+		name           string
+		number         string
+		wantStatus     client.ResponseStatus
+		wantThrottling client.Throttling
+		wantOrder      data.Order
+	}{
+		{name: "registered", number: "1", wantStatus: http.StatusOK, wantOrder: data.Order{Number: "1", Status: StatusRegistered}},
+		{name: "processing", number: "1", wantStatus: http.StatusOK, wantOrder: data.Order{Number: "1", Status: StatusProcessing}},
+		{name: "processed", number: "1", wantStatus: http.StatusOK, wantOrder: data.Order{Number: "1", Status: StatusProcessed, Accrual: money.New(500, 0)}},
+		{name: "unknown order", number: "3", wantStatus: http.StatusNoContent, wantOrder: data.Order{Number: "3"}},
+		{name: "injected failure", number: "2", wantStatus: http.StatusInternalServerError, wantOrder: data.Order{Number: "2"}},
+		{name: "last step is repeated", number: "1", wantStatus: http.StatusOK, wantOrder: data.Order{Number: "1", Status: StatusProcessed, Accrual: money.New(500, 0)}},
+		{
+			name:           "rate limit",
+			number:         "2",
+			wantStatus:     http.StatusTooManyRequests,
+			wantThrottling: client.Throttling{RetryAfter: 30, RequestsPerMinute: 6},
+			wantOrder:      data.Order{Number: "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := data.Order{Number: tt.number}
+			status, throttling, err := accrualClient.RequestCalculationResult(context.Background(), ts.URL, &order)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantThrottling, throttling)
+			assert.Equal(t, tt.wantOrder, order)
+		})
+	}
+
+	assert.Equal(t, 4, simulator.Requests("1"))
+	assert.Equal(t, 0, simulator.Requests("2"))
+
+	// limit is reset in the next minute.
+	simulator.now = func() time.Time { return time.Now().Add(time.Minute) }
+	order := data.Order{Number: "2"}
+	status, _, err := accrualClient.RequestCalculationResult(context.Background(), ts.URL, &order)
+	require.NoError(t, err)
+	assert.Equal(t, client.ResponseStatus(http.StatusOK), status)
+	assert.Equal(t, StatusInvalid, order.Status)
+}
+
+func TestServer_DefaultScriptAndLatency(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	_, ts := CreateTestServer(Config{
+		Latency:       100 * time.Millisecond,
+		DefaultScript: []Step{{Status: StatusProcessed, Accrual: money.New(10, 50)}},
+	}, log)
+	defer ts.Close()
+
+	order := data.Order{Number: "42"}
+	status, _, err := client.CreateDefault(time.Second, log).RequestCalculationResult(context.Background(), ts.URL, &order)
+	require.NoError(t, err)
+	assert.Equal(t, client.ResponseStatus(http.StatusOK), status)
+	assert.Equal(t, money.New(10, 50), order.Accrual)
+
+	_, _, err = client.CreateDefault(50*time.Millisecond, log).RequestCalculationResult(context.Background(), ts.URL, &order)
+	assert.ErrorIs(t, err, client.ErrConnection)
+}
@@ -0,0 +1,33 @@
+package accrual
+
+import (
+	"sync"
+	"time"
+)
+
+// latencyWeight weight of the latest observation in moving average.
+const latencyWeight = 5
+
+// latencyTracker exponentially weighted moving average of accrual system requests latency.
+type latencyTracker struct {
+	mu  sync.Mutex
+	avg time.Duration
+}
+
+func (l *latencyTracker) Observe(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.avg == 0 {
+		l.avg = latency
+		return
+	}
+	l.avg += (latency - l.avg) / latencyWeight
+}
+
+func (l *latencyTracker) Average() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.avg
+}
@@ -0,0 +1,137 @@
+// Package ratelimiter token bucket shared by accrual workers to keep requests rate within accrual system's limits.
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+type Limiter struct {
+	mu sync.Mutex
+
+	rate        float64 // rate tokens per second, zero means unlimited.
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	now func() time.Time
+	log logger.BaseLogger
+}
+
+// Create creates limiter with requestsPerMinute rate(zero - unlimited) and up to burst requests at once.
+func Create(requestsPerMinute int, burst int, log logger.BaseLogger) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	l := &Limiter{
+		burst: float64(burst),
+		now:   time.Now,
+		log:   log,
+	}
+	l.last = l.now()
+	l.tokens = l.burst
+	l.setRate(requestsPerMinute)
+	return l
+}
+
+// Wait blocks till request is allowed or context is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Pause holds all requests for duration. Bucket is emptied, so requests are resumed at limited rate.
+func (l *Limiter) Pause(duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pausedUntil := l.now().Add(duration)
+	if pausedUntil.After(l.pausedUntil) {
+		l.log.Info("[accrual:Limiter:Pause] requests are paused for '%v'", duration)
+		l.pausedUntil = pausedUntil
+	}
+	l.tokens = 0
+	l.last = l.pausedUntil
+}
+
+// SetRate changes allowed requests rate.
+func (l *Limiter) SetRate(requestsPerMinute int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(l.now())
+	l.setRate(requestsPerMinute)
+}
+
+// RequestsPerMinute returns current allowed requests rate, zero - unlimited.
+func (l *Limiter) RequestsPerMinute() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int(l.rate*60 + 0.5)
+}
+
+func (l *Limiter) setRate(requestsPerMinute int) {
+	if requestsPerMinute < 0 {
+		requestsPerMinute = 0
+	}
+
+	if rate := float64(requestsPerMinute) / 60; rate != l.rate {
+		l.log.Info("[accrual:Limiter:setRate] requests rate is set to '%d' per minute", requestsPerMinute)
+		l.rate = rate
+	}
+}
+
+// reserve takes token if it is available, otherwise returns time to wait for the next one.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.rate == 0 {
+		return 0
+	}
+
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if delay < time.Millisecond {
+		delay = time.Millisecond
+	}
+	return delay
+}
+
+func (l *Limiter) refill(now time.Time) {
+	if now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+}
@@ -0,0 +1,59 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_reserve(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	now := time.Now()
+	l := Create(60, 2, log)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	// burst is available at once.
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Second, l.reserve())
+
+	// token is refilled with time.
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Second, l.reserve())
+
+	// pause holds requests and empties bucket.
+	l.Pause(10 * time.Second)
+	assert.Equal(t, 10*time.Second, l.reserve())
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, time.Second, l.reserve())
+
+	// rate is adapted.
+	l.SetRate(120)
+	assert.Equal(t, 120, l.RequestsPerMinute())
+	assert.Equal(t, 500*time.Millisecond, l.reserve())
+
+	// unlimited rate.
+	l.SetRate(0)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, time.Duration(0), l.reserve())
+	}
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	l := Create(60, 1, log)
+	assert.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,59 @@
+// Package statemachine maps accrual system order statuses to the service ones and validates order status transitions.
+package statemachine
+
+import (
+	"fmt"
+
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+)
+
+var ErrUnknownStatus = fmt.Errorf("unknown accrual status")
+var ErrIllegalTransition = fmt.Errorf("illegal order status transition")
+
+// Accrual system statuses. NotRegistered is pseudo status for order unknown to accrual system(204 response).
+const (
+	NotRegistered = "NOT_REGISTERED"
+	Registered    = "REGISTERED"
+	Processing    = "PROCESSING"
+	Invalid       = "INVALID"
+	Processed     = "PROCESSED"
+)
+
+var accrualToOrderStatus = map[string]int{
+	NotRegistered: data.StatusNew,
+	Registered:    data.StatusProcessing,
+	Processing:    data.StatusProcessing,
+	Invalid:       data.StatusInvalid,
+	Processed:     data.StatusProcessed,
+}
+
+// transitions allowed order statuses after the current one. Final statuses have no transitions.
+var transitions = map[int][]int{
+	data.StatusNew:        {data.StatusNew, data.StatusProcessing, data.StatusInvalid, data.StatusProcessed},
+	data.StatusProcessing: {data.StatusProcessing, data.StatusInvalid, data.StatusProcessed},
+	// orders with UNDEFINED status were saved by previous service versions for REGISTERED accrual status.
+	data.StatusUndefined: {data.StatusNew, data.StatusProcessing, data.StatusInvalid, data.StatusProcessed},
+}
+
+var statusNames = map[int]string{
+	data.StatusNew:        "NEW",
+	data.StatusProcessing: "PROCESSING",
+	data.StatusInvalid:    "INVALID",
+	data.StatusProcessed:  "PROCESSED",
+}
+
+// Next returns order's status after accrual system reported accrualStatus for order in current status.
+func Next(current string, accrualStatus string) (string, error) {
+	nextID, ok := accrualToOrderStatus[accrualStatus]
+	if !ok {
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownStatus, accrualStatus)
+	}
+
+	for _, allowedID := range transitions[data.GetOrderStatusID(current)] {
+		if allowedID == nextID {
+			return statusNames[nextID], nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: from '%s' to '%s'(accrual status '%s')", ErrIllegalTransition, current, statusNames[nextID], accrualStatus)
+}
@@ -0,0 +1,41 @@
+package statemachine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       string
+		accrualStatus string
+		want          string
+		wantErr       error
+	}{
+		{name: "not registered new order", current: "NEW", accrualStatus: NotRegistered, want: "NEW"},
+		{name: "registered order", current: "NEW", accrualStatus: Registered, want: "PROCESSING"},
+		{name: "processing order", current: "NEW", accrualStatus: Processing, want: "PROCESSING"},
+		{name: "still processing order", current: "PROCESSING", accrualStatus: Processing, want: "PROCESSING"},
+		{name: "processed order", current: "PROCESSING", accrualStatus: Processed, want: "PROCESSED"},
+		{name: "invalid order", current: "NEW", accrualStatus: Invalid, want: "INVALID"},
+		{name: "legacy undefined order", current: "UNDEFINED", accrualStatus: Registered, want: "PROCESSING"},
+		{name: "processing order is lost by accrual", current: "PROCESSING", accrualStatus: NotRegistered, wantErr: ErrIllegalTransition},
+		{name: "processed order back to processing", current: "PROCESSED", accrualStatus: Processing, wantErr: ErrIllegalTransition},
+		{name: "invalid order is processed", current: "INVALID", accrualStatus: Processed, wantErr: ErrIllegalTransition},
+		{name: "unknown accrual status", current: "NEW", accrualStatus: "DONE", wantErr: ErrUnknownStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Next(tt.current, tt.accrualStatus)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
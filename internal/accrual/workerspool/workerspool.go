@@ -1,69 +1,230 @@
+// Package workerspool generic pool of workers with bounded jobs queue, runtime resizing and counters.
 package workerspool
 
 import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
 	"github.com/erupshis/bonusbridge/internal/logger"
-	"github.com/erupshis/bonusbridge/internal/orders/data"
 )
 
-type Job = func() (*data.Order, error)
+var ErrQueueFull = fmt.Errorf("jobs queue is full")
+var ErrPoolClosed = fmt.Errorf("workers pool is closed")
 
-type Pool struct {
-	jobs    chan Job
-	results chan *data.Order
+// Job unit of work. Context is the pool's one, it is canceled when pool work has to be interrupted.
+type Job[T any] func(ctx context.Context) (T, error)
 
-	log logger.BaseLogger
+// Policy behaviour of Submit when jobs queue is full.
+type Policy int
+
+const (
+	PolicyBlock  Policy = iota // PolicyBlock Submit waits for free place in queue or submit context cancellation.
+	PolicyReject               // PolicyReject Submit returns ErrQueueFull immediately.
+)
+
+// Stats pool's counters snapshot.
+type Stats struct {
+	Workers       int
+	QueueLength   int
+	QueueCapacity int
+	InFlight      int64
+	Completed     int64
+	Failed        int64
 }
 
-func Create(count int, log logger.BaseLogger) *Pool {
-	pool := &Pool{jobs: make(chan func() (*data.Order, error), count), results: make(chan *data.Order, count), log: log}
-	pool.createWorkers(count)
-	return pool
+type Pool[T any] struct {
+	ctx    context.Context
+	policy Policy
+
+	jobs    chan Job[T]
+	results chan T
+	shrink  chan struct{}
+
+	submitMu sync.RWMutex
+	closed   bool
+
+	mu      sync.Mutex
+	workers int
+	target  int
+	wg      sync.WaitGroup
+
+	inFlight  atomic.Int64
+	completed atomic.Int64
+	failed    atomic.Int64
+
+	log logger.BaseLogger
 }
 
-func (p *Pool) AddJob(job Job) {
-	p.log.Info("[accrual:WorkersPool:AddJob] new job incoming.")
-	p.jobs <- job
-	p.log.Info("[accrual:WorkersPool:AddJob] new job added.")
+// Create creates pool with workers count and jobs queue of queueSize. Successful jobs results are sent to Results channel.
+func Create[T any](ctx context.Context, workers int, queueSize int, policy Policy, log logger.BaseLogger) *Pool[T] {
+	p := &Pool[T]{
+		ctx:     ctx,
+		policy:  policy,
+		jobs:    make(chan Job[T], queueSize),
+		results: make(chan T, queueSize),
+		shrink:  make(chan struct{}),
+		log:     log,
+	}
+	p.Resize(workers)
+	return p
 }
 
-func (p *Pool) CloseJobsChan() {
-	p.log.Info("[accrual:WorkersPool:CloseJobsChan] jobs closed.")
-	close(p.jobs)
+// Submit adds job in queue according to pool's policy.
+func (p *Pool[T]) Submit(ctx context.Context, job Job[T]) error {
+	p.submitMu.RLock()
+	defer p.submitMu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	if p.policy == PolicyReject {
+		select {
+		case p.jobs <- job:
+			return nil
+		default:
+			return ErrQueueFull
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("submit job: %w", ctx.Err())
+	case p.jobs <- job:
+		return nil
+	}
 }
 
-func (p *Pool) GetResultChan() chan *data.Order {
+// Results returns channel of successful jobs results. Channel is closed after pool shutdown.
+func (p *Pool[T]) Results() <-chan T {
 	return p.results
 }
 
-func (p *Pool) CloseResultsChan() {
-	p.log.Info("[accrual:WorkersPool:CloseResultsChan] results closed.")
-	close(p.results)
+// Resize changes workers count. Extra workers stop after their current jobs.
+func (p *Pool[T]) Resize(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p.submitMu.RLock()
+	defer p.submitMu.RUnlock()
+	if p.closed {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.target != workers {
+		p.log.Info("[accrual:WorkersPool:Resize] workers count is changed from '%d' to '%d'", p.target, workers)
+	}
+
+	p.target = workers
+	for p.workers < p.target {
+		p.workers++
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	// idle workers are woken up to stop, busy ones check it after the current job.
+	for i := p.target; i < p.workers; i++ {
+		select {
+		case p.shrink <- struct{}{}:
+		default:
+		}
+	}
 }
 
-func (p *Pool) createWorkers(count int) {
-	p.log.Info("[accrual:WorkersPool:createWorkers] start '%d' workers.", count)
-	for i := 0; i < count; i++ {
-		go p.worker(i)
+// Stats returns pool's counters.
+func (p *Pool[T]) Stats() Stats {
+	p.mu.Lock()
+	workers := p.target
+	p.mu.Unlock()
+
+	return Stats{
+		Workers:       workers,
+		QueueLength:   len(p.jobs),
+		QueueCapacity: cap(p.jobs),
+		InFlight:      p.inFlight.Load(),
+		Completed:     p.completed.Load(),
+		Failed:        p.failed.Load(),
 	}
 }
 
-func (p *Pool) worker(seqNum int) {
-	//worker stops when jobs channel is closed.
-	for job := range p.jobs {
-		p.log.Info("[accrual:WorkersPool:worker] worker starts job from queue.")
-		order, err := job()
-		if err != nil {
-			p.log.Info("[accrual:WorkersPool:worker] job finished with error: %v", err)
-			continue
+// Shutdown stops accepting new jobs and waits for queued and in-flight ones till ctx is done.
+// Results channel is closed after all workers stop.
+func (p *Pool[T]) Shutdown(ctx context.Context) error {
+	p.submitMu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+		p.log.Info("[accrual:WorkersPool:Shutdown] jobs queue is closed")
+	}
+	p.submitMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+		p.log.Info("[accrual:WorkersPool:Shutdown] results channel is closed")
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("wait for workers: %w", ctx.Err())
+	case <-done:
+		return nil
+	}
+}
+
+func (p *Pool[T]) worker() {
+	defer p.wg.Done()
+
+	//worker stops when jobs channel is closed or pool is shrunk.
+	for {
+		if p.needStop() {
+			return
 		}
 
-		if order != nil {
-			p.results <- order
-			p.log.Info("[accrual:WorkersPool:worker] job result added to result chan.")
-		} else {
-			p.log.Info("[accrual:WorkersPool:worker] job failed order is nil.")
+		select {
+		case <-p.shrink:
+			continue
+		case job, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+
+			p.run(job)
 		}
 	}
+}
+
+func (p *Pool[T]) run(job Job[T]) {
+	p.inFlight.Add(1)
+	defer p.inFlight.Add(-1)
+
+	res, err := job(p.ctx)
+	if err != nil {
+		p.failed.Add(1)
+		p.log.Info("[accrual:WorkersPool:worker] job finished with error: %v", err)
+		return
+	}
+
+	p.completed.Add(1)
+	p.results <- res
+}
+
+// needStop checks whether worker is extra after pool shrinking.
+func (p *Pool[T]) needStop() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	p.log.Info("[accrual:WorkersPool:worker] worker '%d' has been stopped.", seqNum)
+	if p.workers > p.target {
+		p.workers--
+		return true
+	}
+	return false
 }
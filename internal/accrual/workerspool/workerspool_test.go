@@ -0,0 +1,160 @@
+package workerspool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_Shutdown(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	pool := Create[int](context.Background(), 2, 2, PolicyBlock, log)
+	for i := 0; i < 2; i++ {
+		i := i
+		require.NoError(t, pool.Submit(context.Background(), func(_ context.Context) (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return i, nil
+		}))
+	}
+
+	assert.NoError(t, pool.Shutdown(context.Background()))
+
+	// in-flight jobs results are kept, results channel is closed.
+	results := 0
+	for range pool.Results() {
+		results++
+	}
+	assert.Equal(t, 2, results)
+	assert.ErrorIs(t, pool.Submit(context.Background(), nil), ErrPoolClosed)
+}
+
+func TestPool_ShutdownDeadline(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	pool := Create[int](context.Background(), 1, 1, PolicyBlock, log)
+	require.NoError(t, pool.Submit(context.Background(), func(_ context.Context) (int, error) {
+		time.Sleep(time.Second)
+		return 0, nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestPool_Submit(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		wantErr error
+	}{
+		{name: "reject policy", policy: PolicyReject, wantErr: ErrQueueFull},
+		{name: "block policy", policy: PolicyBlock, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			started := make(chan struct{})
+			pool := Create[int](context.Background(), 1, 1, tt.policy, log)
+			defer func() {
+				close(release)
+				go func() {
+					for range pool.Results() {
+					}
+				}()
+				_ = pool.Shutdown(context.Background())
+			}()
+
+			busy := func(_ context.Context) (int, error) {
+				started <- struct{}{}
+				<-release
+				return 0, nil
+			}
+			require.NoError(t, pool.Submit(context.Background(), busy))
+			<-started
+			// worker is busy, queue takes one job only.
+			require.NoError(t, pool.Submit(context.Background(), func(_ context.Context) (int, error) {
+				<-release
+				return 0, nil
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			assert.ErrorIs(t, pool.Submit(ctx, busy), tt.wantErr)
+
+			stats := pool.Stats()
+			assert.Equal(t, 1, stats.QueueLength)
+			assert.Equal(t, 1, stats.QueueCapacity)
+			assert.Equal(t, int64(1), stats.InFlight)
+		})
+	}
+}
+
+func TestPool_Stats(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	pool := Create[int](context.Background(), 2, 4, PolicyBlock, log)
+	for i := 0; i < 4; i++ {
+		i := i
+		require.NoError(t, pool.Submit(context.Background(), func(_ context.Context) (int, error) {
+			if i%2 == 0 {
+				return 0, fmt.Errorf("job error")
+			}
+			return i, nil
+		}))
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+
+	stats := pool.Stats()
+	assert.Equal(t, int64(2), stats.Completed)
+	assert.Equal(t, int64(2), stats.Failed)
+	assert.Equal(t, int64(0), stats.InFlight)
+	assert.Equal(t, 0, stats.QueueLength)
+}
+
+func TestPool_Resize(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	pool := Create[int](context.Background(), 1, 8, PolicyBlock, log)
+	defer func() {
+		_ = pool.Shutdown(context.Background())
+	}()
+
+	// jobs run concurrently after growth.
+	pool.Resize(4)
+	assert.Equal(t, 4, pool.Stats().Workers)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, pool.Submit(context.Background(), func(_ context.Context) (int, error) {
+			started <- struct{}{}
+			<-release
+			return 0, nil
+		}))
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "jobs aren't run concurrently")
+		}
+	}
+	close(release)
+
+	pool.Resize(0)
+	assert.Equal(t, 1, pool.Stats().Workers)
+}
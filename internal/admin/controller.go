@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"github.com/erupshis/bonusbridge/internal/admin/handlers"
+	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	bonusesStorage "github.com/erupshis/bonusbridge/internal/bonuses/storage"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	ordersStorage "github.com/erupshis/bonusbridge/internal/orders/storage"
+	"github.com/go-chi/chi/v5"
+)
+
+// Controller support staff API. Must be mounted behind admin authorization.
+type Controller struct {
+	usersStrg   managers.BaseUsersManager
+	ordersStrg  ordersStorage.BaseOrdersStorage
+	bonusesStrg bonusesStorage.BaseBonusesStorage
+
+	log logger.BaseLogger
+}
+
+func CreateController(usersStrg managers.BaseUsersManager, ordersStrg ordersStorage.BaseOrdersStorage,
+	bonusesStrg bonusesStorage.BaseBonusesStorage, baseLogger logger.BaseLogger) Controller {
+	return Controller{
+		usersStrg:   usersStrg,
+		ordersStrg:  ordersStrg,
+		bonusesStrg: bonusesStrg,
+		log:         baseLogger,
+	}
+}
+
+func (c *Controller) RouteUsers() *chi.Mux {
+	r := chi.NewRouter()
+	r.Route("/{"+handlers.URLParamLogin+"}", func(r chi.Router) {
+		r.Get("/", handlers.GetUser(c.usersStrg, c.log))
+		r.Put("/role", handlers.UpdateUserRole(c.usersStrg, c.log))
+		r.Get("/orders", handlers.GetUserOrders(c.usersStrg, c.ordersStrg, c.log))
+		r.Get("/withdrawals", handlers.GetUserWithdrawals(c.usersStrg, c.bonusesStrg, c.log))
+		r.Get("/bonuses", handlers.GetUserBonuses(c.usersStrg, c.bonusesStrg, c.log))
+		r.Get("/adjustments", handlers.GetUserAdjustments(c.usersStrg, c.bonusesStrg, c.log))
+		r.Post("/adjustments", handlers.AdjustBonuses(c.usersStrg, c.bonusesStrg, c.log))
+	})
+
+	return r
+}
+
+func (c *Controller) RouteOrders() *chi.Mux {
+	r := chi.NewRouter()
+	r.Post("/{"+handlers.URLParamOrderNumber+"}/reset", handlers.ResetOrder(c.ordersStrg, c.log))
+
+	return r
+}
@@ -0,0 +1,15 @@
+package data
+
+// User represents user's data available for support staff. Password is never exposed.
+//
+//go:generate easyjson -all data.go
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Role  string `json:"role"`
+}
+
+// RoleRequest request body for user's role change.
+type RoleRequest struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/bonuses/storage"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// AdjustBonuses credits(positive sum) or debits(negative sum) user's bonuses on behalf of operator from request's context.
+func AdjustBonuses(usersStrg managers.BaseUsersManager, bonusesStrg storage.BaseBonusesStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		operatorID, err := auth.GetUserIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[admin:handlers:AdjustBonuses] failed to extract operator's userID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		buf := bytes.Buffer{}
+		if _, err = buf.ReadFrom(r.Body); err != nil {
+			log.Info("[admin:handlers:AdjustBonuses] failed to read request body: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer helpers.ExecuteWithLogError(r.Body.Close, log)
+
+		var adjustment data.Adjustment
+		if err = json.Unmarshal(buf.Bytes(), &adjustment); err != nil {
+			log.Info("[admin:handlers:AdjustBonuses] failed to unmarshal request body: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		adjustment.ID = 0
+		adjustment.UserID = user.ID
+		adjustment.OperatorID = operatorID
+		adjustment.CreatedAt = time.Now()
+		if err = bonusesStrg.AdjustBonuses(r.Context(), &adjustment); err != nil {
+			switch {
+			case errors.Is(err, data.ErrInvalidAdjustment):
+				w.WriteHeader(http.StatusBadRequest)
+			case errors.Is(err, data.ErrNotEnoughBonuses):
+				w.WriteHeader(http.StatusConflict)
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[admin:handlers:AdjustBonuses] failed to adjust user '%s' bonuses: %v", user.Login, err)
+			return
+		}
+
+		respBody, err := json.Marshal(adjustment)
+		if err != nil {
+			log.Info("[admin:handlers:AdjustBonuses] failed to marshal adjustment: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("[admin:handlers:AdjustBonuses] operatorID '%d' adjusted user '%s' bonuses by '%s' with reason '%s'",
+			operatorID, user.Login, adjustment.Sum, adjustment.Reason)
+		writeJSON(w, respBody, log)
+	}
+}
+
+func GetUserAdjustments(usersStrg managers.BaseUsersManager, bonusesStrg storage.BaseBonusesStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		adjustments, err := bonusesStrg.GetAdjustments(r.Context(), user.ID)
+		if err != nil {
+			log.Info("[admin:handlers:GetUserAdjustments] failed to get user '%s' adjustments: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(adjustments) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		respBody, err := json.Marshal(adjustments)
+		if err != nil {
+			log.Info("[admin:handlers:GetUserAdjustments] failed to marshal user '%s' adjustments: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustBonuses(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &data.User{ID: 1, Login: "u1", Role: data.RoleUser}
+
+	mockUsers := mocks.NewMockBaseUsersManager(ctrl)
+	gomock.InOrder(
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u2").Return(nil, nil),
+	)
+
+	mockBonuses := mocks.NewMockBaseBonusesStorage(ctrl)
+	gomock.InOrder(
+		mockBonuses.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, adjustment *bonusesData.Adjustment) error {
+				assert.Equal(t, int64(1), adjustment.UserID)
+				assert.Equal(t, int64(5), adjustment.OperatorID)
+				assert.Equal(t, money.New(10, 0), adjustment.Sum)
+				assert.Equal(t, "GOODWILL", adjustment.Reason)
+				return nil
+			}),
+		mockBonuses.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).Return(fmt.Errorf("wrap: %w", bonusesData.ErrInvalidAdjustment)),
+		mockBonuses.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).Return(fmt.Errorf("wrap: %w", bonusesData.ErrNotEnoughBonuses)),
+		mockBonuses.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).Return(fmt.Errorf("db error")),
+	)
+
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxWithValue := context.WithValue(r.Context(), middleware.ContextString(data.UserID), "5")
+			next.ServeHTTP(w, r.WithContext(ctxWithValue))
+		})
+	})
+	router.Post("/{"+URLParamLogin+"}/adjustments", AdjustBonuses(mockUsers, mockBonuses, log))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	type args struct {
+		login string
+		body  []byte
+	}
+	type want struct {
+		statusCode int
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"sum":10,"reason":"GOODWILL","comment":"delivery delay"}`),
+			},
+			want: want{statusCode: http.StatusOK},
+		},
+		{
+			name: "invalid adjustment",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"sum":10,"reason":"UNKNOWN"}`),
+			},
+			want: want{statusCode: http.StatusBadRequest},
+		},
+		{
+			name: "not enough bonuses for debit",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"sum":-100,"reason":"CORRECTION"}`),
+			},
+			want: want{statusCode: http.StatusConflict},
+		},
+		{
+			name: "storage error",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"sum":10,"reason":"GOODWILL"}`),
+			},
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "missing user",
+			args: args{
+				login: "u2",
+				body:  []byte(`{"sum":10,"reason":"GOODWILL"}`),
+			},
+			want: want{statusCode: http.StatusNotFound},
+		},
+		{
+			name: "invalid body",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"sum":10,`),
+			},
+			want: want{statusCode: http.StatusBadRequest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodPost, ts.URL+"/"+tt.args.login+"/adjustments", bytes.NewBuffer(tt.args.body))
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+		})
+	}
+}
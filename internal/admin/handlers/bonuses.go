@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/bonuses/storage"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func GetUserWithdrawals(usersStrg managers.BaseUsersManager, bonusesStrg storage.BaseBonusesStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		withdrawals, err := bonusesStrg.GetWithdrawals(r.Context(), data.WithdrawalsFilter{UserID: user.ID, Sort: keyset.SortNewestFirst})
+		if err != nil {
+			if errors.Is(err, data.ErrWithdrawalsMissing) {
+				w.WriteHeader(http.StatusNoContent)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[admin:handlers:GetUserWithdrawals] failed to get user '%s' withdrawals: %v", user.Login, err)
+			return
+		}
+
+		respBody, err := json.Marshal(withdrawals)
+		if err != nil {
+			log.Info("[admin:handlers:GetUserWithdrawals] failed to marshal user '%s' withdrawals: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
+
+// GetUserBonuses returns user's bonuses ledger: accruals for orders and withdrawals.
+func GetUserBonuses(usersStrg managers.BaseUsersManager, bonusesStrg storage.BaseBonusesStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		bonuses, err := bonusesStrg.GetBonuses(r.Context(), user.ID)
+		if err != nil {
+			log.Info("[admin:handlers:GetUserBonuses] failed to get user '%s' bonuses: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(bonuses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		respBody, err := json.Marshal(bonuses)
+		if err != nil {
+			log.Info("[admin:handlers:GetUserBonuses] failed to marshal user '%s' bonuses: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
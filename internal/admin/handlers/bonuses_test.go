@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserBonuses(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &data.User{ID: 1, Login: "u1", Role: data.RoleUser}
+
+	mockUsers := mocks.NewMockBaseUsersManager(ctrl)
+	mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil).Times(3)
+
+	mockBonuses := mocks.NewMockBaseBonusesStorage(ctrl)
+	gomock.InOrder(
+		mockBonuses.EXPECT().GetBonuses(gomock.Any(), int64(1)).Return([]bonusesData.Bonus{{ID: 1, Order: "1", Count: money.New(10, 0)}}, nil),
+		mockBonuses.EXPECT().GetBonuses(gomock.Any(), int64(1)).Return(nil, nil),
+		mockBonuses.EXPECT().GetBonuses(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("db error")),
+	)
+
+	router := chi.NewRouter()
+	router.Get("/{"+URLParamLogin+"}/bonuses", GetUserBonuses(mockUsers, mockBonuses, log))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	type want struct {
+		statusCode int
+	}
+	tests := []struct {
+		name string
+		want want
+	}{
+		{
+			name: "valid",
+			want: want{statusCode: http.StatusOK},
+		},
+		{
+			name: "empty ledger",
+			want: want{statusCode: http.StatusNoContent},
+		},
+		{
+			name: "db error",
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodGet, ts.URL+"/u1/bonuses", nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+		})
+	}
+}
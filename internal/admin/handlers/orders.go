@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	ordersData "github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/erupshis/bonusbridge/internal/orders/storage"
+	"github.com/go-chi/chi/v5"
+)
+
+// URLParamOrderNumber name of route parameter with order's number.
+const URLParamOrderNumber = "number"
+
+func GetUserOrders(usersStrg managers.BaseUsersManager, ordersStrg storage.BaseOrdersStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		orders, err := ordersStrg.GetOrders(r.Context(), ordersData.Filter{UserID: user.ID, Sort: keyset.SortNewestFirst})
+		if err != nil {
+			log.Info("[admin:handlers:GetUserOrders] failed to get user '%s' orders: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(orders) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		respBody, err := json.Marshal(orders)
+		if err != nil {
+			log.Info("[admin:handlers:GetUserOrders] failed to marshal user '%s' orders: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
+
+// ResetOrder sets order's status to NEW, so it will be polled from accrual system again. Order's accrual is taken back,
+// reset is refused if user has already withdrawn it.
+func ResetOrder(ordersStrg storage.BaseOrdersStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		number := chi.URLParam(r, URLParamOrderNumber)
+		if number == "" {
+			log.Info("[admin:handlers:ResetOrder] missing order number in request")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		orders, err := ordersStrg.GetOrders(r.Context(), ordersData.Filter{Number: number})
+		if err != nil {
+			log.Info("[admin:handlers:ResetOrder] failed to get order '%s': %v", number, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(orders) == 0 {
+			log.Info("[admin:handlers:ResetOrder] order '%s' is not found", number)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err = ordersStrg.ResetOrder(r.Context(), &orders[0]); err != nil {
+			log.Info("[admin:handlers:ResetOrder] failed to reset order '%s': %v", number, err)
+			if errors.Is(err, ordersData.ErrAccrualSpent) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		log.Info("[admin:handlers:ResetOrder] order '%s' reset to NEW", number)
+	}
+}
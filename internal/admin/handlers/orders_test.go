@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	ordersData "github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &data.User{ID: 1, Login: "u1", Role: data.RoleUser}
+
+	mockUsers := mocks.NewMockBaseUsersManager(ctrl)
+	gomock.InOrder(
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u2").Return(nil, nil),
+	)
+
+	mockOrders := mocks.NewMockBaseOrdersStorage(ctrl)
+	gomock.InOrder(
+		mockOrders.EXPECT().GetOrders(gomock.Any(), ordersData.Filter{UserID: 1, Sort: keyset.SortNewestFirst}).Return([]ordersData.Order{{Number: "1"}}, nil),
+		mockOrders.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return(nil, nil),
+		mockOrders.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("db error")),
+	)
+
+	router := chi.NewRouter()
+	router.Get("/{"+URLParamLogin+"}/orders", GetUserOrders(mockUsers, mockOrders, log))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	type args struct {
+		login string
+	}
+	type want struct {
+		statusCode int
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{login: "u1"},
+			want: want{statusCode: http.StatusOK},
+		},
+		{
+			name: "without orders",
+			args: args{login: "u1"},
+			want: want{statusCode: http.StatusNoContent},
+		},
+		{
+			name: "db error",
+			args: args{login: "u1"},
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "missing user",
+			args: args{login: "u2"},
+			want: want{statusCode: http.StatusNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodGet, ts.URL+"/"+tt.args.login+"/orders", nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestResetOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	order := ordersData.Order{
+		ID:      1,
+		Number:  "12345678903",
+		BonusID: 2,
+		Status:  "INVALID",
+		Accrual: money.New(10, 0),
+	}
+
+	mockOrders := mocks.NewMockBaseOrdersStorage(ctrl)
+	gomock.InOrder(
+		mockOrders.EXPECT().GetOrders(gomock.Any(), ordersData.Filter{Number: "12345678903"}).Return([]ordersData.Order{order}, nil),
+		mockOrders.EXPECT().ResetOrder(gomock.Any(), &order).Return(nil),
+		mockOrders.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return(nil, nil),
+		mockOrders.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("db error")),
+		mockOrders.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return([]ordersData.Order{order}, nil),
+		mockOrders.EXPECT().ResetOrder(gomock.Any(), gomock.Any()).Return(fmt.Errorf("db error")),
+		mockOrders.EXPECT().GetOrders(gomock.Any(), gomock.Any()).Return([]ordersData.Order{order}, nil),
+		mockOrders.EXPECT().ResetOrder(gomock.Any(), gomock.Any()).Return(fmt.Errorf("reset: %w", ordersData.ErrAccrualSpent)),
+	)
+
+	router := chi.NewRouter()
+	router.Post("/{"+URLParamOrderNumber+"}/reset", ResetOrder(mockOrders, log))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	type want struct {
+		statusCode int
+	}
+	tests := []struct {
+		name string
+		want want
+	}{
+		{
+			name: "valid",
+			want: want{statusCode: http.StatusOK},
+		},
+		{
+			name: "missing order",
+			want: want{statusCode: http.StatusNotFound},
+		},
+		{
+			name: "select error",
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "update error",
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "accrual already withdrawn",
+			want: want{statusCode: http.StatusConflict},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodPost, ts.URL+"/12345678903/reset", nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	adminData "github.com/erupshis/bonusbridge/internal/admin/data"
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func UpdateUserRole(usersStrg managers.BaseUsersManager, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var roleReq adminData.RoleRequest
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			log.Info("[admin:handlers:UpdateUserRole] failed to read request body: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := json.Unmarshal(buf.Bytes(), &roleReq); err != nil {
+			log.Info("[admin:handlers:UpdateUserRole] failed to parse request body: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		role := data.GetRoleID(roleReq.Role)
+		if role == -1 {
+			log.Info("[admin:handlers:UpdateUserRole] unknown role '%s'", roleReq.Role)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		if err := usersStrg.UpdateUserRole(r.Context(), user.ID, role); err != nil {
+			log.Info("[admin:handlers:UpdateUserRole] failed to update user '%s' role: %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		log.Info("[admin:handlers:UpdateUserRole] user '%s' role changed to '%s'", user.Login, roleReq.Role)
+	}
+}
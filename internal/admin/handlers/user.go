@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	adminData "github.com/erupshis/bonusbridge/internal/admin/data"
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// URLParamLogin name of route parameter with user's login.
+const URLParamLogin = "login"
+
+func GetUser(usersStrg managers.BaseUsersManager, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := findUser(w, r, usersStrg, log)
+		if !ok {
+			return
+		}
+
+		respBody, err := json.Marshal(adminData.User{
+			ID:    user.ID,
+			Login: user.Login,
+			Role:  data.GetRoleName(user.Role),
+		})
+		if err != nil {
+			log.Info("[admin:handlers:GetUser] failed to marshal user '%s': %v", user.Login, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
+
+// findUser searches user by login from route. Writes response status if user is missing or search failed.
+func findUser(w http.ResponseWriter, r *http.Request, usersStrg managers.BaseUsersManager, log logger.BaseLogger) (*data.User, bool) {
+	login := chi.URLParam(r, URLParamLogin)
+	if login == "" {
+		log.Info("[admin:handlers:findUser] missing login in request")
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	user, err := usersStrg.GetUser(r.Context(), login)
+	if err != nil {
+		log.Info("[admin:handlers:findUser] failed to get user '%s': %v", login, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if user == nil {
+		log.Info("[admin:handlers:findUser] user '%s' is not found", login)
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return user, true
+}
+
+// writeJSON writes response body with status OK.
+func writeJSON(w http.ResponseWriter, respBody []byte, log logger.BaseLogger) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(respBody)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(respBody); err != nil {
+		log.Info("[admin:handlers:writeJSON] failed to write response body: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUser(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &data.User{
+		ID:       1,
+		Login:    "u1",
+		Password: "hash",
+		Role:     data.RoleAdmin,
+	}
+
+	mockUsers := mocks.NewMockBaseUsersManager(ctrl)
+	gomock.InOrder(
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u2").Return(nil, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(nil, fmt.Errorf("db error")),
+	)
+
+	router := chi.NewRouter()
+	router.Get("/{"+URLParamLogin+"}", GetUser(mockUsers, log))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	type args struct {
+		login string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				login: "u1",
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"id":1,"login":"u1","role":"ADMIN"}`,
+			},
+		},
+		{
+			name: "missing user",
+			args: args{
+				login: "u2",
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "db error",
+			args: args{
+				login: "u1",
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodGet, ts.URL+"/"+tt.args.login, nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+			if tt.want.body != "" {
+				respBody, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want.body, string(respBody))
+			}
+		})
+	}
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := &data.User{
+		ID:    1,
+		Login: "u1",
+		Role:  data.RoleUser,
+	}
+
+	mockUsers := mocks.NewMockBaseUsersManager(ctrl)
+	gomock.InOrder(
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().UpdateUserRole(gomock.Any(), int64(1), data.RoleAdmin).Return(nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u2").Return(nil, nil),
+		mockUsers.EXPECT().GetUser(gomock.Any(), "u1").Return(user, nil),
+		mockUsers.EXPECT().UpdateUserRole(gomock.Any(), int64(1), data.RoleUser).Return(fmt.Errorf("db error")),
+	)
+
+	router := chi.NewRouter()
+	router.Put("/{"+URLParamLogin+"}/role", UpdateUserRole(mockUsers, log))
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	type args struct {
+		login string
+		body  []byte
+	}
+	type want struct {
+		statusCode int
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"role":"ADMIN"}`),
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "missing user",
+			args: args{
+				login: "u2",
+				body:  []byte(`{"role":"ADMIN"}`),
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "unknown role",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"role":"ROOT"}`),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "invalid body",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"role":`),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "db error",
+			args: args{
+				login: "u1",
+				body:  []byte(`{"role":"USER"}`),
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodPut, ts.URL+"/"+tt.args.login+"/role", bytes.NewBuffer(tt.args.body))
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// JWKS returns public keys that can be used by other services for access tokens verification.
+func JWKS(jwt jwtgenerator.JwtGenerator, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respBody, err := json.Marshal(jwt.JWKS())
+		if err != nil {
+			log.Info("[auth:handlers:JWKS] failed to marshal keys: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(respBody); err != nil {
+			log.Info("[auth:handlers:JWKS] failed to write response: %v", err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKS(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ts := httptest.NewServer(JWKS(jwtgenerator.Create("secret", 1, log), log))
+	defer ts.Close()
+
+	req, errReq := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, errReq)
+
+	resp, errResp := ts.Client().Do(req)
+	require.NoError(t, errResp)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var set jwtgenerator.JWKSet
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&set))
+	assert.Empty(t, set.Keys)
+}
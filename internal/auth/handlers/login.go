@@ -2,17 +2,19 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
-	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/auth/hasher"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/storage"
 	"github.com/erupshis/bonusbridge/internal/auth/users/data"
 	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
 )
 
-func Login(usersStorage managers.BaseUsersManager, jwt jwtgenerator.JwtGenerator, log logger.BaseLogger) http.HandlerFunc {
+func Login(usersStorage managers.BaseUsersManager, sessionsStrg storage.BaseSessionsStorage, hash hasher.BaseHasher, log logger.BaseLogger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		buf := bytes.Buffer{}
 		if _, err := buf.ReadFrom(r.Body); err != nil {
@@ -42,22 +44,51 @@ func Login(usersStorage managers.BaseUsersManager, jwt jwtgenerator.JwtGenerator
 			return
 		}
 
-		if user.Password != userDB.Password {
+		match, err := hash.Compare(userDB.Password, user.Password)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			log.Info("[auth:handlers:Login] failed to check user's password: %v", err)
+			return
+		}
+
+		if !match {
 			w.WriteHeader(http.StatusUnauthorized)
 			log.Info("[auth:handlers:Login] failed to authorize user")
 			return
 		}
 
-		token, err := jwt.BuildJWTString(userDB.ID)
+		if hash.NeedsRehash(userDB.Password) {
+			rehashPassword(r.Context(), usersStorage, hash, userDB.ID, user.Password, log)
+		}
+
+		tokens, err := sessionsStrg.CreateSession(r.Context(), userDB.ID)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
-			log.Info("[auth:handlers:Login] new token generation failed: %w", err)
+			log.Info("[auth:handlers:Login] new session creation failed: %v", err)
 			return
 		}
 
-		w.Header().Set("Authorization", "Bearer "+token)
-		w.WriteHeader(http.StatusOK)
+		if err = writeTokens(w, tokens); err != nil {
+			log.Info("[auth:handlers:Login] failed to write tokens in response: %v", err)
+			return
+		}
 
 		log.Info("[auth:handlers:Login] user '%s' authenticated successfully", user.Login)
 	}
 }
+
+// rehashPassword replaces legacy/outdated user's password hash. Login is not interrupted on failure.
+func rehashPassword(ctx context.Context, usersStorage managers.BaseUsersManager, hash hasher.BaseHasher, userID int64, password string, log logger.BaseLogger) {
+	newHash, err := hash.Hash(password)
+	if err != nil {
+		log.Info("[auth:handlers:rehashPassword] failed to rehash userID '%d' password: %v", userID, err)
+		return
+	}
+
+	if err = usersStorage.UpdateUserPassword(ctx, userID, newHash); err != nil {
+		log.Info("[auth:handlers:rehashPassword] failed to update userID '%d' password: %v", userID, err)
+		return
+	}
+
+	log.Info("[auth:handlers:rehashPassword] userID '%d' password hash has been upgraded", userID)
+}
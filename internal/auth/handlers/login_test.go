@@ -7,7 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/auth/hasher"
+	sessionsData "github.com/erupshis/bonusbridge/internal/auth/sessions/data"
 	"github.com/erupshis/bonusbridge/internal/auth/users/data"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/mocks"
@@ -20,12 +21,20 @@ func TestLogin(t *testing.T) {
 	log, _ := logger.CreateZapLogger("info")
 	defer log.Sync()
 
-	jwtGen := jwtgenerator.Create("secret_key", 3, log)
+	passwordHasher, _ := hasher.Create(hasher.AlgorithmBcrypt, 4, log)
+	hashedPassword, _ := passwordHasher.Hash("p1")
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	user1 := data.User{
+		Login:    "u1",
+		Password: hashedPassword,
+		ID:       1,
+		Role:     data.RoleUser,
+	}
+
+	userLegacy := data.User{
 		Login:    "u1",
 		Password: "p1",
 		ID:       1,
@@ -38,9 +47,28 @@ func TestLogin(t *testing.T) {
 		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("db error")),
 		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(nil, nil),
 		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&user1, nil),
+		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userLegacy, nil),
+		mockStorage.EXPECT().UpdateUserPassword(gomock.Any(), int64(1), gomock.Any()).Return(nil),
+		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userLegacy, nil),
+		mockStorage.EXPECT().UpdateUserPassword(gomock.Any(), int64(1), gomock.Any()).Return(fmt.Errorf("db error")),
+		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&userLegacy, nil),
+		mockStorage.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(&user1, nil),
 	)
 
-	ts := httptest.NewServer(Login(mockStorage, jwtGen, log))
+	tokens := &sessionsData.Tokens{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+	}
+
+	mockSessions := mocks.NewMockBaseSessionsStorage(ctrl)
+	gomock.InOrder(
+		mockSessions.EXPECT().CreateSession(gomock.Any(), int64(1)).Return(tokens, nil),
+		mockSessions.EXPECT().CreateSession(gomock.Any(), int64(1)).Return(tokens, nil),
+		mockSessions.EXPECT().CreateSession(gomock.Any(), int64(1)).Return(tokens, nil),
+		mockSessions.EXPECT().CreateSession(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("sessions error")),
+	)
+
+	ts := httptest.NewServer(Login(mockStorage, mockSessions, passwordHasher, log))
 	defer ts.Close()
 
 	type args struct {
@@ -120,6 +148,58 @@ func TestLogin(t *testing.T) {
 				authorizationHeader: false,
 			},
 		},
+		{
+			name: "legacy plain password is rehashed",
+			args: args{
+				body: []byte(`{
+						"login":"u1", 
+						"password":"p1"
+					}`),
+			},
+			want: want{
+				statusCode:          http.StatusOK,
+				authorizationHeader: true,
+			},
+		},
+		{
+			name: "legacy plain password rehash failed",
+			args: args{
+				body: []byte(`{
+						"login":"u1", 
+						"password":"p1"
+					}`),
+			},
+			want: want{
+				statusCode:          http.StatusOK,
+				authorizationHeader: true,
+			},
+		},
+		{
+			name: "incorrect legacy plain password",
+			args: args{
+				body: []byte(`{
+						"login":"u1", 
+						"password":"p2"
+					}`),
+			},
+			want: want{
+				statusCode:          http.StatusUnauthorized,
+				authorizationHeader: false,
+			},
+		},
+		{
+			name: "session creation error",
+			args: args{
+				body: []byte(`{
+						"login":"u1", 
+						"password":"p1"
+					}`),
+			},
+			want: want{
+				statusCode:          http.StatusInternalServerError,
+				authorizationHeader: false,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/storage"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func Logout(sessionsStrg storage.BaseSessionsStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, err := middleware.GetSessionIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[auth:handlers:Logout] failed to extract sessionID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = sessionsStrg.RevokeSession(r.Context(), sessionID); err != nil {
+			log.Info("[auth:handlers:Logout] failed to revoke session: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		log.Info("[auth:handlers:Logout] sessionID '%d' revoked successfully", sessionID)
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogout(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessions := mocks.NewMockBaseSessionsStorage(ctrl)
+	gomock.InOrder(
+		mockSessions.EXPECT().RevokeSession(gomock.Any(), int64(3)).Return(nil),
+		mockSessions.EXPECT().RevokeSession(gomock.Any(), int64(3)).Return(fmt.Errorf("db error")),
+	)
+
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString(data.SessionID), fmt.Sprintf("%d", 3))
+		Logout(mockSessions, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+
+	type args struct {
+		withSessionIDinContext bool
+	}
+	type want struct {
+		statusCode int
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				withSessionIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "storage error",
+			args: args{
+				withSessionIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "without sessionID in context",
+			args: args{
+				withSessionIDinContext: false,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts *httptest.Server
+			if tt.args.withSessionIDinContext {
+				ts = httptest.NewServer(handlerFunc)
+			} else {
+				ts = httptest.NewServer(Logout(mockSessions, log))
+			}
+			defer ts.Close()
+
+			req, errReq := http.NewRequest(http.MethodPost, ts.URL, nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/storage"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func Refresh(sessionsStrg storage.BaseSessionsStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		buf := bytes.Buffer{}
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			log.Info("[auth:handlers:Refresh] failed to read request body: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer helpers.ExecuteWithLogError(r.Body.Close, log)
+
+		var refreshReq data.RefreshRequest
+		if err := json.Unmarshal(buf.Bytes(), &refreshReq); err != nil || refreshReq.RefreshToken == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			log.Info("[auth:handlers:Refresh] bad refresh request data: %v", err)
+			return
+		}
+
+		tokens, err := sessionsStrg.RefreshSession(r.Context(), refreshReq.RefreshToken)
+		if err != nil {
+			if errors.Is(err, data.ErrSessionNotFound) {
+				w.WriteHeader(http.StatusUnauthorized)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[auth:handlers:Refresh] failed to refresh session: %v", err)
+			return
+		}
+
+		if err = writeTokens(w, tokens); err != nil {
+			log.Info("[auth:handlers:Refresh] failed to write tokens in response: %v", err)
+			return
+		}
+
+		log.Info("[auth:handlers:Refresh] session refreshed successfully")
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresh(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := &data.Tokens{
+		AccessToken:  "access",
+		RefreshToken: "new_refresh",
+	}
+
+	mockSessions := mocks.NewMockBaseSessionsStorage(ctrl)
+	gomock.InOrder(
+		mockSessions.EXPECT().RefreshSession(gomock.Any(), "refresh").Return(tokens, nil),
+		mockSessions.EXPECT().RefreshSession(gomock.Any(), "refresh").Return(nil, fmt.Errorf("refresh: %w", data.ErrSessionNotFound)),
+		mockSessions.EXPECT().RefreshSession(gomock.Any(), "refresh").Return(nil, fmt.Errorf("db error")),
+	)
+
+	ts := httptest.NewServer(Refresh(mockSessions, log))
+	defer ts.Close()
+
+	type args struct {
+		body []byte
+	}
+	type want struct {
+		statusCode          int
+		authorizationHeader string
+		body                []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				body: []byte(`{"refresh_token":"refresh"}`),
+			},
+			want: want{
+				statusCode:          http.StatusOK,
+				authorizationHeader: "Bearer access",
+				body:                []byte(`{"refresh_token":"new_refresh"}`),
+			},
+		},
+		{
+			name: "damaged request body",
+			args: args{
+				body: []byte(`{"refresh_token":"refresh"`),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "missing refresh token",
+			args: args{
+				body: []byte(`{}`),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "session is not found",
+			args: args{
+				body: []byte(`{"refresh_token":"refresh"}`),
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "storage error",
+			args: args{
+				body: []byte(`{"refresh_token":"refresh"}`),
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBuffer(tt.args.body))
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+			assert.Equal(t, tt.want.authorizationHeader, resp.Header.Get("Authorization"))
+
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.want.body), string(respBody))
+		})
+	}
+}
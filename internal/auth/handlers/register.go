@@ -5,14 +5,15 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/auth/hasher"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/storage"
 	"github.com/erupshis/bonusbridge/internal/auth/users/data"
 	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
 )
 
-func Register(usersStorage managers.BaseUsersManager, jwt jwtgenerator.JwtGenerator, log logger.BaseLogger) http.HandlerFunc {
+func Register(usersStorage managers.BaseUsersManager, sessionsStrg storage.BaseSessionsStorage, hash hasher.BaseHasher, log logger.BaseLogger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		buf := bytes.Buffer{}
 		if _, err := buf.ReadFrom(r.Body); err != nil {
@@ -43,6 +44,13 @@ func Register(usersStorage managers.BaseUsersManager, jwt jwtgenerator.JwtGenera
 			return
 		}
 
+		user.Password, err = hash.Hash(user.Password)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			log.Info("[auth:handlers:Register] failed to hash user '%s' password: %v", user.Login, err)
+			return
+		}
+
 		userID, err = usersStorage.AddUser(r.Context(), &user)
 		if err != nil || userID == -1 {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -50,15 +58,17 @@ func Register(usersStorage managers.BaseUsersManager, jwt jwtgenerator.JwtGenera
 			return
 		}
 
-		token, err := jwt.BuildJWTString(userID)
+		tokens, err := sessionsStrg.CreateSession(r.Context(), userID)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
-			log.Info("[auth:handlers:Register] new token generation failed: %v", err)
+			log.Info("[auth:handlers:Register] new session creation failed: %v", err)
 			return
 		}
 
-		w.Header().Set("Authorization", "Bearer "+token)
-		w.WriteHeader(http.StatusOK)
+		if err = writeTokens(w, tokens); err != nil {
+			log.Info("[auth:handlers:Register] failed to write tokens in response: %v", err)
+			return
+		}
 
 		log.Info("[auth:handlers:Register] user '%s' registered successfully", user.Login)
 	}
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
-	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/auth/hasher"
+	sessionsData "github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/mocks"
 	"github.com/golang/mock/gomock"
@@ -19,7 +22,7 @@ func TestRegister(t *testing.T) {
 	log, _ := logger.CreateZapLogger("info")
 	defer log.Sync()
 
-	jwtGen := jwtgenerator.Create("secret_key", 3, log)
+	passwordHasher, _ := hasher.Create(hasher.AlgorithmBcrypt, 4, log)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -27,14 +30,33 @@ func TestRegister(t *testing.T) {
 	mockStorage := mocks.NewMockBaseUsersManager(ctrl)
 	gomock.InOrder(
 		mockStorage.EXPECT().GetUserID(gomock.Any(), gomock.Any()).Return(int64(-1), nil),
-		mockStorage.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(int64(1), nil),
+		mockStorage.EXPECT().AddUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *data.User) (int64, error) {
+			match, err := passwordHasher.Compare(user.Password, "p1")
+			assert.NoError(t, err)
+			assert.True(t, match)
+			assert.NotEqual(t, "p1", user.Password)
+			return int64(1), nil
+		}),
 		mockStorage.EXPECT().GetUserID(gomock.Any(), gomock.Any()).Return(int64(-1), fmt.Errorf("failed to find user(db error)")),
 		mockStorage.EXPECT().GetUserID(gomock.Any(), gomock.Any()).Return(int64(1), nil),
 		mockStorage.EXPECT().GetUserID(gomock.Any(), gomock.Any()).Return(int64(-1), nil),
 		mockStorage.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(int64(1), fmt.Errorf("failed to add user(db error)")),
+		mockStorage.EXPECT().GetUserID(gomock.Any(), gomock.Any()).Return(int64(-1), nil),
+		mockStorage.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(int64(1), nil),
+	)
+
+	tokens := &sessionsData.Tokens{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+	}
+
+	mockSessions := mocks.NewMockBaseSessionsStorage(ctrl)
+	gomock.InOrder(
+		mockSessions.EXPECT().CreateSession(gomock.Any(), int64(1)).Return(tokens, nil),
+		mockSessions.EXPECT().CreateSession(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("sessions error")),
 	)
 
-	ts := httptest.NewServer(Register(mockStorage, jwtGen, log))
+	ts := httptest.NewServer(Register(mockStorage, mockSessions, passwordHasher, log))
 	defer ts.Close()
 
 	type args struct {
@@ -114,6 +136,19 @@ func TestRegister(t *testing.T) {
 				authorizationHeader: false,
 			},
 		},
+		{
+			name: "session creation error",
+			args: args{
+				body: []byte(`{
+						"login":"u2", 
+						"password":"p1"
+					}`),
+			},
+			want: want{
+				statusCode:          http.StatusInternalServerError,
+				authorizationHeader: false,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+)
+
+// writeTokens puts access token in 'Authorization' header and refresh token in response body.
+func writeTokens(w http.ResponseWriter, tokens *data.Tokens) error {
+	respBody, err := json.Marshal(tokens)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return fmt.Errorf("marshal tokens: %w", err)
+	}
+
+	w.Header().Set("Authorization", "Bearer "+tokens.AccessToken)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(respBody); err != nil {
+		return fmt.Errorf("write tokens: %w", err)
+	}
+
+	return nil
+}
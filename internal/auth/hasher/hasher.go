@@ -0,0 +1,98 @@
+// Package hasher implements passwords hashing with transparent upgrade of legacy hashes.
+package hasher
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// algorithm interface of supported hashing algorithms.
+type algorithm interface {
+	hash(password string) (string, error)
+	compare(hash string, password string) (bool, error)
+	isOwnHash(hash string) bool
+	isOutdated(hash string) bool
+}
+
+// hasher keeps algorithm for new hashes generation and all known algorithms for verification.
+type hasher struct {
+	current    algorithm
+	algorithms []algorithm
+
+	log logger.BaseLogger
+}
+
+// Create creates passwords hasher. cost is applied to bcrypt algorithm only.
+func Create(algorithmName string, cost int, baseLogger logger.BaseLogger) (BaseHasher, error) {
+	bcryptAlg, err := createBcrypt(cost)
+	if err != nil {
+		return nil, fmt.Errorf("create hasher: %w", err)
+	}
+	argon2Alg := createArgon2id()
+
+	res := &hasher{
+		algorithms: []algorithm{bcryptAlg, argon2Alg},
+		log:        baseLogger,
+	}
+
+	switch algorithmName {
+	case AlgorithmBcrypt, "":
+		res.current = bcryptAlg
+	case AlgorithmArgon2id:
+		res.current = argon2Alg
+	default:
+		return nil, fmt.Errorf("create hasher: unknown algorithm '%s'", algorithmName)
+	}
+
+	return res, nil
+}
+
+func (h *hasher) Hash(password string) (string, error) {
+	hash, err := h.current.hash(password)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+
+	return hash, nil
+}
+
+func (h *hasher) Compare(hash string, password string) (bool, error) {
+	alg := h.findAlgorithm(hash)
+	if alg == nil {
+		h.log.Info("[auth:hasher:Compare] unknown hash format, compare as legacy plain password")
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(password)) == 1, nil
+	}
+
+	match, err := alg.compare(hash, password)
+	if err != nil {
+		return false, fmt.Errorf("compare password with hash: %w", err)
+	}
+
+	return match, nil
+}
+
+func (h *hasher) NeedsRehash(hash string) bool {
+	if !h.current.isOwnHash(hash) {
+		return true
+	}
+
+	return h.current.isOutdated(hash)
+}
+
+// findAlgorithm returns algorithm that generated hash. Returns nil for legacy plain passwords.
+func (h *hasher) findAlgorithm(hash string) algorithm {
+	for _, alg := range h.algorithms {
+		if alg.isOwnHash(hash) {
+			return alg
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package hasher
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	type args struct {
+		algorithm string
+		cost      int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid bcrypt",
+			args: args{
+				algorithm: AlgorithmBcrypt,
+				cost:      4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid argon2id",
+			args: args{
+				algorithm: AlgorithmArgon2id,
+				cost:      4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "default algorithm and cost",
+			args: args{
+				algorithm: "",
+				cost:      0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown algorithm",
+			args: args{
+				algorithm: "md5",
+				cost:      4,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bcrypt cost out of range",
+			args: args{
+				algorithm: AlgorithmBcrypt,
+				cost:      50,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Create(tt.args.algorithm, tt.args.cost, log)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHasher_Overall(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	tests := []struct {
+		name      string
+		algorithm string
+	}{
+		{
+			name:      "bcrypt",
+			algorithm: AlgorithmBcrypt,
+		},
+		{
+			name:      "argon2id",
+			algorithm: AlgorithmArgon2id,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := Create(tt.algorithm, 4, log)
+			require.NoError(t, err)
+
+			hash, err := h.Hash("password")
+			require.NoError(t, err)
+			assert.NotEqual(t, "password", hash)
+			assert.False(t, h.NeedsRehash(hash))
+
+			match, err := h.Compare(hash, "password")
+			require.NoError(t, err)
+			assert.True(t, match)
+
+			match, err = h.Compare(hash, "wrong password")
+			require.NoError(t, err)
+			assert.False(t, match)
+		})
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	bcryptLowCost, _ := Create(AlgorithmBcrypt, 4, log)
+	bcryptHighCost, _ := Create(AlgorithmBcrypt, 5, log)
+	argon2id, _ := Create(AlgorithmArgon2id, 4, log)
+
+	bcryptHash, _ := bcryptLowCost.Hash("password")
+	argon2idHash, _ := argon2id.Hash("password")
+
+	tests := []struct {
+		name   string
+		hasher BaseHasher
+		hash   string
+		want   bool
+	}{
+		{
+			name:   "legacy plain password",
+			hasher: bcryptLowCost,
+			hash:   "password",
+			want:   true,
+		},
+		{
+			name:   "same bcrypt cost",
+			hasher: bcryptLowCost,
+			hash:   bcryptHash,
+			want:   false,
+		},
+		{
+			name:   "old bcrypt cost",
+			hasher: bcryptHighCost,
+			hash:   bcryptHash,
+			want:   true,
+		},
+		{
+			name:   "bcrypt hash with argon2id as current algorithm",
+			hasher: argon2id,
+			hash:   bcryptHash,
+			want:   true,
+		},
+		{
+			name:   "argon2id hash with bcrypt as current algorithm",
+			hasher: bcryptLowCost,
+			hash:   argon2idHash,
+			want:   true,
+		},
+		{
+			name:   "argon2id with another settings",
+			hasher: argon2id,
+			hash:   "$argon2id$v=19$m=1024,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.hasher.NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func TestHasher_CompareAcrossAlgorithms(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	bcryptHasher, _ := Create(AlgorithmBcrypt, 4, log)
+	argon2idHasher, _ := Create(AlgorithmArgon2id, 4, log)
+
+	bcryptHash, _ := bcryptHasher.Hash("password")
+	argon2idHash, _ := argon2idHasher.Hash("password")
+
+	tests := []struct {
+		name     string
+		hasher   BaseHasher
+		hash     string
+		password string
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name:     "bcrypt hash verified by argon2id hasher",
+			hasher:   argon2idHasher,
+			hash:     bcryptHash,
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "argon2id hash verified by bcrypt hasher",
+			hasher:   bcryptHasher,
+			hash:     argon2idHash,
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "legacy plain password",
+			hasher:   bcryptHasher,
+			hash:     "password",
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "legacy plain password mismatch",
+			hasher:   bcryptHasher,
+			hash:     "password",
+			password: "another",
+			want:     false,
+		},
+		{
+			name:     "damaged argon2id hash",
+			hasher:   bcryptHasher,
+			hash:     "$argon2id$v=19$damaged",
+			password: "password",
+			want:     false,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.hasher.Compare(tt.hash, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compare() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package hasher
+
+// BaseHasher interface of passwords hasher.
+type BaseHasher interface {
+	// Hash generates hash of password with current algorithm and settings.
+	Hash(password string) (string, error)
+
+	// Compare checks if password matches previously generated hash. Supports hashes of all known algorithms and legacy plain passwords.
+	Compare(hash string, password string) (bool, error)
+
+	// NeedsRehash checks if hash was generated with another algorithm/settings and should be replaced.
+	NeedsRehash(hash string) bool
+}
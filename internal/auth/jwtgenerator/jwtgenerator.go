@@ -1,6 +1,8 @@
 package jwtgenerator
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -8,43 +10,56 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
-// Claims struct that keeps standard jwt claims plus custom UserID.
+// Claims struct that keeps standard jwt claims plus custom UserID and SessionID.
 type Claims struct {
 	jwt.RegisteredClaims
-	UserID int64
+	UserID    int64
+	SessionID int64
 }
 
 // JwtGenerator generator itself.
 type JwtGenerator struct {
-	jwtKey   string
+	keyring  *Keyring
 	tokenExp int
 
 	log logger.BaseLogger
 }
 
-// Create creates JWT tokens generator.
+// Create creates JWT tokens generator with single HS256 key.
+// Random key is generated if jwtKey is empty, issued tokens become invalid after restart.
 func Create(jwtKey string, tokenExp int, baseLogger logger.BaseLogger) JwtGenerator {
 	if jwtKey == "" {
-		baseLogger.Info("[auth:jwtgenerator:Create] JWT token generation key is missing")
+		baseLogger.Info("[auth:jwtgenerator:Create] JWT token generation key is missing, random key is used")
+		jwtKey = randomSecret()
 	}
 
+	return CreateWithKeyring(CreateHMACKeyring(jwtKey), tokenExp, baseLogger)
+}
+
+// CreateWithKeyring creates JWT tokens generator that signs tokens with keyring's active key.
+func CreateWithKeyring(keyring *Keyring, tokenExp int, baseLogger logger.BaseLogger) JwtGenerator {
 	return JwtGenerator{
-		jwtKey:   jwtKey,
+		keyring:  keyring,
 		tokenExp: tokenExp,
 		log:      baseLogger,
 	}
 }
 
-// BuildJWTString creates token and returns it as string.
-func (j *JwtGenerator) BuildJWTString(userID int64) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+// BuildJWTString creates token for user's session and returns it as string.
+func (j *JwtGenerator) BuildJWTString(userID int64, sessionID int64) (string, error) {
+	key := j.keyring.Active()
+	token := jwt.NewWithClaims(key.Method, Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(j.tokenExp) * time.Hour)),
 		},
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 	})
+	if key.ID != LegacyKeyID {
+		token.Header["kid"] = key.ID
+	}
 
-	tokenString, err := token.SignedString([]byte(j.jwtKey))
+	tokenString, err := token.SignedString(key.signKey)
 	if err != nil {
 		return "", err
 	}
@@ -55,23 +70,59 @@ func (j *JwtGenerator) BuildJWTString(userID int64) (string, error) {
 
 // GetUserID gets token in string format, parse it and returns userID.
 func (j *JwtGenerator) GetUserID(tokenString string) int64 {
+	claims := j.GetClaims(tokenString)
+	if claims == nil {
+		return -1
+	}
+
+	return claims.UserID
+}
+
+// GetClaims gets token in string format, parse it and returns its claims. Returns nil if token is not valid.
+func (j *JwtGenerator) GetClaims(tokenString string) *Claims {
 	claims := &Claims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims,
 		func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			keyID := LegacyKeyID
+			if kid, ok := t.Header["kid"]; ok {
+				if keyID, ok = kid.(string); !ok {
+					return nil, fmt.Errorf("unexpected key id: %v", kid)
+				}
+			}
+
+			key, ok := j.keyring.Get(keyID)
+			if !ok {
+				return nil, fmt.Errorf("unknown key id: %s", keyID)
+			}
+
+			if t.Method.Alg() != key.Method.Alg() {
 				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 			}
-			return []byte(j.jwtKey), nil
+			return key.verifyKey, nil
 		})
 	if err != nil {
-		return -1
+		return nil
 	}
 
 	if !token.Valid {
-		j.log.Info("[auth:jwtgenerator:GetUserID] Token is not valid")
-		return -1
+		j.log.Info("[auth:jwtgenerator:GetClaims] Token is not valid")
+		return nil
 	}
 
-	j.log.Info("[auth:jwtgenerator:GetUserID] Token os valid")
-	return claims.UserID
+	j.log.Info("[auth:jwtgenerator:GetClaims] Token is valid")
+	return claims
+}
+
+// JWKS returns public keys for tokens verification.
+func (j *JwtGenerator) JWKS() JWKSet {
+	return j.keyring.JWKS()
+}
+
+// randomSecret generates HMAC secret that is known to the current process only.
+func randomSecret() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("generate random JWT key: %v", err))
+	}
+	return hex.EncodeToString(buf)
 }
@@ -69,12 +69,8 @@ func TestJwtGenerator_Overall(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			j := &JwtGenerator{
-				jwtKey:   tt.fields.jwtKey,
-				tokenExp: tt.fields.tokenExp,
-				log:      tt.fields.log,
-			}
-			tokenString, err := j.BuildJWTString(tt.args.userID)
+			j := Create(tt.fields.jwtKey, tt.fields.tokenExp, tt.fields.log)
+			tokenString, err := j.BuildJWTString(tt.args.userID, 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildJWTString() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -88,3 +84,23 @@ func TestJwtGenerator_Overall(t *testing.T) {
 		})
 	}
 }
+
+func TestJwtGenerator_EmptyKey(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	generator := Create("", 1, log)
+	token, err := generator.BuildJWTString(1, 1)
+	if err != nil {
+		t.Fatalf("BuildJWTString() error = %v", err)
+	}
+
+	if got := generator.GetUserID(token); got != 1 {
+		t.Errorf("GetUserID() = %v, want 1", got)
+	}
+
+	forged := Create("", 1, log)
+	if got := forged.GetUserID(token); got != -1 {
+		t.Errorf("GetUserID() of another generator with empty key = %v, want -1", got)
+	}
+}
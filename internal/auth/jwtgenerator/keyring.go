@@ -0,0 +1,195 @@
+package jwtgenerator
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// LegacyKeyID id of HMAC key used for tokens issued without 'kid' header.
+const LegacyKeyID = ""
+
+// Key signing/verification key of keyring.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+
+	signKey   interface{} // signKey is nil for verification only keys.
+	verifyKey interface{}
+	notAfter  time.Time // notAfter time after that key doesn't verify tokens(zero - no limit).
+}
+
+// CanSign checks if key can be used for tokens signing.
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
+
+// Keyring set of keys identified by 'kid'. Active key signs new tokens, all keys verify tokens.
+type Keyring struct {
+	keys   map[string]*Key
+	active *Key
+}
+
+// CreateHMACKeyring creates keyring with single HS256 key that is used for tokens without 'kid'.
+func CreateHMACKeyring(secret string) *Keyring {
+	keyring := &Keyring{keys: map[string]*Key{}}
+	keyring.active = keyring.addHMAC(LegacyKeyID, secret)
+	return keyring
+}
+
+// LoadKeyring loads PEM keys from dir. File name without extension is used as 'kid'.
+// Private keys(RSA, Ed25519) are used for signing and verification, public keys - for verification only.
+// Non-empty legacySecret adds HS256 key to verify tokens issued before keys rotation(without 'kid') till legacyUntil.
+// Legacy key is skipped if legacyUntil is zero or already passed.
+// If activeKeyID is empty, the only private key in dir becomes active.
+func LoadKeyring(dir string, activeKeyID string, legacySecret string, legacyUntil time.Time) (*Keyring, error) {
+	errMsg := fmt.Sprintf("load keyring from '%s'", dir) + ": %w"
+
+	keyring := &Keyring{keys: map[string]*Key{}}
+	if legacySecret != "" && legacyUntil.After(time.Now()) {
+		keyring.addHMAC(LegacyKeyID, legacySecret).notAfter = legacyUntil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	sort.Strings(files)
+
+	var signingKeys []*Key
+	for _, file := range files {
+		key, err := loadKey(file)
+		if err != nil {
+			return nil, fmt.Errorf(errMsg, err)
+		}
+
+		if _, ok := keyring.keys[key.ID]; ok {
+			return nil, fmt.Errorf(errMsg, fmt.Errorf("duplicated key id '%s'", key.ID))
+		}
+		keyring.keys[key.ID] = key
+
+		if key.CanSign() {
+			signingKeys = append(signingKeys, key)
+		}
+	}
+
+	switch {
+	case activeKeyID != "":
+		if err = keyring.SetActive(activeKeyID); err != nil {
+			return nil, fmt.Errorf(errMsg, err)
+		}
+	case len(signingKeys) == 1:
+		keyring.active = signingKeys[0]
+	default:
+		return nil, fmt.Errorf(errMsg, fmt.Errorf("active key id is not set, found '%d' private keys", len(signingKeys)))
+	}
+
+	return keyring, nil
+}
+
+// SetActive selects key for new tokens signing.
+func (k *Keyring) SetActive(id string) error {
+	key, ok := k.keys[id]
+	if !ok {
+		return fmt.Errorf("key '%s' is not found", id)
+	}
+
+	if !key.CanSign() {
+		return fmt.Errorf("key '%s' can't be used for signing", id)
+	}
+
+	k.active = key
+	return nil
+}
+
+// Active returns key for new tokens signing.
+func (k *Keyring) Active() *Key {
+	return k.active
+}
+
+// Get returns key by id. Keys after their verification cutoff are not returned.
+func (k *Keyring) Get(id string) (*Key, bool) {
+	key, ok := k.keys[id]
+	if !ok || (!key.notAfter.IsZero() && time.Now().After(key.notAfter)) {
+		return nil, false
+	}
+	return key, true
+}
+
+func (k *Keyring) addHMAC(id string, secret string) *Key {
+	key := &Key{
+		ID:        id,
+		Method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+	k.keys[id] = key
+	return key
+}
+
+// loadKey parses PEM file and detects signing method by key type.
+func loadKey(file string) (*Key, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("file '%s' doesn't contain PEM data", file)
+	}
+
+	key := &Key{ID: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type '%s' in '%s'", block.Type, file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse key '%s': %w", file, err)
+	}
+
+	switch typed := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodRS256, typed, &typed.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodRS256, typed
+	case ed25519.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodEdDSA, typed, typed.Public()
+	case ed25519.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodEdDSA, typed
+	default:
+		return nil, fmt.Errorf("unsupported key type '%T' in '%s'", parsed, file)
+	}
+
+	return key, nil
+}
+
+// publicKey returns key for JWKS. HMAC keys are never published.
+func (k *Key) publicKey() (crypto.PublicKey, bool) {
+	switch k.verifyKey.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		return k.verifyKey, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,228 @@
+package jwtgenerator
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, dir string, name string, blockType string, bytes []byte) {
+	t.Helper()
+	err := os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+	require.NoError(t, err)
+}
+
+func createKeysDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writePEM(t, dir, "rsa-1", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edBytes, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	writePEM(t, dir, "ed-2", "PRIVATE KEY", edBytes)
+
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPublicBytes, err := x509.MarshalPKIXPublicKey(edPublic)
+	require.NoError(t, err)
+	writePEM(t, dir, "ed-old", "PUBLIC KEY", edPublicBytes)
+
+	return dir
+}
+
+func TestLoadKeyring(t *testing.T) {
+	dir := createKeysDir(t)
+
+	type args struct {
+		activeKeyID string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantActive string
+		wantErr    bool
+	}{
+		{
+			name:       "rsa active",
+			args:       args{activeKeyID: "rsa-1"},
+			wantActive: "rsa-1",
+			wantErr:    false,
+		},
+		{
+			name:       "ed25519 active",
+			args:       args{activeKeyID: "ed-2"},
+			wantActive: "ed-2",
+			wantErr:    false,
+		},
+		{
+			name:    "public key can't be active",
+			args:    args{activeKeyID: "ed-old"},
+			wantErr: true,
+		},
+		{
+			name:    "missing active key",
+			args:    args{activeKeyID: "missing"},
+			wantErr: true,
+		},
+		{
+			name:    "several private keys without active key",
+			args:    args{activeKeyID: ""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := LoadKeyring(dir, tt.args.activeKeyID, "", time.Time{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActive, keyring.Active().ID)
+		})
+	}
+}
+
+func TestJwtGenerator_Rotation(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	dir := createKeysDir(t)
+
+	legacyGenerator := Create("secret", 1, log)
+	legacyToken, err := legacyGenerator.BuildJWTString(1, 1)
+	require.NoError(t, err)
+
+	legacyUntil := time.Now().Add(time.Hour)
+
+	keyringRSA, err := LoadKeyring(dir, "rsa-1", "secret", legacyUntil)
+	require.NoError(t, err)
+	generatorRSA := CreateWithKeyring(keyringRSA, 1, log)
+	rsaToken, err := generatorRSA.BuildJWTString(2, 2)
+	require.NoError(t, err)
+
+	keyringEd, err := LoadKeyring(dir, "ed-2", "secret", legacyUntil)
+	require.NoError(t, err)
+	generatorEd := CreateWithKeyring(keyringEd, 1, log)
+	edToken, err := generatorEd.BuildJWTString(3, 3)
+	require.NoError(t, err)
+
+	keyringWithoutLegacy, err := LoadKeyring(dir, "ed-2", "", time.Time{})
+	require.NoError(t, err)
+	generatorWithoutLegacy := CreateWithKeyring(keyringWithoutLegacy, 1, log)
+
+	keyringLegacyNoCutoff, err := LoadKeyring(dir, "ed-2", "secret", time.Time{})
+	require.NoError(t, err)
+	generatorLegacyNoCutoff := CreateWithKeyring(keyringLegacyNoCutoff, 1, log)
+
+	keyringLegacyExpired, err := LoadKeyring(dir, "ed-2", "secret", legacyUntil)
+	require.NoError(t, err)
+	keyringLegacyExpired.keys[LegacyKeyID].notAfter = time.Now().Add(-time.Second)
+	generatorLegacyExpired := CreateWithKeyring(keyringLegacyExpired, 1, log)
+
+	otherDir := createKeysDir(t)
+	keyringOther, err := LoadKeyring(otherDir, "rsa-1", "", time.Time{})
+	require.NoError(t, err)
+	generatorOther := CreateWithKeyring(keyringOther, 1, log)
+
+	tests := []struct {
+		name      string
+		generator JwtGenerator
+		token     string
+		want      int64
+	}{
+		{
+			name:      "legacy token after rotation",
+			generator: generatorEd,
+			token:     legacyToken,
+			want:      1,
+		},
+		{
+			name:      "token of previous active key",
+			generator: generatorEd,
+			token:     rsaToken,
+			want:      2,
+		},
+		{
+			name:      "token of active key",
+			generator: generatorEd,
+			token:     edToken,
+			want:      3,
+		},
+		{
+			name:      "legacy token without legacy secret",
+			generator: generatorWithoutLegacy,
+			token:     legacyToken,
+			want:      -1,
+		},
+		{
+			name:      "legacy token without legacy cutoff",
+			generator: generatorLegacyNoCutoff,
+			token:     legacyToken,
+			want:      -1,
+		},
+		{
+			name:      "legacy token after legacy cutoff",
+			generator: generatorLegacyExpired,
+			token:     legacyToken,
+			want:      -1,
+		},
+		{
+			name:      "same kid signed by another key",
+			generator: generatorOther,
+			token:     rsaToken,
+			want:      -1,
+		},
+		{
+			name:      "asymmetric token for HMAC only generator",
+			generator: legacyGenerator,
+			token:     rsaToken,
+			want:      -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.generator.GetUserID(tt.token))
+		})
+	}
+}
+
+func TestKeyring_JWKS(t *testing.T) {
+	keyring, err := LoadKeyring(createKeysDir(t), "rsa-1", "secret", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	set := keyring.JWKS()
+	require.Len(t, set.Keys, 3)
+
+	assert.Equal(t, "ed-2", set.Keys[0].KeyID)
+	assert.Equal(t, "OKP", set.Keys[0].KeyType)
+	assert.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+	assert.Equal(t, "Ed25519", set.Keys[0].Curve)
+	assert.NotEmpty(t, set.Keys[0].X)
+
+	assert.Equal(t, "ed-old", set.Keys[1].KeyID)
+
+	assert.Equal(t, "rsa-1", set.Keys[2].KeyID)
+	assert.Equal(t, "RSA", set.Keys[2].KeyType)
+	assert.Equal(t, "RS256", set.Keys[2].Algorithm)
+	assert.Equal(t, "AQAB", set.Keys[2].E)
+	assert.NotEmpty(t, set.Keys[2].N)
+
+	assert.Empty(t, CreateHMACKeyring("secret").JWKS().Keys)
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	sessionsData "github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	sessionsStorage "github.com/erupshis/bonusbridge/internal/auth/sessions/storage"
 	"github.com/erupshis/bonusbridge/internal/auth/users/data"
 	"github.com/erupshis/bonusbridge/internal/auth/users/managers"
 	"github.com/erupshis/bonusbridge/internal/logger"
@@ -14,7 +16,8 @@ import (
 
 type ContextString string
 
-func AuthorizeUser(h http.Handler, userRoleRequirement int, usersStorage managers.BaseUsersManager, jwt jwtgenerator.JwtGenerator, log logger.BaseLogger) http.Handler {
+func AuthorizeUser(h http.Handler, userRoleRequirement int, usersStorage managers.BaseUsersManager, sessionsStrg sessionsStorage.BaseSessionsStorage,
+	jwt jwtgenerator.JwtGenerator, log logger.BaseLogger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -30,7 +33,27 @@ func AuthorizeUser(h http.Handler, userRoleRequirement int, usersStorage manager
 			return
 		}
 
-		userID := jwt.GetUserID(token[1])
+		claims := jwt.GetClaims(token[1])
+		if claims == nil {
+			log.Info("[auth:middleware:Authorize] invalid token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		userID := claims.UserID
+		sessionActive, err := sessionsStrg.IsSessionActive(r.Context(), claims.SessionID, userID)
+		if err != nil {
+			log.Info("[auth:middleware:Authorize] failed to check user's session: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !sessionActive {
+			log.Info("[auth:middleware:Authorize] user's session was revoked or expired")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
 		userRole, err := usersStorage.GetUserRole(r.Context(), userID)
 		if err != nil {
 			log.Info("[auth:middleware:Authorize] failed to search user in system: %v", err)
@@ -51,6 +74,7 @@ func AuthorizeUser(h http.Handler, userRoleRequirement int, usersStorage manager
 		}
 
 		ctxWithValue := context.WithValue(r.Context(), ContextString(data.UserID), fmt.Sprintf("%d", userID))
+		ctxWithValue = context.WithValue(ctxWithValue, ContextString(sessionsData.SessionID), fmt.Sprintf("%d", claims.SessionID))
 		h.ServeHTTP(w, r.WithContext(ctxWithValue))
 	})
 }
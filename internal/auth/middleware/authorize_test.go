@@ -29,7 +29,7 @@ func TestAuthorizeUser(t *testing.T) {
 	defer log.Sync()
 
 	jwtGen := jwtgenerator.Create("secret_key", 3, log)
-	validToken, _ := jwtGen.BuildJWTString(2)
+	validToken, _ := jwtGen.BuildJWTString(2, 1)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -42,6 +42,16 @@ func TestAuthorizeUser(t *testing.T) {
 		mockStorage.EXPECT().GetUserRole(gomock.Any(), gomock.Any()).Return(data.RoleUser, nil),
 	)
 
+	mockSessions := mocks.NewMockBaseSessionsStorage(ctrl)
+	gomock.InOrder(
+		mockSessions.EXPECT().IsSessionActive(gomock.Any(), int64(1), int64(2)).Return(true, nil),
+		mockSessions.EXPECT().IsSessionActive(gomock.Any(), int64(1), int64(2)).Return(true, nil),
+		mockSessions.EXPECT().IsSessionActive(gomock.Any(), int64(1), int64(2)).Return(true, nil),
+		mockSessions.EXPECT().IsSessionActive(gomock.Any(), int64(1), int64(2)).Return(true, nil),
+		mockSessions.EXPECT().IsSessionActive(gomock.Any(), int64(1), int64(2)).Return(false, nil),
+		mockSessions.EXPECT().IsSessionActive(gomock.Any(), int64(1), int64(2)).Return(false, fmt.Errorf("sessions error")),
+	)
+
 	type args struct {
 		authorizationHeader string
 		role                int
@@ -125,10 +135,40 @@ func TestAuthorizeUser(t *testing.T) {
 				statusCode: http.StatusForbidden,
 			},
 		},
+		{
+			name: "revoked session",
+			args: args{
+				authorizationHeader: string("Bearer ") + validToken,
+				role:                data.RoleUser,
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "sessions storage error",
+			args: args{
+				authorizationHeader: string("Bearer ") + validToken,
+				role:                data.RoleUser,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "damaged token",
+			args: args{
+				authorizationHeader: string("Bearer ") + validToken + "af",
+				role:                data.RoleUser,
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := httptest.NewServer(AuthorizeUser(testHandler{}, tt.args.role, mockStorage, jwtGen, log))
+			ts := httptest.NewServer(AuthorizeUser(testHandler{}, tt.args.role, mockStorage, mockSessions, jwtGen, log))
 			defer ts.Close()
 
 			req, errReq := http.NewRequest(http.MethodPost, ts.URL, nil)
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+)
+
+func GetSessionIDFromContext(ctx context.Context) (int64, error) {
+	sessionIDraw := ctx.Value(ContextString(data.SessionID))
+	if sessionIDraw == nil {
+		return -1, fmt.Errorf("missing sessionID in request's context")
+	}
+
+	sessionID, err := strconv.ParseInt(sessionIDraw.(string), 10, 64)
+	if err != nil {
+		return -1, fmt.Errorf("parse sessionID from request's context: %w", err)
+	}
+
+	return sessionID, nil
+}
@@ -0,0 +1,50 @@
+package refreshtoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	token1, err := Generate()
+	require.NoError(t, err)
+
+	token2, err := Generate()
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, token1)
+	assert.NotEqual(t, token1, token2)
+}
+
+func TestHash(t *testing.T) {
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "valid",
+			args: args{
+				token: "token",
+			},
+			want: "3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0",
+		},
+		{
+			name: "empty token",
+			args: args{
+				token: "",
+			},
+			want: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Hash(tt.args.token))
+		})
+	}
+}
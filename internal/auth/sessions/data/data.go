@@ -0,0 +1,23 @@
+package data
+
+import (
+	"fmt"
+)
+
+const SessionID = "sessionID"
+
+// ErrSessionNotFound missing, expired or revoked session.
+var ErrSessionNotFound = fmt.Errorf("session not found")
+
+// Tokens pair of tokens returned to user on authentication. Access token is passed in 'Authorization' header.
+//
+//go:generate easyjson -all data.go
+type Tokens struct {
+	AccessToken  string `json:"-"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshRequest request body for access token refresh.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,9 @@
+package data
+
+// Filter sessions selection conditions. Zero value fields are not applied.
+type Filter struct {
+	ID               int64
+	RefreshTokenHash string
+	// SupersededRefreshTokenHash selects session whose refresh token was replaced by rotation.
+	SupersededRefreshTokenHash string
+}
@@ -0,0 +1,19 @@
+package data
+
+import (
+	"time"
+)
+
+// Session represents user's authorized session. Refresh token is stored as hash only.
+type Session struct {
+	ID               int64
+	UserID           int64
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+	Revoked          bool
+}
+
+// IsActive checks if session can be used for authorization.
+func (s *Session) IsActive() bool {
+	return !s.Revoked && time.Now().Before(s.ExpiresAt)
+}
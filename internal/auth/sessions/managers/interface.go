@@ -0,0 +1,18 @@
+package managers
+
+import (
+	"context"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+)
+
+//go:generate mockgen -destination=../../../../mocks/mock_BaseSessionsManager.go -package=mocks github.com/erupshis/bonusbridge/internal/auth/sessions/managers BaseSessionsManager
+type BaseSessionsManager interface {
+	AddSession(ctx context.Context, session *data.Session) (int64, error)
+	GetSession(ctx context.Context, sessionID int64) (*data.Session, error)
+	GetSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (*data.Session, error)
+	GetSessionBySupersededRefreshToken(ctx context.Context, refreshTokenHash string) (*data.Session, error)
+	RotateRefreshToken(ctx context.Context, sessionID int64, oldRefreshTokenHash string, refreshTokenHash string, expiresAt time.Time) (bool, error)
+	RevokeSession(ctx context.Context, sessionID int64) error
+}
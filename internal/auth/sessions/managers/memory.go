@@ -0,0 +1,104 @@
+package managers
+
+import (
+	"context"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+type memoryManager struct {
+	store *memory.Store
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation keeping sessions in memory.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseSessionsManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+func (p *memoryManager) AddSession(_ context.Context, session *data.Session) (int64, error) {
+	p.log.Info("[sessions:memoryManager:AddSession] add session for userID '%d'", session.UserID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	newSession := *session
+	newSession.ID = p.store.NextID("sessions")
+	p.store.Sessions = append(p.store.Sessions, newSession)
+	return newSession.ID, nil
+}
+
+func (p *memoryManager) GetSession(_ context.Context, sessionID int64) (*data.Session, error) {
+	return p.getSession(func(session *data.Session) bool { return session.ID == sessionID }), nil
+}
+
+func (p *memoryManager) GetSessionByRefreshToken(_ context.Context, refreshTokenHash string) (*data.Session, error) {
+	return p.getSession(func(session *data.Session) bool { return session.RefreshTokenHash == refreshTokenHash }), nil
+}
+
+// GetSessionBySupersededRefreshToken returns session whose refresh token was replaced by rotation.
+func (p *memoryManager) GetSessionBySupersededRefreshToken(_ context.Context, refreshTokenHash string) (*data.Session, error) {
+	p.store.Lock()
+	sessionID, ok := p.store.SupersededRefreshTokens[refreshTokenHash]
+	p.store.Unlock()
+
+	if !ok {
+		return nil, nil
+	}
+	return p.getSession(func(session *data.Session) bool { return session.ID == sessionID }), nil
+}
+
+// RotateRefreshToken replaces session's refresh token if it is still oldRefreshTokenHash and keeps the old one as superseded.
+// Returns false if the old token has already been rotated by another request.
+func (p *memoryManager) RotateRefreshToken(_ context.Context, sessionID int64, oldRefreshTokenHash string, refreshTokenHash string, expiresAt time.Time) (bool, error) {
+	rotated := false
+	p.updateSession(sessionID, func(session *data.Session) {
+		if session.RefreshTokenHash != oldRefreshTokenHash {
+			return
+		}
+
+		session.RefreshTokenHash = refreshTokenHash
+		session.ExpiresAt = expiresAt
+		p.store.SupersededRefreshTokens[oldRefreshTokenHash] = sessionID
+		rotated = true
+	})
+	return rotated, nil
+}
+
+func (p *memoryManager) RevokeSession(_ context.Context, sessionID int64) error {
+	p.updateSession(sessionID, func(session *data.Session) { session.Revoked = true })
+	return nil
+}
+
+func (p *memoryManager) updateSession(sessionID int64, update func(session *data.Session)) {
+	p.log.Info("[sessions:memoryManager:updateSession] update sessionID '%d'", sessionID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.Sessions {
+		if p.store.Sessions[i].ID == sessionID {
+			update(&p.store.Sessions[i])
+			return
+		}
+	}
+}
+
+func (p *memoryManager) getSession(match func(session *data.Session) bool) *data.Session {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.Sessions {
+		if match(&p.store.Sessions[i]) {
+			session := p.store.Sessions[i]
+			return &session
+		}
+	}
+	return nil
+}
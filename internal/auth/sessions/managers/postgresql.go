@@ -0,0 +1,160 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries/sessions"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// manager storageManager implementation for PostgreSQL.
+type manager struct {
+	*db.Conn
+
+	log logger.BaseLogger
+}
+
+// Create creates manager implementation.
+func Create(dbConn *db.Conn, log logger.BaseLogger) BaseSessionsManager {
+	return &manager{
+		Conn: dbConn,
+		log:  log,
+	}
+}
+
+func (p *manager) AddSession(ctx context.Context, session *data.Session) (int64, error) {
+	p.log.Info("[sessions:manager:AddSession] start transaction for userID '%d'", session.UserID)
+	errMsg := "add session in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	id, err := sessions.Insert(ctx, tx, session, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[sessions:manager:AddSession] transaction successful")
+	return id, nil
+}
+
+func (p *manager) GetSession(ctx context.Context, sessionID int64) (*data.Session, error) {
+	session, err := p.getSession(ctx, data.Filter{ID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (p *manager) GetSessionByRefreshToken(ctx context.Context, refreshTokenHash string) (*data.Session, error) {
+	session, err := p.getSession(ctx, data.Filter{RefreshTokenHash: refreshTokenHash})
+	if err != nil {
+		return nil, fmt.Errorf("get session by refresh token: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetSessionBySupersededRefreshToken returns session whose refresh token was replaced by rotation.
+func (p *manager) GetSessionBySupersededRefreshToken(ctx context.Context, refreshTokenHash string) (*data.Session, error) {
+	session, err := p.getSession(ctx, data.Filter{SupersededRefreshTokenHash: refreshTokenHash})
+	if err != nil {
+		return nil, fmt.Errorf("get session by superseded refresh token: %w", err)
+	}
+
+	return session, nil
+}
+
+// RotateRefreshToken replaces session's refresh token if it is still oldRefreshTokenHash and keeps the old one as superseded.
+// Returns false if the old token has already been rotated by another request.
+func (p *manager) RotateRefreshToken(ctx context.Context, sessionID int64, oldRefreshTokenHash string, refreshTokenHash string, expiresAt time.Time) (bool, error) {
+	p.log.Info("[sessions:manager:RotateRefreshToken] start transaction for sessionID '%d'", sessionID)
+	errMsg := "rotate refresh token in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	values := sessions.Values{
+		RefreshTokenHash: &refreshTokenHash,
+		ExpiresAt:        &expiresAt,
+	}
+	rotated, err := sessions.UpdateByIDAndRefreshToken(ctx, tx, sessionID, oldRefreshTokenHash, values, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	if rotated {
+		if err = sessions.InsertSupersededRefreshToken(ctx, tx, sessionID, oldRefreshTokenHash, p.log); err != nil {
+			helpers.ExecuteWithLogError(tx.Rollback, p.log)
+			return false, fmt.Errorf(errMsg, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[sessions:manager:RotateRefreshToken] transaction successful")
+	return rotated, nil
+}
+
+func (p *manager) RevokeSession(ctx context.Context, sessionID int64) error {
+	revoked := true
+	if err := p.updateSession(ctx, sessionID, sessions.Values{Revoked: &revoked}); err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func (p *manager) updateSession(ctx context.Context, sessionID int64, values sessions.Values) error {
+	p.log.Info("[sessions:manager:updateSession] start transaction for sessionID '%d'", sessionID)
+	errMsg := "update session in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = sessions.UpdateByID(ctx, tx, sessionID, values, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[sessions:manager:updateSession] transaction successful")
+	return nil
+}
+
+func (p *manager) getSession(ctx context.Context, filter data.Filter) (*data.Session, error) {
+	p.log.Info("[sessions:manager:getSession] perform request")
+
+	sessionsSelected, err := sessions.Select(ctx, p.DB, filter, p.log)
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+
+	p.log.Info("[sessions:manager:getSession] request successful")
+
+	if len(sessionsSelected) == 0 {
+		return nil, nil
+	}
+	return &sessionsSelected[0], nil
+}
@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+)
+
+//go:generate mockgen -destination=../../../../mocks/mock_BaseSessionsStorage.go -package=mocks github.com/erupshis/bonusbridge/internal/auth/sessions/storage BaseSessionsStorage
+type BaseSessionsStorage interface {
+	CreateSession(ctx context.Context, userID int64) (*data.Tokens, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*data.Tokens, error)
+	RevokeSession(ctx context.Context, sessionID int64) error
+	IsSessionActive(ctx context.Context, sessionID int64, userID int64) (bool, error)
+}
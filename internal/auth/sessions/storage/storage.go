@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/auth/refreshtoken"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/managers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+type Storage struct {
+	manager managers.BaseSessionsManager
+	jwt     jwtgenerator.JwtGenerator
+
+	refreshTokenExp int
+
+	log logger.BaseLogger
+}
+
+// Create creates sessions storage. refreshTokenExp - refresh token lifetime in hours.
+func Create(manager managers.BaseSessionsManager, jwt jwtgenerator.JwtGenerator, refreshTokenExp int, baseLogger logger.BaseLogger) BaseSessionsStorage {
+	return &Storage{
+		manager:         manager,
+		jwt:             jwt,
+		refreshTokenExp: refreshTokenExp,
+		log:             baseLogger,
+	}
+}
+
+func (s *Storage) CreateSession(ctx context.Context, userID int64) (*data.Tokens, error) {
+	errMsg := fmt.Sprintf("create session for userID '%d'", userID) + ": %w"
+
+	refreshToken, err := refreshtoken.Generate()
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	session := &data.Session{
+		UserID:           userID,
+		RefreshTokenHash: refreshtoken.Hash(refreshToken),
+		ExpiresAt:        s.refreshTokenExpiresAt(),
+	}
+
+	session.ID, err = s.manager.AddSession(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	accessToken, err := s.jwt.BuildJWTString(userID, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	return &data.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// RefreshSession rotates refresh token of active session. Concurrent reuse of the same token or reuse of the token
+// replaced by rotation revokes the session.
+func (s *Storage) RefreshSession(ctx context.Context, refreshToken string) (*data.Tokens, error) {
+	errMsg := "refresh session: %w"
+
+	refreshTokenHash := refreshtoken.Hash(refreshToken)
+	session, err := s.manager.GetSessionByRefreshToken(ctx, refreshTokenHash)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if session == nil {
+		if err = s.revokeSupersededSession(ctx, refreshTokenHash); err != nil {
+			return nil, fmt.Errorf(errMsg, err)
+		}
+		return nil, fmt.Errorf(errMsg, data.ErrSessionNotFound)
+	}
+
+	if !session.IsActive() {
+		return nil, fmt.Errorf(errMsg, data.ErrSessionNotFound)
+	}
+
+	newRefreshToken, err := refreshtoken.Generate()
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	rotated, err := s.manager.RotateRefreshToken(ctx, session.ID, refreshTokenHash, refreshtoken.Hash(newRefreshToken), s.refreshTokenExpiresAt())
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if !rotated {
+		// the same refresh token was used twice, it might be stolen. Both holders lose the session.
+		s.log.Info("[sessions:Storage:RefreshSession] refresh token reuse detected, revoke sessionID '%d'", session.ID)
+		if err = s.manager.RevokeSession(ctx, session.ID); err != nil {
+			return nil, fmt.Errorf(errMsg, err)
+		}
+		return nil, fmt.Errorf(errMsg, data.ErrSessionNotFound)
+	}
+
+	accessToken, err := s.jwt.BuildJWTString(session.UserID, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	return &data.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+	}, nil
+}
+
+func (s *Storage) RevokeSession(ctx context.Context, sessionID int64) error {
+	if err := s.manager.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("revoke sessionID '%d': %w", sessionID, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) IsSessionActive(ctx context.Context, sessionID int64, userID int64) (bool, error) {
+	session, err := s.manager.GetSession(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("check sessionID '%d': %w", sessionID, err)
+	}
+
+	if session == nil || session.UserID != userID {
+		return false, nil
+	}
+
+	return session.IsActive(), nil
+}
+
+// revokeSupersededSession revokes session if refresh token was replaced by rotation before.
+// The token is presented after its successor was issued, it might be stolen. Both holders lose the session.
+func (s *Storage) revokeSupersededSession(ctx context.Context, refreshTokenHash string) error {
+	session, err := s.manager.GetSessionBySupersededRefreshToken(ctx, refreshTokenHash)
+	if err != nil {
+		return err
+	}
+
+	if session == nil || session.Revoked {
+		return nil
+	}
+
+	s.log.Info("[sessions:Storage:revokeSupersededSession] superseded refresh token reuse detected, revoke sessionID '%d'", session.ID)
+	return s.manager.RevokeSession(ctx, session.ID)
+}
+
+func (s *Storage) refreshTokenExpiresAt() time.Time {
+	return time.Now().Add(time.Duration(s.refreshTokenExp) * time.Hour)
+}
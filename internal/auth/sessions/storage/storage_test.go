@@ -0,0 +1,273 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/jwtgenerator"
+	"github.com/erupshis/bonusbridge/internal/auth/refreshtoken"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/managers"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_CreateSession(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jwtGen := jwtgenerator.Create("secret_key", 1, log)
+
+	mockManager := mocks.NewMockBaseSessionsManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().AddSession(gomock.Any(), gomock.Any()).Return(int64(5), nil),
+		mockManager.EXPECT().AddSession(gomock.Any(), gomock.Any()).Return(int64(-1), fmt.Errorf("manager error")),
+	)
+
+	s := Create(mockManager, jwtGen, 24, log)
+
+	tokens, err := s.CreateSession(context.Background(), 2)
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	claims := jwtGen.GetClaims(tokens.AccessToken)
+	require.NotNil(t, claims)
+	assert.Equal(t, int64(2), claims.UserID)
+	assert.Equal(t, int64(5), claims.SessionID)
+
+	_, err = s.CreateSession(context.Background(), 2)
+	assert.Error(t, err)
+}
+
+func TestStorage_RefreshSession(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jwtGen := jwtgenerator.Create("secret_key", 1, log)
+
+	activeSession := &data.Session{
+		ID:               5,
+		UserID:           2,
+		RefreshTokenHash: refreshtoken.Hash("refresh"),
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+	revokedSession := &data.Session{
+		ID:        5,
+		UserID:    2,
+		ExpiresAt: time.Now().Add(time.Hour),
+		Revoked:   true,
+	}
+	expiredSession := &data.Session{
+		ID:        5,
+		UserID:    2,
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+
+	mockManager := mocks.NewMockBaseSessionsManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), refreshtoken.Hash("refresh")).Return(activeSession, nil),
+		mockManager.EXPECT().RotateRefreshToken(gomock.Any(), int64(5), refreshtoken.Hash("refresh"), gomock.Any(), gomock.Any()).Return(true, nil),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(nil, nil),
+		mockManager.EXPECT().GetSessionBySupersededRefreshToken(gomock.Any(), refreshtoken.Hash("refresh")).Return(nil, nil),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(nil, nil),
+		mockManager.EXPECT().GetSessionBySupersededRefreshToken(gomock.Any(), refreshtoken.Hash("refresh")).Return(activeSession, nil),
+		mockManager.EXPECT().RevokeSession(gomock.Any(), int64(5)).Return(nil),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(revokedSession, nil),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(expiredSession, nil),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("manager error")),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(activeSession, nil),
+		mockManager.EXPECT().RotateRefreshToken(gomock.Any(), int64(5), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("manager error")),
+		mockManager.EXPECT().GetSessionByRefreshToken(gomock.Any(), gomock.Any()).Return(activeSession, nil),
+		mockManager.EXPECT().RotateRefreshToken(gomock.Any(), int64(5), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil),
+		mockManager.EXPECT().RevokeSession(gomock.Any(), int64(5)).Return(nil),
+	)
+
+	s := Create(mockManager, jwtGen, 24, log)
+
+	tests := []struct {
+		name        string
+		wantErr     bool
+		wantErrType error
+	}{
+		{
+			name:    "valid",
+			wantErr: false,
+		},
+		{
+			name:        "missing session",
+			wantErr:     true,
+			wantErrType: data.ErrSessionNotFound,
+		},
+		{
+			name:        "token superseded by rotation",
+			wantErr:     true,
+			wantErrType: data.ErrSessionNotFound,
+		},
+		{
+			name:        "revoked session",
+			wantErr:     true,
+			wantErrType: data.ErrSessionNotFound,
+		},
+		{
+			name:        "expired session",
+			wantErr:     true,
+			wantErrType: data.ErrSessionNotFound,
+		},
+		{
+			name:    "manager error on search",
+			wantErr: true,
+		},
+		{
+			name:    "manager error on rotation",
+			wantErr: true,
+		},
+		{
+			name:        "token already rotated by concurrent request",
+			wantErr:     true,
+			wantErrType: data.ErrSessionNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := s.RefreshSession(context.Background(), "refresh")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefreshSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErrType != nil {
+				assert.True(t, errors.Is(err, tt.wantErrType))
+			}
+
+			if !tt.wantErr {
+				assert.NotEqual(t, "refresh", tokens.RefreshToken)
+				claims := jwtGen.GetClaims(tokens.AccessToken)
+				require.NotNil(t, claims)
+				assert.Equal(t, int64(5), claims.SessionID)
+			}
+		})
+	}
+}
+
+func TestStorage_RefreshSessionReplay(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctx := context.Background()
+	jwtGen := jwtgenerator.Create("secret_key", 1, log)
+	s := Create(managers.CreateInMemory(memory.Create(), log), jwtGen, 24, log)
+
+	tokens, err := s.CreateSession(ctx, 2)
+	require.NoError(t, err)
+
+	rotatedTokens, err := s.RefreshSession(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+
+	// replay of the old token after successful rotation revokes the whole session.
+	_, err = s.RefreshSession(ctx, tokens.RefreshToken)
+	assert.ErrorIs(t, err, data.ErrSessionNotFound)
+
+	_, err = s.RefreshSession(ctx, rotatedTokens.RefreshToken)
+	assert.ErrorIs(t, err, data.ErrSessionNotFound)
+
+	claims := jwtGen.GetClaims(rotatedTokens.AccessToken)
+	require.NotNil(t, claims)
+	active, err := s.IsSessionActive(ctx, claims.SessionID, 2)
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestStorage_IsSessionActive(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	activeSession := &data.Session{
+		ID:        5,
+		UserID:    2,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	revokedSession := &data.Session{
+		ID:        5,
+		UserID:    2,
+		ExpiresAt: time.Now().Add(time.Hour),
+		Revoked:   true,
+	}
+
+	mockManager := mocks.NewMockBaseSessionsManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().GetSession(gomock.Any(), int64(5)).Return(activeSession, nil),
+		mockManager.EXPECT().GetSession(gomock.Any(), int64(5)).Return(revokedSession, nil),
+		mockManager.EXPECT().GetSession(gomock.Any(), int64(5)).Return(activeSession, nil),
+		mockManager.EXPECT().GetSession(gomock.Any(), int64(5)).Return(nil, nil),
+		mockManager.EXPECT().GetSession(gomock.Any(), int64(5)).Return(nil, fmt.Errorf("manager error")),
+	)
+
+	s := Create(mockManager, jwtgenerator.Create("secret_key", 1, log), 24, log)
+
+	type args struct {
+		userID int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			args:    args{userID: 2},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "revoked session",
+			args:    args{userID: 2},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "session of another user",
+			args:    args{userID: 3},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "missing session",
+			args:    args{userID: 2},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "manager error",
+			args:    args{userID: 2},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.IsSessionActive(context.Background(), 5, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsSessionActive() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -11,9 +11,36 @@ const (
 	RoleAdmin
 )
 
+// GetRoleID converts role name into id. Returns -1 for unknown role.
+func GetRoleID(roleStr string) int {
+	switch roleStr {
+	case "USER":
+		return RoleUser
+	case "ADMIN":
+		return RoleAdmin
+	default:
+		return -1
+	}
+}
+
+// GetRoleName converts role id into name.
+func GetRoleName(role int) string {
+	switch role {
+	case RoleUser:
+		return "USER"
+	case RoleAdmin:
+		return "ADMIN"
+	default:
+		return "UNDEFINED"
+	}
+}
+
 // ErrUserNotFound missing user in database.
 var ErrUserNotFound = fmt.Errorf("user not found")
 
+// ErrLoginExists user with the same login has already been added.
+var ErrLoginExists = fmt.Errorf("login already exists")
+
 // User represents a user in our system.
 //
 //go:generate easyjson -all data.go
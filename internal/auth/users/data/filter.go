@@ -0,0 +1,7 @@
+package data
+
+// Filter users selection conditions. Zero value fields are not applied.
+type Filter struct {
+	ID    int64
+	Login string
+}
@@ -0,0 +1,113 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+type memoryManager struct {
+	store *memory.Store
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation keeping users in memory.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseUsersManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+func (p *memoryManager) AddUser(_ context.Context, user *data.User) (int64, error) {
+	p.log.Info("[users:memoryManager:AddUser] add user '%s'", user.Login)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for _, existing := range p.store.Users {
+		if existing.Login == user.Login {
+			return -1, fmt.Errorf("add user in memory: login '%s': %w", user.Login, data.ErrLoginExists)
+		}
+	}
+
+	newUser := *user
+	newUser.ID = p.store.NextID("users")
+	p.store.Users = append(p.store.Users, newUser)
+	return newUser.ID, nil
+}
+
+func (p *memoryManager) UpdateUserPassword(_ context.Context, userID int64, password string) error {
+	p.log.Info("[users:memoryManager:UpdateUserPassword] update userID '%d'", userID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if i := p.findUser(data.Filter{ID: userID}); i != -1 {
+		p.store.Users[i].Password = password
+	}
+	return nil
+}
+
+func (p *memoryManager) UpdateUserRole(_ context.Context, userID int64, role int) error {
+	p.log.Info("[users:memoryManager:UpdateUserRole] update userID '%d'", userID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if i := p.findUser(data.Filter{ID: userID}); i != -1 {
+		p.store.Users[i].Role = role
+	}
+	return nil
+}
+
+func (p *memoryManager) GetUser(_ context.Context, login string) (*data.User, error) {
+	return p.getUser(data.Filter{Login: login}), nil
+}
+
+func (p *memoryManager) GetUserID(_ context.Context, login string) (int64, error) {
+	user := p.getUser(data.Filter{Login: login})
+	if user == nil {
+		return -1, nil
+	}
+
+	return user.ID, nil
+}
+
+func (p *memoryManager) GetUserRole(_ context.Context, userID int64) (int, error) {
+	user := p.getUser(data.Filter{ID: userID})
+	if user == nil {
+		return -1, nil
+	}
+
+	return user.Role, nil
+}
+
+func (p *memoryManager) getUser(filter data.Filter) *data.User {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	i := p.findUser(filter)
+	if i == -1 {
+		return nil
+	}
+
+	user := p.store.Users[i]
+	return &user
+}
+
+// findUser returns index of user satisfying filter or -1. Must be called under store lock.
+func (p *memoryManager) findUser(filter data.Filter) int {
+	for i, user := range p.store.Users {
+		if filter.ID != 0 && user.ID != filter.ID {
+			continue
+		}
+		if filter.Login != "" && user.Login != filter.Login {
+			continue
+		}
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,15 @@
+package managers
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func TestMemoryManager_Users(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testUsers(t, CreateInMemory(memory.Create(), log))
+}
@@ -28,7 +28,7 @@ func Create(dbConn *db.Conn, log logger.BaseLogger) BaseUsersManager {
 }
 
 func (p *manager) AddUser(ctx context.Context, user *data.User) (int64, error) {
-	p.log.Info("[users:manager:AddUser] start transaction with user data '%v'", *user)
+	p.log.Info("[users:manager:AddUser] start transaction for user '%s'", user.Login)
 	errMsg := "add user in db: %w"
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
@@ -50,8 +50,54 @@ func (p *manager) AddUser(ctx context.Context, user *data.User) (int64, error) {
 	return p.GetUserID(ctx, user.Login)
 }
 
+func (p *manager) UpdateUserPassword(ctx context.Context, userID int64, password string) error {
+	p.log.Info("[users:manager:UpdateUserPassword] start transaction for userID '%d'", userID)
+	errMsg := "update user password in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	err = users.UpdateByID(ctx, tx, userID, users.Values{Password: &password}, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[users:manager:UpdateUserPassword] transaction successful")
+	return nil
+}
+
+func (p *manager) UpdateUserRole(ctx context.Context, userID int64, role int) error {
+	p.log.Info("[users:manager:UpdateUserRole] start transaction for userID '%d'", userID)
+	errMsg := "update user role in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	err = users.UpdateByID(ctx, tx, userID, users.Values{RoleID: &role}, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[users:manager:UpdateUserRole] transaction successful")
+	return nil
+}
+
 func (p *manager) GetUser(ctx context.Context, login string) (*data.User, error) {
-	user, err := p.getUser(ctx, map[string]interface{}{"login": login})
+	user, err := p.getUser(ctx, data.Filter{Login: login})
 	if err != nil {
 		return nil, fmt.Errorf("get user: %w", err)
 	}
@@ -64,7 +110,7 @@ func (p *manager) GetUser(ctx context.Context, login string) (*data.User, error)
 }
 
 func (p *manager) GetUserID(ctx context.Context, login string) (int64, error) {
-	user, err := p.getUser(ctx, map[string]interface{}{"login": login})
+	user, err := p.getUser(ctx, data.Filter{Login: login})
 	if err != nil {
 		return -1, fmt.Errorf("get user ID: %w", err)
 	}
@@ -77,7 +123,7 @@ func (p *manager) GetUserID(ctx context.Context, login string) (int64, error) {
 }
 
 func (p *manager) GetUserRole(ctx context.Context, userID int64) (int, error) {
-	user, err := p.getUser(ctx, map[string]interface{}{"id": userID})
+	user, err := p.getUser(ctx, data.Filter{ID: userID})
 	if err != nil {
 		return -1, fmt.Errorf("get user role: %w", err)
 	}
@@ -89,11 +135,11 @@ func (p *manager) GetUserRole(ctx context.Context, userID int64) (int, error) {
 	return user.Role, nil
 }
 
-func (p *manager) getUser(ctx context.Context, filters map[string]interface{}) (*data.User, error) {
-	p.log.Info("[users:manager:getUser] perform request with filters '%v'", filters)
+func (p *manager) getUser(ctx context.Context, filter data.Filter) (*data.User, error) {
+	p.log.Info("[users:manager:getUser] perform request with filter '%+v'", filter)
 	errMsg := "get user: %w"
 
-	usersSelected, err := users.Select(ctx, p.DB, filters, p.log)
+	usersSelected, err := users.Select(ctx, p.DB, filter, p.log)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
@@ -109,27 +155,3 @@ func (p *manager) getUser(ctx context.Context, filters map[string]interface{}) (
 	}
 	return &usersSelected[0], nil
 }
-
-//TODO: hash password in db.
-//password := "user_password" // Replace with the actual password provided by the user
-//
-//// Hash and salt the password
-//hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-//if err != nil {
-//log.Fatal(err)
-//}
-//
-//// Store 'hashedPassword' in the database for the user
-//
-//// User login: Verify password
-//providedPassword := "user_password" // Replace with the password provided during login
-//
-//// Verify the provided password with the stored hashed password
-//err = bcrypt.CompareHashAndPassword(hashedPassword, []byte(providedPassword))
-//if err == nil {
-//fmt.Println("Password is correct!")
-//} else if err == bcrypt.ErrMismatchedHashAndPassword {
-//fmt.Println("Password is incorrect.")
-//} else {
-//log.Fatal(err)
-//}
@@ -0,0 +1,15 @@
+package managers
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/db/dbtest"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func TestSQLiteManager_Users(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testUsers(t, Create(dbtest.CreateSQLiteConnection(t), log))
+}
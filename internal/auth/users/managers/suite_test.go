@@ -0,0 +1,43 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testUsers checks users registration and updates. Scenarios are shared by managers implementations.
+func testUsers(t *testing.T, manager BaseUsersManager) {
+	ctx := context.Background()
+	login := fmt.Sprintf("user_%d", time.Now().UnixNano())
+
+	userID, err := manager.GetUserID(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), userID)
+
+	userID, err = manager.AddUser(ctx, &data.User{Login: login, Password: "hash", Role: data.RoleUser})
+	require.NoError(t, err)
+
+	_, err = manager.AddUser(ctx, &data.User{Login: login, Password: "another", Role: data.RoleUser})
+	assert.Error(t, err)
+
+	require.NoError(t, manager.UpdateUserPassword(ctx, userID, "new hash"))
+	require.NoError(t, manager.UpdateUserRole(ctx, userID, data.RoleAdmin))
+
+	user, err := manager.GetUser(ctx, login)
+	require.NoError(t, err)
+	assert.Equal(t, &data.User{ID: userID, Login: login, Password: "new hash", Role: data.RoleAdmin}, user)
+
+	role, err := manager.GetUserRole(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, data.RoleAdmin, role)
+
+	user, err = manager.GetUser(ctx, login+"_missing")
+	require.NoError(t, err)
+	assert.Nil(t, user)
+}
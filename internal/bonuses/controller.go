@@ -3,27 +3,31 @@ package bonuses
 import (
 	"github.com/erupshis/bonusbridge/internal/bonuses/handlers"
 	"github.com/erupshis/bonusbridge/internal/bonuses/storage"
+	"github.com/erupshis/bonusbridge/internal/idempotency"
+	idempotencyStorage "github.com/erupshis/bonusbridge/internal/idempotency/storage"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/go-chi/chi/v5"
 )
 
 type Controller struct {
-	storage storage.BaseBonusesStorage
+	storage         storage.BaseBonusesStorage
+	idempotencyStrg idempotencyStorage.BaseIdempotencyStorage
 
 	log logger.BaseLogger
 }
 
-func CreateController(storage storage.BaseBonusesStorage, baseLogger logger.BaseLogger) Controller {
+func CreateController(storage storage.BaseBonusesStorage, idempotencyStrg idempotencyStorage.BaseIdempotencyStorage, baseLogger logger.BaseLogger) Controller {
 	return Controller{
-		storage: storage,
-		log:     baseLogger,
+		storage:         storage,
+		idempotencyStrg: idempotencyStrg,
+		log:             baseLogger,
 	}
 }
 
 func (c *Controller) RouteBonuses() *chi.Mux {
 	r := chi.NewRouter()
 	r.Get("/", handlers.Balance(c.storage, c.log))
-	r.Post("/withdraw", handlers.Withdraw(c.storage, c.log))
+	r.With(idempotency.Middleware(c.idempotencyStrg, c.log)).Post("/withdraw", handlers.Withdraw(c.storage, c.log))
 
 	return r
 }
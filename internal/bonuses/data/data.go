@@ -3,24 +3,88 @@ package data
 import (
 	"fmt"
 	"time"
+
+	"github.com/erupshis/bonusbridge/internal/money"
 )
 
 var ErrNotEnoughBonuses = fmt.Errorf("not enough bonuses for withdrawal")
 var ErrWithdrawalsMissing = fmt.Errorf("user doesn't have any withdrawal")
+var ErrInvalidAdjustment = fmt.Errorf("invalid bonuses adjustment")
+
+const (
+	ReasonGoodwill = iota + 1
+	ReasonCompensation
+	ReasonCorrection
+)
+
+// GetAdjustmentReasonID converts adjustment reason code into id. Returns -1 for unknown code.
+func GetAdjustmentReasonID(reasonStr string) int {
+	switch reasonStr {
+	case "GOODWILL":
+		return ReasonGoodwill
+	case "COMPENSATION":
+		return ReasonCompensation
+	case "CORRECTION":
+		return ReasonCorrection
+	default:
+		return -1
+	}
+}
 
 //go:generate easyjson -all data.go
 type Balance struct {
-	ID        int64   `json:"-"`
-	UserID    int64   `json:"-"`
-	Current   float32 `json:"current"`
-	Withdrawn float32 `json:"withdrawn"`
+	ID        int64        `json:"-"`
+	UserID    int64        `json:"-"`
+	Current   money.Amount `json:"current"`
+	Withdrawn money.Amount `json:"withdrawn"`
+	Accrued   money.Amount `json:"-"`
+}
+
+// BalanceMismatch materialized balance that differs from balance calculated by bonuses ledger.
+type BalanceMismatch struct {
+	UserID int64   `json:"user_id"`
+	Stored Balance `json:"stored"`
+	Ledger Balance `json:"ledger"`
 }
 
 type Withdrawal struct {
-	ID          int64     `json:"-"`
-	UserID      int64     `json:"-"`
-	BonusID     int64     `json:"-"`
-	Order       string    `json:"order"`
-	Sum         float32   `json:"sum"`
-	ProcessedAt time.Time `json:"processed_at"`
+	ID          int64        `json:"-"`
+	UserID      int64        `json:"-"`
+	BonusID     int64        `json:"-"`
+	Order       string       `json:"order"`
+	Sum         money.Amount `json:"sum"`
+	ProcessedAt time.Time    `json:"processed_at"`
+}
+
+// WithdrawalsSummary count and total sum of withdrawals for requested period.
+type WithdrawalsSummary struct {
+	Count int64        `json:"count"`
+	Sum   money.Amount `json:"sum"`
+}
+
+// WithdrawalsPage page of withdrawals with summary of all withdrawals for requested period.
+type WithdrawalsPage struct {
+	Withdrawals []Withdrawal        `json:"withdrawals"`
+	Summary     *WithdrawalsSummary `json:"summary"`
+}
+
+// Bonus ledger record. Positive count - accrual for order, negative - withdrawal.
+type Bonus struct {
+	ID     int64        `json:"id"`
+	UserID int64        `json:"-"`
+	Order  string       `json:"order,omitempty"`
+	Reason string       `json:"reason,omitempty"`
+	Count  money.Amount `json:"count"`
+}
+
+// Adjustment manual bonuses credit(positive sum) or debit(negative sum) made by support staff.
+type Adjustment struct {
+	ID         int64        `json:"id"`
+	UserID     int64        `json:"-"`
+	BonusID    int64        `json:"-"`
+	OperatorID int64        `json:"operator_id"`
+	Sum        money.Amount `json:"sum"`
+	Reason     string       `json:"reason"`
+	Comment    string       `json:"comment,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
 }
@@ -17,7 +17,199 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(in *jlexer.Lexer, out *Withdrawal) {
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(in *jlexer.Lexer, out *WithdrawalsSummary) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int64(in.Int64())
+		case "sum":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Sum).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(out *jwriter.Writer, in WithdrawalsSummary) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Count))
+	}
+	{
+		const prefix string = ",\"sum\":"
+		out.RawString(prefix)
+		out.Raw((in.Sum).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WithdrawalsSummary) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WithdrawalsSummary) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WithdrawalsSummary) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WithdrawalsSummary) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(in *jlexer.Lexer, out *WithdrawalsPage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "withdrawals":
+			if in.IsNull() {
+				in.Skip()
+				out.Withdrawals = nil
+			} else {
+				in.Delim('[')
+				if out.Withdrawals == nil {
+					if !in.IsDelim(']') {
+						out.Withdrawals = make([]Withdrawal, 0, 0)
+					} else {
+						out.Withdrawals = []Withdrawal{}
+					}
+				} else {
+					out.Withdrawals = (out.Withdrawals)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Withdrawal
+					(v1).UnmarshalEasyJSON(in)
+					out.Withdrawals = append(out.Withdrawals, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "summary":
+			if in.IsNull() {
+				in.Skip()
+				out.Summary = nil
+			} else {
+				if out.Summary == nil {
+					out.Summary = new(WithdrawalsSummary)
+				}
+				(*out.Summary).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(out *jwriter.Writer, in WithdrawalsPage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"withdrawals\":"
+		out.RawString(prefix[1:])
+		if in.Withdrawals == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Withdrawals {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"summary\":"
+		out.RawString(prefix)
+		if in.Summary == nil {
+			out.RawString("null")
+		} else {
+			(*in.Summary).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WithdrawalsPage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WithdrawalsPage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WithdrawalsPage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WithdrawalsPage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData2(in *jlexer.Lexer, out *Withdrawal) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -39,7 +231,9 @@ func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(in *j
 		case "order":
 			out.Order = string(in.String())
 		case "sum":
-			out.Sum = float32(in.Float32())
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Sum).UnmarshalJSON(data))
+			}
 		case "processed_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.ProcessedAt).UnmarshalJSON(data))
@@ -54,7 +248,7 @@ func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(in *j
 		in.Consumed()
 	}
 }
-func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(out *jwriter.Writer, in Withdrawal) {
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData2(out *jwriter.Writer, in Withdrawal) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -71,7 +265,7 @@ func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(out *
 	{
 		const prefix string = ",\"sum\":"
 		out.RawString(prefix)
-		out.Float32(float32(in.Sum))
+		out.Raw((in.Sum).MarshalJSON())
 	}
 	{
 		const prefix string = ",\"processed_at\":"
@@ -84,27 +278,196 @@ func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(out *
 // MarshalJSON supports json.Marshaler interface
 func (v Withdrawal) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(&w, v)
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Withdrawal) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData(w, v)
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Withdrawal) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(&r, v)
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Withdrawal) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData(l, v)
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData2(l, v)
 }
-func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(in *jlexer.Lexer, out *Balance) {
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData3(in *jlexer.Lexer, out *Bonus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "order":
+			out.Order = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "count":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Count).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData3(out *jwriter.Writer, in Bonus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	if in.Order != "" {
+		const prefix string = ",\"order\":"
+		out.RawString(prefix)
+		out.String(string(in.Order))
+	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Raw((in.Count).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Bonus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Bonus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Bonus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Bonus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData3(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData4(in *jlexer.Lexer, out *BalanceMismatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = int64(in.Int64())
+		case "stored":
+			(out.Stored).UnmarshalEasyJSON(in)
+		case "ledger":
+			(out.Ledger).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData4(out *jwriter.Writer, in BalanceMismatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.UserID))
+	}
+	{
+		const prefix string = ",\"stored\":"
+		out.RawString(prefix)
+		(in.Stored).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"ledger\":"
+		out.RawString(prefix)
+		(in.Ledger).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BalanceMismatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BalanceMismatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BalanceMismatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BalanceMismatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData4(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData5(in *jlexer.Lexer, out *Balance) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -124,9 +487,13 @@ func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(in *
 		}
 		switch key {
 		case "current":
-			out.Current = float32(in.Float32())
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Current).UnmarshalJSON(data))
+			}
 		case "withdrawn":
-			out.Withdrawn = float32(in.Float32())
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Withdrawn).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -137,7 +504,7 @@ func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(in *
 		in.Consumed()
 	}
 }
-func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(out *jwriter.Writer, in Balance) {
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData5(out *jwriter.Writer, in Balance) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -149,12 +516,12 @@ func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(out
 		} else {
 			out.RawString(prefix)
 		}
-		out.Float32(float32(in.Current))
+		out.Raw((in.Current).MarshalJSON())
 	}
 	{
 		const prefix string = ",\"withdrawn\":"
 		out.RawString(prefix)
-		out.Float32(float32(in.Withdrawn))
+		out.Raw((in.Withdrawn).MarshalJSON())
 	}
 	out.RawByte('}')
 }
@@ -162,23 +529,128 @@ func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(out
 // MarshalJSON supports json.Marshaler interface
 func (v Balance) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(&w, v)
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Balance) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData1(w, v)
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Balance) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(&r, v)
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Balance) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData1(l, v)
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData5(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData6(in *jlexer.Lexer, out *Adjustment) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "operator_id":
+			out.OperatorID = int64(in.Int64())
+		case "sum":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Sum).UnmarshalJSON(data))
+			}
+		case "reason":
+			out.Reason = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData6(out *jwriter.Writer, in Adjustment) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"operator_id\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.OperatorID))
+	}
+	{
+		const prefix string = ",\"sum\":"
+		out.RawString(prefix)
+		out.Raw((in.Sum).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Adjustment) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Adjustment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalBonusesData6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Adjustment) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Adjustment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalBonusesData6(l, v)
 }
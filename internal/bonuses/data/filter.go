@@ -0,0 +1,28 @@
+package data
+
+import (
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/keyset"
+)
+
+// CursorOf returns keyset cursor pointing to withdrawal.
+func CursorOf(withdrawal *Withdrawal) keyset.Cursor {
+	return keyset.Cursor{Time: withdrawal.ProcessedAt, ID: withdrawal.ID}
+}
+
+// WithdrawalsFilter withdrawals selection conditions. Zero value fields are not applied.
+type WithdrawalsFilter struct {
+	UserID int64
+	From   time.Time // From inclusive lower bound of processing time.
+	To     time.Time // To exclusive upper bound of processing time.
+
+	Sort  keyset.Sort
+	After *keyset.Cursor // After selects withdrawals following cursor in Sort order, requires Sort.
+	Limit int
+}
+
+// AdjustmentsFilter adjustments selection conditions. Zero value fields are not applied.
+type AdjustmentsFilter struct {
+	UserID int64
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/erupshis/bonusbridge/internal/auth/middleware"
 	"github.com/erupshis/bonusbridge/internal/bonuses/data"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -25,8 +26,8 @@ func TestBalance(t *testing.T) {
 	defer ctrl.Finish()
 
 	balance1 := data.Balance{
-		Current:   345,
-		Withdrawn: 100,
+		Current:   money.New(345, 0),
+		Withdrawn: money.New(100, 0),
 	}
 
 	mockStorage := mocks.NewMockBaseBonusesStorage(ctrl)
@@ -45,6 +45,13 @@ func Withdraw(strg storage.BaseBonusesStorage, log logger.BaseLogger) http.Handl
 			return
 		}
 
+		// negative sum would credit balance and sum rounded to zero would record empty withdrawal.
+		if withdrawal.Sum <= 0 {
+			log.Info("[bonuses:handlers:Withdraw] withdrawal sum '%s' should be positive", withdrawal.Sum)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
 		withdrawal.UserID = userID
 		withdrawal.ProcessedAt = time.Now()
 		if err = strg.WithdrawBonuses(r.Context(), &withdrawal); err != nil {
@@ -88,6 +88,26 @@ func TestWithdraw(t *testing.T) {
 				statusCode: http.StatusUnprocessableEntity,
 			},
 		},
+		{
+			name: "negative sum",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte("{\"order\":\"2377225624\",\"sum\":-45}"),
+			},
+			want: want{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "sum rounded to zero",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte("{\"order\":\"2377225624\",\"sum\":0.001}"),
+			},
+			want: want{
+				statusCode: http.StatusUnprocessableEntity,
+			},
+		},
 		{
 			name: "not enough bonuses",
 			args: args{
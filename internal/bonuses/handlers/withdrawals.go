@@ -5,13 +5,29 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/auth"
 	"github.com/erupshis/bonusbridge/internal/bonuses/data"
 	"github.com/erupshis/bonusbridge/internal/bonuses/storage"
+	"github.com/erupshis/bonusbridge/internal/keyset"
 	"github.com/erupshis/bonusbridge/internal/logger"
 )
 
+const (
+	// maxPageLimit max count of withdrawals on page.
+	maxPageLimit = 1000
+
+	// HeaderNextCursor response header with cursor of the next page.
+	HeaderNextCursor = "X-Next-Cursor"
+)
+
+// Withdrawals returns user's withdrawals, newest first by default.
+// Supported query parameters: limit, after(cursor), from, to(RFC3339), sort(asc|desc) and summary(bool).
+// With summary response is an object with page of withdrawals and count and sum of all withdrawals for the period.
+// If next page exists its cursor is returned in X-Next-Cursor and Link headers.
 func Withdrawals(strg storage.BaseBonusesStorage, log logger.BaseLogger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID, err := auth.GetUserIDFromContext(r.Context())
@@ -21,7 +37,21 @@ func Withdrawals(strg storage.BaseBonusesStorage, log logger.BaseLogger) http.Ha
 			return
 		}
 
-		withdrawals, err := strg.GetWithdrawals(r.Context(), userID)
+		filter, withSummary, err := parseWithdrawalsQuery(r.URL.Query())
+		if err != nil {
+			log.Info("[bonuses:handlers:Withdrawals] failed to parse withdrawals query: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filter.UserID = userID
+
+		pageFilter := filter
+		if filter.Limit > 0 {
+			// extra withdrawal shows whether the next page exists.
+			pageFilter.Limit++
+		}
+
+		withdrawals, err := strg.GetWithdrawals(r.Context(), pageFilter)
 		if err != nil {
 			if errors.Is(err, data.ErrWithdrawalsMissing) {
 				w.WriteHeader(http.StatusNoContent)
@@ -32,7 +62,23 @@ func Withdrawals(strg storage.BaseBonusesStorage, log logger.BaseLogger) http.Ha
 			return
 		}
 
-		respBody, err := json.Marshal(withdrawals)
+		if filter.Limit > 0 && len(withdrawals) > filter.Limit {
+			withdrawals = withdrawals[:filter.Limit]
+			setNextPageHeaders(w, r, data.CursorOf(&withdrawals[filter.Limit-1]))
+		}
+
+		var resp interface{} = withdrawals
+		if withSummary {
+			summary, err := strg.GetWithdrawalsSummary(r.Context(), filter)
+			if err != nil {
+				log.Info("[bonuses:handlers:Withdrawals] failed to get withdrawals summary: %v", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			resp = data.WithdrawalsPage{Withdrawals: withdrawals, Summary: summary}
+		}
+
+		respBody, err := json.Marshal(resp)
 		if err != nil {
 			log.Info("[bonuses:handlers:Withdrawals] failed convert withdrawals to JSON: %v", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -47,3 +93,73 @@ func Withdrawals(strg storage.BaseBonusesStorage, log logger.BaseLogger) http.Ha
 		}
 	}
 }
+
+// parseWithdrawalsQuery converts request query parameters in withdrawals filter and summary flag.
+func parseWithdrawalsQuery(query url.Values) (data.WithdrawalsFilter, bool, error) {
+	filter := data.WithdrawalsFilter{Sort: keyset.SortNewestFirst}
+
+	if limit := query.Get("limit"); limit != "" {
+		val, err := strconv.Atoi(limit)
+		if err != nil || val < 1 || val > maxPageLimit {
+			return filter, false, fmt.Errorf("limit '%s' should be in range [1, %d]", limit, maxPageLimit)
+		}
+		filter.Limit = val
+	}
+
+	switch sort := keyset.Sort(query.Get("sort")); sort {
+	case "":
+	case keyset.SortNewestFirst, keyset.SortOldestFirst:
+		filter.Sort = sort
+	default:
+		return filter, false, fmt.Errorf("unknown sort '%s'", sort)
+	}
+
+	if after := query.Get("after"); after != "" {
+		cursor, err := keyset.ParseCursor(after)
+		if err != nil {
+			return filter, false, err
+		}
+		filter.After = cursor
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(query, "from"); err != nil {
+		return filter, false, err
+	}
+	if filter.To, err = parseTimeParam(query, "to"); err != nil {
+		return filter, false, err
+	}
+
+	withSummary := false
+	if summary := query.Get("summary"); summary != "" {
+		if withSummary, err = strconv.ParseBool(summary); err != nil {
+			return filter, false, fmt.Errorf("parse 'summary': %w", err)
+		}
+	}
+
+	return filter, withSummary, nil
+}
+
+// parseTimeParam parses optional RFC3339 time query parameter.
+func parseTimeParam(query url.Values, name string) (time.Time, error) {
+	val := query.Get(name)
+	if val == "" {
+		return time.Time{}, nil
+	}
+
+	res, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse '%s': %w", name, err)
+	}
+	return res, nil
+}
+
+// setNextPageHeaders adds next page cursor and link to it in response headers.
+func setNextPageHeaders(w http.ResponseWriter, r *http.Request, cursor keyset.Cursor) {
+	query := r.URL.Query()
+	query.Set("after", cursor.String())
+	next := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+
+	w.Header().Set(HeaderNextCursor, cursor.String())
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+}
@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/auth/middleware"
 	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/keyset"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -27,7 +30,7 @@ func TestWithdrawals(t *testing.T) {
 	withdrawals := []data.Withdrawal{
 		{
 			Order: "2377225624",
-			Sum:   100.0,
+			Sum:   money.New(100, 0),
 		},
 	}
 
@@ -123,3 +126,90 @@ func TestWithdrawals(t *testing.T) {
 		})
 	}
 }
+
+func TestWithdrawals_pagination(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	withdrawals := []data.Withdrawal{
+		{ID: 3, Order: "3", Sum: money.New(30, 0), ProcessedAt: processedAt},
+		{ID: 2, Order: "2", Sum: money.New(20, 0), ProcessedAt: processedAt},
+		{ID: 1, Order: "1", Sum: money.New(10, 0), ProcessedAt: processedAt},
+	}
+	cursor := data.CursorOf(&withdrawals[1])
+	periodFilter := data.WithdrawalsFilter{UserID: 1, From: from, To: to, Sort: keyset.SortNewestFirst, Limit: 2}
+
+	mockStorage := mocks.NewMockBaseBonusesStorage(ctrl)
+	gomock.InOrder(
+		// one withdrawal more than limit is requested to detect next page.
+		mockStorage.EXPECT().GetWithdrawals(gomock.Any(), data.WithdrawalsFilter{UserID: 1, Sort: keyset.SortNewestFirst, Limit: 3}).
+			Return(withdrawals, nil),
+		mockStorage.EXPECT().GetWithdrawals(gomock.Any(), data.WithdrawalsFilter{UserID: 1, From: from, To: to, Sort: keyset.SortNewestFirst, Limit: 3}).
+			Return(withdrawals[:1], nil),
+		mockStorage.EXPECT().GetWithdrawalsSummary(gomock.Any(), periodFilter).
+			Return(&data.WithdrawalsSummary{Count: 1, Sum: money.New(30, 0)}, nil),
+		mockStorage.EXPECT().GetWithdrawals(gomock.Any(), data.WithdrawalsFilter{UserID: 1, Sort: keyset.SortOldestFirst, After: &cursor}).
+			Return(nil, data.ErrWithdrawalsMissing),
+	)
+
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		Withdrawals(mockStorage, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+	ts := httptest.NewServer(handlerFunc)
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantNextCursor string
+		wantBody       string
+	}{
+		{
+			name:           "first page",
+			query:          "limit=2",
+			wantStatusCode: http.StatusOK,
+			wantNextCursor: cursor.String(),
+			wantBody: `[{"order":"3","sum":30,"processed_at":"2024-01-02T03:04:05Z"},` +
+				`{"order":"2","sum":20,"processed_at":"2024-01-02T03:04:05Z"}]`,
+		},
+		{
+			name:           "period with summary",
+			query:          "limit=2&from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z&summary=true",
+			wantStatusCode: http.StatusOK,
+			wantBody: `{"withdrawals":[{"order":"3","sum":30,"processed_at":"2024-01-02T03:04:05Z"}],` +
+				`"summary":{"count":1,"sum":30}}`,
+		},
+		{
+			name:           "after the last page",
+			query:          "sort=asc&after=" + cursor.String(),
+			wantStatusCode: http.StatusNoContent,
+		},
+		{name: "invalid limit", query: "limit=abc", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid period", query: "from=today", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid summary flag", query: "summary=maybe", wantStatusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ts.Client().Get(ts.URL + "/?" + tt.query)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantNextCursor, resp.Header.Get(HeaderNextCursor))
+
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, string(respBody))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	bonusesStorage "github.com/erupshis/bonusbridge/internal/bonuses/storage"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// Reconciler periodically verifies materialized users balances against bonuses ledger.
+type Reconciler struct {
+	bonusesStorage bonusesStorage.BaseBonusesStorage
+
+	stop chan struct{}
+	done chan struct{}
+
+	log logger.BaseLogger
+}
+
+func Create(bonusesStorage bonusesStorage.BaseBonusesStorage, baseLogger logger.BaseLogger) Reconciler {
+	return Reconciler{
+		bonusesStorage: bonusesStorage,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+		log:            baseLogger,
+	}
+}
+
+func (r *Reconciler) Run(ctx context.Context, interval int) {
+	r.log.Info("[reconciler:Reconciler:Run] start balances reconciliation, interval '%d' seconds", interval)
+
+	go r.reconcileBalances(ctx, time.Duration(interval))
+}
+
+// Shutdown stops reconciliation and waits till the current one is finished or ctx is done.
+func (r *Reconciler) Shutdown(ctx context.Context) error {
+	close(r.stop)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.done:
+		return nil
+	}
+}
+
+func (r *Reconciler) reconcileBalances(ctx context.Context, interval time.Duration) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(interval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("[reconciler:Reconciler:reconcileBalances] reconciliation task is stopping by context")
+			return
+		case <-r.stop:
+			r.log.Info("[reconciler:Reconciler:reconcileBalances] reconciliation task is stopped")
+			return
+		case <-ticker.C:
+			r.reconcile(ctx)
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) {
+	mismatches, err := r.bonusesStorage.ReconcileBalances(ctx)
+	if err != nil {
+		r.log.Info("[reconciler:Reconciler:reconcile] failed to reconcile balances: %v", err)
+		return
+	}
+
+	if len(mismatches) != 0 {
+		r.log.Info("[reconciler:Reconciler:reconcile] '%d' balances mismatched the ledger and were repaired", len(mismatches))
+	}
+}
@@ -0,0 +1,87 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler_Run(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	calls := make(chan struct{}, 2)
+	mockStorage := mocks.NewMockBaseBonusesStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().ReconcileBalances(gomock.Any()).DoAndReturn(func(_ context.Context) ([]data.BalanceMismatch, error) {
+			calls <- struct{}{}
+			return []data.BalanceMismatch{{UserID: 1}}, nil
+		}),
+		mockStorage.EXPECT().ReconcileBalances(gomock.Any()).DoAndReturn(func(_ context.Context) ([]data.BalanceMismatch, error) {
+			calls <- struct{}{}
+			return nil, fmt.Errorf("storage error")
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := Create(mockStorage, log)
+	r.Run(ctx, 1)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "reconciliation was not called in time")
+		}
+	}
+	cancel()
+}
+
+func TestReconciler_Shutdown(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started, finish := make(chan struct{}), make(chan struct{})
+	mockStorage := mocks.NewMockBaseBonusesStorage(ctrl)
+	mockStorage.EXPECT().ReconcileBalances(gomock.Any()).DoAndReturn(func(_ context.Context) ([]data.BalanceMismatch, error) {
+		close(started)
+		<-finish
+		return nil, nil
+	})
+
+	r := Create(mockStorage, log)
+	r.Run(context.Background(), 1)
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "reconciliation was not called in time")
+	}
+
+	// shutdown waits for the current reconciliation.
+	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShutdown()
+	require.ErrorIs(t, r.Shutdown(ctxShutdown), context.DeadlineExceeded)
+
+	close(finish)
+	select {
+	case <-r.done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "reconciler was not stopped after reconciliation")
+	}
+}
@@ -10,5 +10,12 @@ import (
 type BaseBonusesStorage interface {
 	WithdrawBonuses(ctx context.Context, withdrawal *data.Withdrawal) error
 	GetBalance(ctx context.Context, userID int64) (*data.Balance, error)
-	GetWithdrawals(ctx context.Context, userID int64) ([]data.Withdrawal, error)
+	GetWithdrawals(ctx context.Context, filter data.WithdrawalsFilter) ([]data.Withdrawal, error)
+	GetWithdrawalsSummary(ctx context.Context, filter data.WithdrawalsFilter) (*data.WithdrawalsSummary, error)
+	GetBonuses(ctx context.Context, userID int64) ([]data.Bonus, error)
+
+	AdjustBonuses(ctx context.Context, adjustment *data.Adjustment) error
+	GetAdjustments(ctx context.Context, userID int64) ([]data.Adjustment, error)
+
+	ReconcileBalances(ctx context.Context) ([]data.BalanceMismatch, error)
 }
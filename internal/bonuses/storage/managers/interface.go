@@ -8,9 +8,16 @@ import (
 
 //go:generate mockgen -destination=../../../../mocks/mock_BaseBonusesManager.go -package=mocks github.com/erupshis/bonusbridge/internal/bonuses/storage/managers BaseBonusesManager
 type BaseBonusesManager interface {
-	GetBalanceDif(ctx context.Context, userID int64) (float32, error)
-	GetBalance(ctx context.Context, income bool, userID int64) (float32, error)
+	GetBalance(ctx context.Context, userID int64) (*data.Balance, error)
 
 	WithdrawBonuses(ctx context.Context, withdrawal *data.Withdrawal) error
-	GetWithdrawals(ctx context.Context, userID int64) ([]data.Withdrawal, error)
+	GetWithdrawals(ctx context.Context, filter data.WithdrawalsFilter) ([]data.Withdrawal, error)
+	GetWithdrawalsSummary(ctx context.Context, filter data.WithdrawalsFilter) (*data.WithdrawalsSummary, error)
+	GetBonuses(ctx context.Context, userID int64) ([]data.Bonus, error)
+
+	AdjustBonuses(ctx context.Context, adjustment *data.Adjustment) error
+	GetAdjustments(ctx context.Context, userID int64) ([]data.Adjustment, error)
+
+	GetBalanceMismatches(ctx context.Context) ([]data.BalanceMismatch, error)
+	RepairBalance(ctx context.Context, userID int64) (*data.Balance, error)
 }
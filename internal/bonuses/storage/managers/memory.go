@@ -0,0 +1,263 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+type memoryManager struct {
+	store *memory.Store
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation keeping bonuses in memory.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseBonusesManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+func (p *memoryManager) GetBalance(_ context.Context, userID int64) (*data.Balance, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	balance := p.store.Balances[userID]
+	balance.UserID = userID
+	return &balance, nil
+}
+
+func (p *memoryManager) WithdrawBonuses(_ context.Context, withdrawal *data.Withdrawal) error {
+	p.log.Info("[bonuses:memoryManager:WithdrawBonuses] withdraw '%v'", *withdrawal)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if balance := p.store.Balances[withdrawal.UserID]; balance.Current < withdrawal.Sum {
+		return fmt.Errorf("userID '%d' balance '%s' is not enough for withdrawn: %w", withdrawal.UserID, balance.Current, data.ErrNotEnoughBonuses)
+	}
+
+	event, err := outboxData.CreateEvent(withdrawal.UserID, outboxData.TypeBonusesWithdrawn, &outboxData.BonusesWithdrawn{
+		Order:       withdrawal.Order,
+		Sum:         withdrawal.Sum,
+		ProcessedAt: withdrawal.ProcessedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("withdraw bonuses in memory: %w", err)
+	}
+
+	withdrawal.BonusID = p.addBonus(data.Bonus{UserID: withdrawal.UserID, Order: withdrawal.Order, Count: -withdrawal.Sum})
+
+	stored := *withdrawal
+	stored.ID = p.store.NextID("withdrawals")
+	p.store.Withdrawals = append(p.store.Withdrawals, stored)
+
+	p.store.AddBalanceDelta(&data.Balance{UserID: withdrawal.UserID, Current: -withdrawal.Sum, Withdrawn: withdrawal.Sum})
+	p.store.AddEvent(event)
+	return nil
+}
+
+func (p *memoryManager) GetWithdrawals(_ context.Context, filter data.WithdrawalsFilter) ([]data.Withdrawal, error) {
+	p.log.Info("[bonuses:memoryManager:GetWithdrawals] select withdrawals with filter '%+v'", filter)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Withdrawal
+	for _, withdrawal := range p.store.Withdrawals {
+		if !matchWithdrawal(&withdrawal, &filter) || !matchWithdrawalCursor(&withdrawal, &filter) {
+			continue
+		}
+
+		res = append(res, data.Withdrawal{
+			ID:          withdrawal.ID,
+			UserID:      withdrawal.UserID,
+			Order:       withdrawal.Order,
+			Sum:         withdrawal.Sum,
+			ProcessedAt: withdrawal.ProcessedAt,
+		})
+	}
+
+	// keyset pagination: withdrawals with the same processing time are ordered by id.
+	switch filter.Sort {
+	case keyset.SortNewestFirst:
+		sort.SliceStable(res, func(i, j int) bool { return isWithdrawalBefore(&res[j], &res[i]) })
+	case keyset.SortOldestFirst:
+		sort.SliceStable(res, func(i, j int) bool { return isWithdrawalBefore(&res[i], &res[j]) })
+	}
+
+	if filter.Limit > 0 && len(res) > filter.Limit {
+		res = res[:filter.Limit]
+	}
+	return res, nil
+}
+
+// GetWithdrawalsSummary counts withdrawals satisfying filter. Pagination settings are ignored.
+func (p *memoryManager) GetWithdrawalsSummary(_ context.Context, filter data.WithdrawalsFilter) (*data.WithdrawalsSummary, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	summary := &data.WithdrawalsSummary{}
+	for _, withdrawal := range p.store.Withdrawals {
+		if matchWithdrawal(&withdrawal, &filter) {
+			summary.Count++
+			summary.Sum += withdrawal.Sum
+		}
+	}
+	return summary, nil
+}
+
+func (p *memoryManager) GetBonuses(_ context.Context, userID int64) ([]data.Bonus, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Bonus
+	for _, bonus := range p.store.Bonuses {
+		if bonus.UserID == userID {
+			res = append(res, bonus)
+		}
+	}
+	return res, nil
+}
+
+func (p *memoryManager) AdjustBonuses(_ context.Context, adjustment *data.Adjustment) error {
+	p.log.Info("[bonuses:memoryManager:AdjustBonuses] adjust '%v'", *adjustment)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if balance := p.store.Balances[adjustment.UserID]; adjustment.Sum < 0 && balance.Current < -adjustment.Sum {
+		return fmt.Errorf("userID '%d' balance '%s' is not enough for debit: %w", adjustment.UserID, balance.Current, data.ErrNotEnoughBonuses)
+	}
+
+	event, err := outboxData.CreateEvent(adjustment.UserID, outboxData.TypeBonusesAdjusted, &outboxData.BonusesAdjusted{
+		Sum:       adjustment.Sum,
+		Reason:    adjustment.Reason,
+		CreatedAt: adjustment.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("adjust bonuses in memory: %w", err)
+	}
+
+	adjustment.BonusID = p.addBonus(data.Bonus{UserID: adjustment.UserID, Reason: adjustment.Reason, Count: adjustment.Sum})
+	adjustment.ID = p.store.NextID("adjustments")
+	p.store.Adjustments = append(p.store.Adjustments, *adjustment)
+
+	p.store.AddBalanceDelta(&data.Balance{UserID: adjustment.UserID, Current: adjustment.Sum})
+	p.store.AddEvent(event)
+	return nil
+}
+
+func (p *memoryManager) GetAdjustments(_ context.Context, userID int64) ([]data.Adjustment, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Adjustment
+	for _, adjustment := range p.store.Adjustments {
+		if adjustment.UserID == userID {
+			res = append(res, adjustment)
+		}
+	}
+	return res, nil
+}
+
+// GetBalanceMismatches compares materialized balances with balances calculated from bonuses ledger.
+func (p *memoryManager) GetBalanceMismatches(_ context.Context) ([]data.BalanceMismatch, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	ledger := p.store.LedgerBalances()
+	usersIDs := make([]int64, 0, len(ledger))
+	for userID := range ledger {
+		usersIDs = append(usersIDs, userID)
+	}
+	for userID := range p.store.Balances {
+		if _, ok := ledger[userID]; !ok {
+			usersIDs = append(usersIDs, userID)
+		}
+	}
+	sort.Slice(usersIDs, func(i, j int) bool { return usersIDs[i] < usersIDs[j] })
+
+	var res []data.BalanceMismatch
+	for _, userID := range usersIDs {
+		mismatch := data.BalanceMismatch{
+			UserID: userID,
+			Stored: data.Balance{UserID: userID},
+			Ledger: data.Balance{UserID: userID},
+		}
+		if stored, ok := p.store.Balances[userID]; ok {
+			mismatch.Stored.Current, mismatch.Stored.Withdrawn, mismatch.Stored.Accrued = stored.Current, stored.Withdrawn, stored.Accrued
+		}
+		if calculated, ok := ledger[userID]; ok {
+			mismatch.Ledger.Current, mismatch.Ledger.Withdrawn, mismatch.Ledger.Accrued = calculated.Current, calculated.Withdrawn, calculated.Accrued
+		}
+
+		if mismatch.Stored != mismatch.Ledger {
+			res = append(res, mismatch)
+		}
+	}
+	return res, nil
+}
+
+// RepairBalance overwrites materialized balance by values calculated from bonuses ledger.
+func (p *memoryManager) RepairBalance(_ context.Context, userID int64) (*data.Balance, error) {
+	p.log.Info("[bonuses:memoryManager:RepairBalance] repair balance of userID '%d'", userID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	balance := p.store.LedgerBalances()[userID]
+	balance.UserID = userID
+	p.store.Balances[userID] = balance
+	return &balance, nil
+}
+
+// addBonus adds ledger record and returns its id. Must be called under store lock.
+func (p *memoryManager) addBonus(bonus data.Bonus) int64 {
+	bonus.ID = p.store.NextID("bonuses")
+	p.store.Bonuses = append(p.store.Bonuses, bonus)
+	return bonus.ID
+}
+
+// matchWithdrawal checks withdrawal against filter conditions except pagination.
+func matchWithdrawal(withdrawal *data.Withdrawal, filter *data.WithdrawalsFilter) bool {
+	if filter.UserID != 0 && withdrawal.UserID != filter.UserID {
+		return false
+	}
+	if !filter.From.IsZero() && withdrawal.ProcessedAt.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !withdrawal.ProcessedAt.Before(filter.To) {
+		return false
+	}
+	return true
+}
+
+// matchWithdrawalCursor checks that withdrawal is placed after filter's cursor.
+func matchWithdrawalCursor(withdrawal *data.Withdrawal, filter *data.WithdrawalsFilter) bool {
+	if filter.After == nil {
+		return true
+	}
+
+	after := data.Withdrawal{ID: filter.After.ID, ProcessedAt: filter.After.Time}
+	switch filter.Sort {
+	case keyset.SortNewestFirst:
+		return isWithdrawalBefore(withdrawal, &after)
+	case keyset.SortOldestFirst:
+		return isWithdrawalBefore(&after, withdrawal)
+	}
+	return true
+}
+
+// isWithdrawalBefore compares withdrawals by (processed_at, id).
+func isWithdrawalBefore(lhs *data.Withdrawal, rhs *data.Withdrawal) bool {
+	if !lhs.ProcessedAt.Equal(rhs.ProcessedAt) {
+		return lhs.ProcessedAt.Before(rhs.ProcessedAt)
+	}
+	return lhs.ID < rhs.ID
+}
@@ -0,0 +1,71 @@
+package managers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryManager_WithdrawBonusesConcurrently(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testWithdrawBonusesConcurrently(t, CreateInMemory(memory.Create(), log), 1)
+}
+
+func TestMemoryManager_AdjustBonuses(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testAdjustBonuses(t, CreateInMemory(memory.Create(), log), 1)
+}
+
+func TestMemoryManager_GetWithdrawals(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testGetWithdrawals(t, CreateInMemory(memory.Create(), log), 1)
+}
+
+func TestMemoryManager_RepairBalance(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctx := context.Background()
+	store := memory.Create()
+	manager := CreateInMemory(store, log)
+
+	require.NoError(t, manager.AdjustBonuses(ctx, &data.Adjustment{UserID: 1, Sum: money.New(50, 0), Reason: "GOODWILL", CreatedAt: time.Now()}))
+	require.NoError(t, manager.WithdrawBonuses(ctx, &data.Withdrawal{UserID: 1, Order: "2377225624", Sum: money.New(20, 0), ProcessedAt: time.Now()}))
+
+	mismatches, err := manager.GetBalanceMismatches(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	// balance is broken behind the ledger's back.
+	store.Balances[1] = data.Balance{UserID: 1, Current: money.New(70, 0)}
+	store.Balances[2] = data.Balance{UserID: 2, Current: money.New(5, 0)}
+
+	mismatches, err = manager.GetBalanceMismatches(ctx)
+	require.NoError(t, err)
+	wantLedger := data.Balance{UserID: 1, Current: money.New(30, 0), Withdrawn: money.New(20, 0)}
+	assert.Equal(t, []data.BalanceMismatch{
+		{UserID: 1, Stored: store.Balances[1], Ledger: wantLedger},
+		{UserID: 2, Stored: store.Balances[2], Ledger: data.Balance{UserID: 2}},
+	}, mismatches)
+
+	balance, err := manager.RepairBalance(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &wantLedger, balance)
+
+	balance, err = manager.GetBalance(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &wantLedger, balance)
+}
@@ -7,10 +7,14 @@ import (
 
 	"github.com/erupshis/bonusbridge/internal/bonuses/data"
 	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries/adjustments"
+	"github.com/erupshis/bonusbridge/internal/db/queries/balances"
 	"github.com/erupshis/bonusbridge/internal/db/queries/bonuses"
+	"github.com/erupshis/bonusbridge/internal/db/queries/outbox"
 	"github.com/erupshis/bonusbridge/internal/db/queries/withdrawals"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
@@ -31,82 +35,213 @@ func Create(dbConn *db.Conn, log logger.BaseLogger) BaseBonusesManager {
 	}
 }
 
-func (p *manager) GetBalanceDif(ctx context.Context, userID int64) (float32, error) {
-	p.log.Info("[bonuses:manager:GetBalanceDif] start transaction for userID '%d'", userID)
+func (p *manager) GetBalance(ctx context.Context, userID int64) (*data.Balance, error) {
+	p.log.Info("[bonuses:manager:GetBalance] start transaction for userID '%d'", userID)
 	errMsg := "get bonuses balance in db: %w"
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	bonusesDif, err := bonuses.SelectSumByUserID(ctx, tx, bonuses.SumTotal, userID, p.log)
+	balance, err := balances.SelectByUserID(ctx, tx, userID, p.log)
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
 	if err = tx.Commit(); err != nil {
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	p.log.Info("[bonuses:manager:GetBalanceDif] transaction successful")
-	return bonusesDif, nil
+	p.log.Info("[bonuses:manager:GetBalance] transaction successful")
+	return balance, nil
 }
 
-func (p *manager) GetBalance(ctx context.Context, income bool, userID int64) (float32, error) {
-	p.log.Info("[bonuses:manager:GetBalance] start transaction for userID '%d' for income? '%t'", userID, income)
-	errMsg := "get bonuses income sum in db: %w"
+func (p *manager) WithdrawBonuses(ctx context.Context, withdrawal *data.Withdrawal) error {
+	p.log.Info("[bonuses:manager:WithdrawBonuses] start transaction for withdrawal '%v'", *withdrawal)
+	errMsg := "withdraw bonuses in db: %w"
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
-		return -1.0, fmt.Errorf(errMsg, err)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = bonuses.LockUserBalance(ctx, tx, p.Dialect, withdrawal.UserID, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	balance, err := balances.SelectByUserID(ctx, tx, withdrawal.UserID, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if balance.Current < withdrawal.Sum {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf("userID '%d' balance '%s' is not enough for withdrawn: %w", withdrawal.UserID, balance.Current, data.ErrNotEnoughBonuses)
+	}
+
+	withdrawal.BonusID, err = bonuses.Insert(ctx, tx, withdrawal.UserID, -withdrawal.Sum, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = withdrawals.Insert(ctx, tx, withdrawal, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	delta := &data.Balance{UserID: withdrawal.UserID, Current: -withdrawal.Sum, Withdrawn: withdrawal.Sum}
+	if err = balances.AddDelta(ctx, tx, delta, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	event, err := outboxData.CreateEvent(withdrawal.UserID, outboxData.TypeBonusesWithdrawn, &outboxData.BonusesWithdrawn{
+		Order:       withdrawal.Order,
+		Sum:         withdrawal.Sum,
+		ProcessedAt: withdrawal.ProcessedAt,
+	})
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if _, err = outbox.Insert(ctx, tx, event, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
 	}
 
-	var filter int
-	if income {
-		filter = bonuses.SumIn
-	} else {
-		filter = bonuses.SumOut
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[bonuses:manager:WithdrawBonuses] transaction successful")
+	return nil
+}
+
+func (p *manager) GetWithdrawals(ctx context.Context, filter data.WithdrawalsFilter) ([]data.Withdrawal, error) {
+	p.log.Info("[bonuses:manager:GetWithdrawals] start transaction with filter '%+v'", filter)
+	errMsg := "get withdrawals from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	bonusesIncome, err := bonuses.SelectSumByUserID(ctx, tx, filter, userID, p.log)
+	withdrawalsArr, err := withdrawals.Select(ctx, tx, filter, p.log)
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
 	if err = tx.Commit(); err != nil {
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	p.log.Info("[bonuses:manager:GetBalance] transaction successful")
-	return bonusesIncome, nil
+	p.log.Info("[bonuses:manager:GetWithdrawals] transaction successful")
+	return withdrawalsArr, nil
 }
 
-func (p *manager) WithdrawBonuses(ctx context.Context, withdrawal *data.Withdrawal) error {
-	p.log.Info("[bonuses:manager:WithdrawBonuses] start transaction for withdrawal '%v'", *withdrawal)
-	errMsg := "withdraw bonuses in db: %w"
+func (p *manager) GetWithdrawalsSummary(ctx context.Context, filter data.WithdrawalsFilter) (*data.WithdrawalsSummary, error) {
+	p.log.Info("[bonuses:manager:GetWithdrawalsSummary] start transaction with filter '%+v'", filter)
+	errMsg := "get withdrawals summary from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	summary, err := withdrawals.SelectSummary(ctx, tx, filter, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[bonuses:manager:GetWithdrawalsSummary] transaction successful")
+	return summary, nil
+}
+
+func (p *manager) GetBonuses(ctx context.Context, userID int64) ([]data.Bonus, error) {
+	p.log.Info("[bonuses:manager:GetBonuses] start transaction for userID '%d'", userID)
+	errMsg := "get bonuses from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	bonusesArr, err := bonuses.SelectByUserID(ctx, tx, userID, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[bonuses:manager:GetBonuses] transaction successful")
+	return bonusesArr, nil
+}
+
+func (p *manager) AdjustBonuses(ctx context.Context, adjustment *data.Adjustment) error {
+	p.log.Info("[bonuses:manager:AdjustBonuses] start transaction for adjustment '%v'", *adjustment)
+	errMsg := "adjust bonuses in db: %w"
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf(errMsg, err)
 	}
 
-	bonusesDif, err := bonuses.SelectSumByUserID(ctx, tx, bonuses.SumTotal, withdrawal.UserID, p.log)
+	// lock is taken for credits too: balance repair relies on it to see no concurrent balance changes.
+	if err = bonuses.LockUserBalance(ctx, tx, p.Dialect, adjustment.UserID, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if adjustment.Sum < 0 {
+		balance, err := balances.SelectByUserID(ctx, tx, adjustment.UserID, p.log)
+		if err != nil {
+			helpers.ExecuteWithLogError(tx.Rollback, p.log)
+			return fmt.Errorf(errMsg, err)
+		}
+
+		if balance.Current < -adjustment.Sum {
+			helpers.ExecuteWithLogError(tx.Rollback, p.log)
+			return fmt.Errorf("userID '%d' balance '%s' is not enough for debit: %w", adjustment.UserID, balance.Current, data.ErrNotEnoughBonuses)
+		}
+	}
+
+	adjustment.BonusID, err = bonuses.Insert(ctx, tx, adjustment.UserID, adjustment.Sum, p.log)
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return fmt.Errorf(errMsg, err)
 	}
 
-	if bonusesDif < withdrawal.Sum {
-		return fmt.Errorf("userID '%d' balance '%f' is not enough for withdrawn: %w", withdrawal.UserID, bonusesDif, data.ErrNotEnoughBonuses)
+	adjustment.ID, err = adjustments.Insert(ctx, tx, adjustment, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
 	}
 
-	withdrawal.BonusID, err = bonuses.Insert(ctx, tx, withdrawal.UserID, -withdrawal.Sum, p.log)
+	if err = balances.AddDelta(ctx, tx, &data.Balance{UserID: adjustment.UserID, Current: adjustment.Sum}, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	event, err := outboxData.CreateEvent(adjustment.UserID, outboxData.TypeBonusesAdjusted, &outboxData.BonusesAdjusted{
+		Sum:       adjustment.Sum,
+		Reason:    adjustment.Reason,
+		CreatedAt: adjustment.CreatedAt,
+	})
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return fmt.Errorf(errMsg, err)
 	}
 
-	if err = withdrawals.Insert(ctx, tx, withdrawal, p.log); err != nil {
+	if _, err = outbox.Insert(ctx, tx, event, p.log); err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return fmt.Errorf(errMsg, err)
 	}
@@ -115,19 +250,19 @@ func (p *manager) WithdrawBonuses(ctx context.Context, withdrawal *data.Withdraw
 		return fmt.Errorf(errMsg, err)
 	}
 
-	p.log.Info("[bonuses:manager:WithdrawBonuses] transaction successful")
+	p.log.Info("[bonuses:manager:AdjustBonuses] transaction successful")
 	return nil
 }
 
-func (p *manager) GetWithdrawals(ctx context.Context, userID int64) ([]data.Withdrawal, error) {
-	p.log.Info("[bonuses:manager:GetWithdrawals] start transaction for userID '%d'", userID)
-	errMsg := "get withdrawals from db: %w"
+func (p *manager) GetAdjustments(ctx context.Context, userID int64) ([]data.Adjustment, error) {
+	p.log.Info("[bonuses:manager:GetAdjustments] start transaction for userID '%d'", userID)
+	errMsg := "get adjustments from db: %w"
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	withdrawalsArr, err := withdrawals.Select(ctx, tx, map[string]interface{}{"user_id": userID}, p.log)
+	adjustmentsArr, err := adjustments.Select(ctx, tx, data.AdjustmentsFilter{UserID: userID}, p.log)
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return nil, fmt.Errorf(errMsg, err)
@@ -137,6 +272,61 @@ func (p *manager) GetWithdrawals(ctx context.Context, userID int64) ([]data.With
 		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	p.log.Info("[bonuses:manager:GetWithdrawals] transaction successful")
-	return withdrawalsArr, nil
+	p.log.Info("[bonuses:manager:GetAdjustments] transaction successful")
+	return adjustmentsArr, nil
+}
+
+func (p *manager) GetBalanceMismatches(ctx context.Context) ([]data.BalanceMismatch, error) {
+	p.log.Info("[bonuses:manager:GetBalanceMismatches] start transaction")
+	errMsg := "get balances mismatches from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	mismatches, err := balances.SelectMismatches(ctx, tx, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[bonuses:manager:GetBalanceMismatches] transaction successful")
+	return mismatches, nil
+}
+
+// RepairBalance overwrites materialized balance by values calculated from bonuses ledger.
+func (p *manager) RepairBalance(ctx context.Context, userID int64) (*data.Balance, error) {
+	p.log.Info("[bonuses:manager:RepairBalance] start transaction for userID '%d'", userID)
+	errMsg := "repair balance in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = bonuses.LockUserBalance(ctx, tx, p.Dialect, userID, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	balance, err := balances.SelectLedgerByUserID(ctx, tx, userID, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = balances.Upsert(ctx, tx, balance, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[bonuses:manager:RepairBalance] transaction successful")
+	return balance, nil
 }
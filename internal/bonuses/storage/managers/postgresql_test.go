@@ -0,0 +1,48 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	usersData "github.com/erupshis/bonusbridge/internal/auth/users/data"
+	usersManagers "github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/dbtest"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_WithdrawBonusesConcurrently(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testWithdrawBonusesConcurrently(t, Create(conn, log), createTestUser(t, conn, "withdraw_race", log))
+}
+
+func TestManager_AdjustBonuses(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testAdjustBonuses(t, Create(conn, log), createTestUser(t, conn, "adjust", log))
+}
+
+func TestManager_GetWithdrawals(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testGetWithdrawals(t, Create(conn, log), createTestUser(t, conn, "withdrawals", log))
+}
+
+func createTestUser(t *testing.T, conn *db.Conn, prefix string, log logger.BaseLogger) int64 {
+	t.Helper()
+
+	login := fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	userID, err := usersManagers.Create(conn, log).AddUser(context.Background(), &usersData.User{Login: login, Password: "p", Role: usersData.RoleUser})
+	require.NoError(t, err)
+	return userID
+}
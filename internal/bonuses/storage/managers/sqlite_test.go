@@ -0,0 +1,32 @@
+package managers
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/db/dbtest"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+func TestSQLiteManager_WithdrawBonusesConcurrently(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testWithdrawBonusesConcurrently(t, Create(conn, log), createTestUser(t, conn, "withdraw_race", log))
+}
+
+func TestSQLiteManager_AdjustBonuses(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testAdjustBonuses(t, Create(conn, log), createTestUser(t, conn, "adjust", log))
+}
+
+func TestSQLiteManager_GetWithdrawals(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testGetWithdrawals(t, Create(conn, log), createTestUser(t, conn, "withdrawals", log))
+}
@@ -0,0 +1,144 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWithdrawBonusesConcurrently checks that concurrent withdrawals never overdraw user's balance.
+// Scenarios are shared by managers implementations.
+func testWithdrawBonusesConcurrently(t *testing.T, manager BaseBonusesManager, userID int64) {
+	ctx := context.Background()
+	require.NoError(t, manager.AdjustBonuses(ctx, &data.Adjustment{
+		UserID:     userID,
+		OperatorID: userID,
+		Sum:        money.New(100, 0),
+		Reason:     "GOODWILL",
+		CreatedAt:  time.Now(),
+	}))
+
+	const withdrawalsCount = 20
+	orderPrefix := time.Now().UnixNano()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded, rejected := 0, 0
+	for i := 0; i < withdrawalsCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := manager.WithdrawBonuses(ctx, &data.Withdrawal{
+				UserID:      userID,
+				Order:       fmt.Sprintf("%d%03d", orderPrefix, i),
+				Sum:         money.New(10, 0),
+				ProcessedAt: time.Now(),
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				succeeded++
+			} else if errors.Is(err, data.ErrNotEnoughBonuses) {
+				rejected++
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, succeeded)
+	assert.Equal(t, withdrawalsCount-10, rejected)
+
+	balance, err := manager.GetBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, money.Amount(0), balance.Current)
+	assert.Equal(t, money.New(100, 0), balance.Withdrawn)
+
+	// materialized balance must match the ledger.
+	ledgerBalance, err := manager.RepairBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, balance, ledgerBalance)
+}
+
+// testAdjustBonuses checks credits and debits of user's balance by support staff.
+func testAdjustBonuses(t *testing.T, manager BaseBonusesManager, userID int64) {
+	ctx := context.Background()
+
+	debit := &data.Adjustment{UserID: userID, OperatorID: userID, Sum: -money.New(10, 0), Reason: "CORRECTION", CreatedAt: time.Now()}
+	assert.ErrorIs(t, manager.AdjustBonuses(ctx, debit), data.ErrNotEnoughBonuses)
+
+	credit := &data.Adjustment{UserID: userID, OperatorID: userID, Sum: money.New(30, 0), Reason: "GOODWILL", Comment: "apology", CreatedAt: time.Now()}
+	require.NoError(t, manager.AdjustBonuses(ctx, credit))
+	require.NoError(t, manager.AdjustBonuses(ctx, debit))
+
+	balance, err := manager.GetBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(20, 0), balance.Current)
+	assert.Equal(t, money.Amount(0), balance.Withdrawn)
+
+	adjustments, err := manager.GetAdjustments(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, adjustments, 2)
+	assert.Equal(t, credit.ID, adjustments[0].ID)
+	assert.Equal(t, credit.BonusID, adjustments[0].BonusID)
+	assert.Equal(t, "apology", adjustments[0].Comment)
+	assert.Equal(t, debit.Sum, adjustments[1].Sum)
+
+	bonuses, err := manager.GetBonuses(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, bonuses, 2)
+	assert.Equal(t, data.Bonus{ID: credit.BonusID, UserID: userID, Reason: "GOODWILL", Count: credit.Sum}, bonuses[0])
+	assert.Equal(t, data.Bonus{ID: debit.BonusID, UserID: userID, Reason: "CORRECTION", Count: debit.Sum}, bonuses[1])
+}
+
+// testGetWithdrawals checks withdrawals period filter, keyset pagination and summary.
+func testGetWithdrawals(t *testing.T, manager BaseBonusesManager, userID int64) {
+	ctx := context.Background()
+	require.NoError(t, manager.AdjustBonuses(ctx, &data.Adjustment{
+		UserID:     userID,
+		OperatorID: userID,
+		Sum:        money.New(100, 0),
+		Reason:     "GOODWILL",
+		CreatedAt:  time.Now(),
+	}))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	orderPrefix := time.Now().UnixNano()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, manager.WithdrawBonuses(ctx, &data.Withdrawal{
+			UserID:      userID,
+			Order:       fmt.Sprintf("%d%03d", orderPrefix, i),
+			Sum:         money.New(int64(i+1), 0),
+			ProcessedAt: start.Add(time.Duration(i) * time.Hour),
+		}))
+	}
+
+	filter := data.WithdrawalsFilter{UserID: userID, From: start.Add(time.Hour), Sort: keyset.SortNewestFirst, Limit: 2}
+	page, err := manager.GetWithdrawals(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, money.New(4, 0), page[0].Sum)
+	assert.Equal(t, money.New(3, 0), page[1].Sum)
+
+	cursor := data.CursorOf(&page[1])
+	filter.After = &cursor
+	page, err = manager.GetWithdrawals(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, money.New(2, 0), page[0].Sum)
+	assert.True(t, page[0].ProcessedAt.Equal(start.Add(time.Hour)))
+
+	// summary ignores pagination.
+	summary, err := manager.GetWithdrawalsSummary(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, &data.WithdrawalsSummary{Count: 3, Sum: money.New(9, 0)}, summary)
+}
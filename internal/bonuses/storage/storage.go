@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/erupshis/bonusbridge/internal/bonuses/data"
@@ -31,35 +32,96 @@ func (s *Storage) WithdrawBonuses(ctx context.Context, withdrawal *data.Withdraw
 }
 
 func (s *Storage) GetBalance(ctx context.Context, userID int64) (*data.Balance, error) {
-	var res data.Balance
-	var err error
-
-	res.Current, err = s.manager.GetBalanceDif(ctx, userID)
+	balance, err := s.manager.GetBalance(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get userID '%d' bonuses balance: %w", userID, err)
 	}
 
-	res.Withdrawn, err = s.manager.GetBalance(ctx, false, userID)
+	return balance, nil
+}
+
+func (s *Storage) GetWithdrawals(ctx context.Context, filter data.WithdrawalsFilter) ([]data.Withdrawal, error) {
+	withdrawals, err := s.manager.GetWithdrawals(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("get userID '%d' bonuses balance: %w", userID, err)
+		return nil, fmt.Errorf("get userID '%d' withdrawals: %w", filter.UserID, err)
 	}
 
-	if res.Withdrawn < 0 {
-		res.Withdrawn = -res.Withdrawn
+	if len(withdrawals) == 0 {
+		return nil, fmt.Errorf("get userID '%d' withdrawals: %w", filter.UserID, data.ErrWithdrawalsMissing)
 	}
 
-	return &res, nil
+	return withdrawals, nil
 }
 
-func (s *Storage) GetWithdrawals(ctx context.Context, userID int64) ([]data.Withdrawal, error) {
-	withdrawals, err := s.manager.GetWithdrawals(ctx, userID)
+func (s *Storage) GetWithdrawalsSummary(ctx context.Context, filter data.WithdrawalsFilter) (*data.WithdrawalsSummary, error) {
+	summary, err := s.manager.GetWithdrawalsSummary(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("get userID '%d' withdrawals: %w", userID, err)
+		return nil, fmt.Errorf("get userID '%d' withdrawals summary: %w", filter.UserID, err)
 	}
 
-	if len(withdrawals) == 0 {
-		return nil, fmt.Errorf("get userID '%d' withdrawals: %w", userID, data.ErrWithdrawalsMissing)
+	return summary, nil
+}
+
+func (s *Storage) GetBonuses(ctx context.Context, userID int64) ([]data.Bonus, error) {
+	bonuses, err := s.manager.GetBonuses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get userID '%d' bonuses: %w", userID, err)
 	}
 
-	return withdrawals, nil
+	return bonuses, nil
+}
+
+// AdjustBonuses adds manual credit or debit. Reason code and operator are mandatory.
+func (s *Storage) AdjustBonuses(ctx context.Context, adjustment *data.Adjustment) error {
+	errMsg := fmt.Sprintf("adjust userID '%d' bonuses", adjustment.UserID) + ": %w"
+
+	if adjustment.Sum == 0 {
+		return fmt.Errorf(errMsg, fmt.Errorf("zero sum: %w", data.ErrInvalidAdjustment))
+	}
+
+	if data.GetAdjustmentReasonID(adjustment.Reason) == -1 {
+		return fmt.Errorf(errMsg, fmt.Errorf("unknown reason '%s': %w", adjustment.Reason, data.ErrInvalidAdjustment))
+	}
+
+	if adjustment.OperatorID <= 0 {
+		return fmt.Errorf(errMsg, fmt.Errorf("missing operator: %w", data.ErrInvalidAdjustment))
+	}
+
+	if err := s.manager.AdjustBonuses(ctx, adjustment); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetAdjustments(ctx context.Context, userID int64) ([]data.Adjustment, error) {
+	adjustments, err := s.manager.GetAdjustments(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get userID '%d' adjustments: %w", userID, err)
+	}
+
+	return adjustments, nil
+}
+
+// ReconcileBalances verifies materialized balances against bonuses ledger and repairs mismatched ones.
+// Returns found mismatches.
+func (s *Storage) ReconcileBalances(ctx context.Context) ([]data.BalanceMismatch, error) {
+	mismatches, err := s.manager.GetBalanceMismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile balances: %w", err)
+	}
+
+	var errs []error
+	for _, mismatch := range mismatches {
+		s.log.Info("[bonuses:Storage:ReconcileBalances] userID '%d' balance mismatch: stored '%v', ledger '%v'", mismatch.UserID, mismatch.Stored, mismatch.Ledger)
+		if _, err = s.manager.RepairBalance(ctx, mismatch.UserID); err != nil {
+			errs = append(errs, fmt.Errorf("repair userID '%d' balance: %w", mismatch.UserID, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return mismatches, fmt.Errorf("reconcile balances: %w", errors.Join(errs...))
+	}
+
+	return mismatches, nil
 }
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -9,8 +10,10 @@ import (
 	"github.com/erupshis/bonusbridge/internal/bonuses/data"
 	"github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestStorage_WithdrawBonuses(t *testing.T) {
@@ -87,11 +90,8 @@ func TestStorage_GetBalance(t *testing.T) {
 
 	mockManager := mocks.NewMockBaseBonusesManager(ctrl)
 	gomock.InOrder(
-		mockManager.EXPECT().GetBalanceDif(gomock.Any(), gomock.Any()).Return(float32(100.0), nil),
-		mockManager.EXPECT().GetBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(float32(-30.0), nil),
-		mockManager.EXPECT().GetBalanceDif(gomock.Any(), gomock.Any()).Return(float32(100.0), fmt.Errorf("dif error")),
-		mockManager.EXPECT().GetBalanceDif(gomock.Any(), gomock.Any()).Return(float32(100.0), nil),
-		mockManager.EXPECT().GetBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(float32(-30.0), fmt.Errorf("common error")),
+		mockManager.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(&data.Balance{UserID: 1, Current: money.New(100, 0), Withdrawn: money.New(30, 0)}, nil),
+		mockManager.EXPECT().GetBalance(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("manager error")),
 	)
 
 	type fields struct {
@@ -120,26 +120,14 @@ func TestStorage_GetBalance(t *testing.T) {
 				userID: 1,
 			},
 			want: &data.Balance{
-				Current:   100,
-				Withdrawn: 30,
+				UserID:    1,
+				Current:   money.New(100, 0),
+				Withdrawn: money.New(30, 0),
 			},
 			wantErr: false,
 		},
 		{
-			name: "GetBalanceDif generates error",
-			fields: fields{
-				manager: mockManager,
-				log:     log,
-			},
-			args: args{
-				ctx:    context.Background(),
-				userID: 1,
-			},
-			want:    nil,
-			wantErr: true,
-		},
-		{
-			name: "GetBalance generates error",
+			name: "manager returns error",
 			fields: fields{
 				manager: mockManager,
 				log:     log,
@@ -180,7 +168,7 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 	withdrawals := []data.Withdrawal{
 		{
 			Order: "2377225624",
-			Sum:   100.0,
+			Sum:   money.New(100, 0),
 		},
 	}
 
@@ -197,7 +185,7 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 	}
 	type args struct {
 		ctx    context.Context
-		userID int64
+		filter data.WithdrawalsFilter
 	}
 	tests := []struct {
 		name    string
@@ -214,7 +202,7 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 			},
 			args: args{
 				ctx:    context.Background(),
-				userID: 1,
+				filter: data.WithdrawalsFilter{UserID: 1},
 			},
 			want:    withdrawals,
 			wantErr: false,
@@ -227,7 +215,7 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 			},
 			args: args{
 				ctx:    context.Background(),
-				userID: 1,
+				filter: data.WithdrawalsFilter{UserID: 1},
 			},
 			want:    nil,
 			wantErr: true,
@@ -240,7 +228,7 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 			},
 			args: args{
 				ctx:    context.Background(),
-				userID: 1,
+				filter: data.WithdrawalsFilter{UserID: 1},
 			},
 			want:    nil,
 			wantErr: true,
@@ -252,7 +240,7 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 				manager: tt.fields.manager,
 				log:     tt.fields.log,
 			}
-			got, err := s.GetWithdrawals(tt.args.ctx, tt.args.userID)
+			got, err := s.GetWithdrawals(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetWithdrawals() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -263,3 +251,207 @@ func TestStorage_GetWithdrawals(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_AdjustBonuses(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockBaseBonusesManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).Return(nil),
+		mockManager.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).Return(nil),
+		mockManager.EXPECT().AdjustBonuses(gomock.Any(), gomock.Any()).Return(fmt.Errorf("manager error")),
+	)
+
+	type args struct {
+		adjustment *data.Adjustment
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantErr        bool
+		wantInvalidErr bool
+	}{
+		{
+			name: "valid credit",
+			args: args{
+				adjustment: &data.Adjustment{UserID: 1, OperatorID: 2, Sum: money.New(10, 0), Reason: "GOODWILL"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid debit",
+			args: args{
+				adjustment: &data.Adjustment{UserID: 1, OperatorID: 2, Sum: money.New(-10, 0), Reason: "CORRECTION"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "manager returns error",
+			args: args{
+				adjustment: &data.Adjustment{UserID: 1, OperatorID: 2, Sum: money.New(10, 0), Reason: "GOODWILL"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero sum",
+			args: args{
+				adjustment: &data.Adjustment{UserID: 1, OperatorID: 2, Sum: 0, Reason: "GOODWILL"},
+			},
+			wantErr:        true,
+			wantInvalidErr: true,
+		},
+		{
+			name: "missing reason",
+			args: args{
+				adjustment: &data.Adjustment{UserID: 1, OperatorID: 2, Sum: money.New(10, 0)},
+			},
+			wantErr:        true,
+			wantInvalidErr: true,
+		},
+		{
+			name: "missing operator",
+			args: args{
+				adjustment: &data.Adjustment{UserID: 1, Sum: money.New(10, 0), Reason: "GOODWILL"},
+			},
+			wantErr:        true,
+			wantInvalidErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Storage{
+				manager: mockManager,
+				log:     log,
+			}
+			err := s.AdjustBonuses(context.Background(), tt.args.adjustment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AdjustBonuses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, data.ErrInvalidAdjustment) != tt.wantInvalidErr {
+				t.Errorf("AdjustBonuses() error = %v, wantInvalidErr %v", err, tt.wantInvalidErr)
+			}
+		})
+	}
+}
+
+func TestStorage_ReconcileBalances(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mismatches := []data.BalanceMismatch{
+		{
+			UserID: 1,
+			Stored: data.Balance{UserID: 1, Current: money.New(10, 0)},
+			Ledger: data.Balance{UserID: 1, Current: money.New(15, 0), Accrued: money.New(15, 0)},
+		},
+		{
+			UserID: 2,
+			Stored: data.Balance{UserID: 2},
+			Ledger: data.Balance{UserID: 2, Current: money.New(5, 0), Accrued: money.New(5, 0)},
+		},
+	}
+
+	mockManager := mocks.NewMockBaseBonusesManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().GetBalanceMismatches(gomock.Any()).Return(nil, nil),
+
+		mockManager.EXPECT().GetBalanceMismatches(gomock.Any()).Return(mismatches, nil),
+		mockManager.EXPECT().RepairBalance(gomock.Any(), int64(1)).Return(&mismatches[0].Ledger, nil),
+		mockManager.EXPECT().RepairBalance(gomock.Any(), int64(2)).Return(&mismatches[1].Ledger, nil),
+
+		mockManager.EXPECT().GetBalanceMismatches(gomock.Any()).Return(mismatches, nil),
+		mockManager.EXPECT().RepairBalance(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("repair error")),
+		mockManager.EXPECT().RepairBalance(gomock.Any(), int64(2)).Return(&mismatches[1].Ledger, nil),
+
+		mockManager.EXPECT().GetBalanceMismatches(gomock.Any()).Return(nil, fmt.Errorf("manager error")),
+	)
+
+	tests := []struct {
+		name    string
+		want    []data.BalanceMismatch
+		wantErr bool
+	}{
+		{
+			name:    "no mismatches",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "mismatches repaired",
+			want:    mismatches,
+			wantErr: false,
+		},
+		{
+			name:    "repair of one balance failed",
+			want:    mismatches,
+			wantErr: true,
+		},
+		{
+			name:    "manager returns error",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Storage{
+				manager: mockManager,
+				log:     log,
+			}
+			got, err := s.ReconcileBalances(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReconcileBalances() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileBalances() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorage_GetWithdrawalsSummary(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	summary := &data.WithdrawalsSummary{Count: 2, Sum: money.New(150, 0)}
+
+	mockManager := mocks.NewMockBaseBonusesManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().GetWithdrawalsSummary(gomock.Any(), gomock.Any()).Return(summary, nil),
+		mockManager.EXPECT().GetWithdrawalsSummary(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("manager error")),
+	)
+
+	tests := []struct {
+		name    string
+		want    *data.WithdrawalsSummary
+		wantErr bool
+	}{
+		{name: "valid", want: summary},
+		{name: "manager returns error", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Storage{
+				manager: mockManager,
+				log:     log,
+			}
+			got, err := s.GetWithdrawalsSummary(context.Background(), data.WithdrawalsFilter{UserID: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetWithdrawalsSummary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,37 +5,44 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/erupshis/bonusbridge/internal/config"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
 
-const migrationsFolder = "file://db/migrations/"
+const (
+	migrationsFolder       = "file://db/migrations/"
+	sqliteMigrationsFolder = "file://db/migrations/sqlite/"
+)
+
+// sqliteParams are added to SQLite DSN: write transactions take database lock at start and wait for it instead of
+// failing on concurrent commit, timestamps are stored in text format comparable by database.
+const sqliteParams = "_txlock=immediate&_time_format=sqlite&_pragma=busy_timeout(10000)&_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)"
 
 type Conn struct {
 	*sql.DB
-	log logger.BaseLogger
+	Dialect Dialect
+	log     logger.BaseLogger
 }
 
 func CreateConnection(ctx context.Context, cfg config.Config, log logger.BaseLogger) (*Conn, error) {
 	log.Info("[dbconn:CreateConnection] open database with settings: '%s'", cfg.DatabaseDSN)
 	errMsg := "create db: %w"
-	database, err := sql.Open("pgx", cfg.DatabaseDSN)
-	if err != nil {
-		return nil, fmt.Errorf(errMsg, err)
-	}
-
-	driver, err := postgres.WithInstance(database, &postgres.Config{})
+	dialect := dialectFromDSN(cfg.DatabaseDSN)
+	database, err := openDatabase(dialect, cfg.DatabaseDSN)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(migrationsFolder, "postgres", driver)
+	m, err := createMigrate(dialect, database)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
@@ -46,8 +53,9 @@ func CreateConnection(ctx context.Context, cfg config.Config, log logger.BaseLog
 	}
 
 	manager := &Conn{
-		DB:  database,
-		log: log,
+		DB:      database,
+		Dialect: dialect,
+		log:     log,
 	}
 
 	if _, err = manager.CheckConnection(ctx); err != nil {
@@ -58,6 +66,45 @@ func CreateConnection(ctx context.Context, cfg config.Config, log logger.BaseLog
 	return manager, nil
 }
 
+// openDatabase opens database with driver of dialect.
+func openDatabase(dialect Dialect, dsn string) (*sql.DB, error) {
+	if dialect == DialectPostgres {
+		return sql.Open("pgx", dsn)
+	}
+
+	return OpenSQLite(dsn), nil
+}
+
+// SQLiteDataSource converts DSN with sqlite:// scheme into SQLite driver's data source with connection settings.
+func SQLiteDataSource(dsn string) string {
+	path := strings.TrimPrefix(dsn, sqliteScheme)
+	if strings.Contains(path, "?") {
+		return path + "&" + sqliteParams
+	}
+	return path + "?" + sqliteParams
+}
+
+// createMigrate creates migrations runner with migrations set of dialect.
+func createMigrate(dialect Dialect, conn *sql.DB) (*migrate.Migrate, error) {
+	var (
+		driver database.Driver
+		err    error
+	)
+
+	folder, databaseName := migrationsFolder, "postgres"
+	if dialect == DialectSQLite {
+		folder, databaseName = sqliteMigrationsFolder, "sqlite"
+		driver, err = sqlite.WithInstance(conn, &sqlite.Config{})
+	} else {
+		driver, err = postgres.WithInstance(conn, &postgres.Config{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewWithDatabaseInstance(folder, databaseName, driver)
+}
+
 func (p *Conn) CheckConnection(ctx context.Context) (bool, error) {
 	exec := func(context context.Context) (int64, []byte, error) {
 		return 0, []byte{}, p.PingContext(context)
@@ -0,0 +1,76 @@
+// Package dbtest creates migrated databases connections for integration tests.
+package dbtest
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/stretchr/testify/require"
+)
+
+// EnvDatabaseURI PostgreSQL DSN for integration tests. Tests are skipped if it is not set.
+const EnvDatabaseURI = "TEST_DATABASE_URI"
+
+// CreatePostgresConnection creates migrated PostgreSQL database connection by EnvDatabaseURI DSN, skips test if it is not set.
+func CreatePostgresConnection(t *testing.T) *db.Conn {
+	t.Helper()
+
+	dsn := os.Getenv(EnvDatabaseURI)
+	if dsn == "" {
+		t.Skipf("%s is not set", EnvDatabaseURI)
+	}
+
+	conn, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	driver, err := postgres.WithInstance(conn, &postgres.Config{})
+	require.NoError(t, err)
+	migrateUp(t, "postgres", driver, "")
+
+	return &db.Conn{DB: conn, Dialect: db.DialectPostgres}
+}
+
+// CreateSQLiteConnection creates migrated SQLite database in test's temporary directory.
+func CreateSQLiteConnection(t *testing.T) *db.Conn {
+	t.Helper()
+
+	conn := db.OpenSQLite("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	driver, err := sqlite.WithInstance(conn, &sqlite.Config{})
+	require.NoError(t, err)
+	migrateUp(t, "sqlite", driver, "sqlite")
+
+	return &db.Conn{DB: conn, Dialect: db.DialectSQLite}
+}
+
+// migrateUp applies migrations from db/migrations subfolder of repository.
+func migrateUp(t *testing.T, databaseName string, driver database.Driver, folder string) {
+	t.Helper()
+
+	_, file, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	migrations := filepath.Join(filepath.Dir(file), "..", "..", "..", "db", "migrations", folder)
+
+	m, err := migrate.NewWithDatabaseInstance("file://"+filepath.ToSlash(migrations), databaseName, driver)
+	require.NoError(t, err)
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+}
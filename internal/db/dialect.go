@@ -0,0 +1,31 @@
+package db
+
+import "strings"
+
+// Dialect SQL dialect of connected database.
+type Dialect int
+
+const (
+	DialectPostgres Dialect = iota
+	DialectSQLite
+)
+
+// sqliteScheme DSN prefix to select SQLite database file, e.g. 'sqlite://gophermart.db'.
+const sqliteScheme = "sqlite://"
+
+// dialectFromDSN selects dialect by DSN scheme. DSN without known scheme is treated as PostgreSQL one.
+func dialectFromDSN(dsn string) Dialect {
+	if strings.HasPrefix(dsn, sqliteScheme) {
+		return DialectSQLite
+	}
+	return DialectPostgres
+}
+
+// RowsLockSuffix returns clause to lock selected rows till the end of transaction skipping rows locked by others.
+// SQLite write transactions are started as immediate and serialized, so rows locks are not needed there.
+func (d Dialect) RowsLockSuffix() string {
+	if d == DialectSQLite {
+		return ""
+	}
+	return "FOR UPDATE SKIP LOCKED"
+}
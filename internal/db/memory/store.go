@@ -0,0 +1,128 @@
+// Package memory in-memory replacement of database for running the service without PostgreSQL(tests, demos).
+// Data is lost on restart.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	sessionsData "github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	usersData "github.com/erupshis/bonusbridge/internal/auth/users/data"
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
+	idempotencyData "github.com/erupshis/bonusbridge/internal/idempotency/data"
+	ordersData "github.com/erupshis/bonusbridge/internal/orders/data"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	webhooksData "github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+// AccrualJob order's polling schedule in accrual system.
+type AccrualJob struct {
+	OrderID       int64
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+// Event outbox event with its delivery state.
+type Event struct {
+	outboxData.Event
+	PublishedAt    time.Time
+	DeadLetteredAt time.Time
+}
+
+// Store tables shared by in-memory managers. Managers hold the mutex for the whole operation,
+// so every manager's call is atomic like database transaction.
+// Rows of every table are kept in ascending id order.
+type Store struct {
+	sync.Mutex
+
+	Users    []usersData.User
+	Sessions []sessionsData.Session
+	// SupersededRefreshTokens sessions ids by hashes of refresh tokens replaced by rotation.
+	SupersededRefreshTokens map[string]int64
+	IdempotencyRecords      []idempotencyData.Record
+
+	Orders      []ordersData.Order
+	AccrualJobs []AccrualJob
+
+	Bonuses     []bonusesData.Bonus
+	Withdrawals []bonusesData.Withdrawal
+	Adjustments []bonusesData.Adjustment
+	Balances    map[int64]bonusesData.Balance
+
+	Events     []Event
+	Webhooks   []webhooksData.Webhook
+	Deliveries []webhooksData.Delivery
+
+	lastIDs map[string]int64
+}
+
+// Create creates empty store.
+func Create() *Store {
+	return &Store{
+		SupersededRefreshTokens: make(map[string]int64),
+		Balances:                make(map[int64]bonusesData.Balance),
+		lastIDs:                 make(map[string]int64),
+	}
+}
+
+// NextID generates id for the new row of table. Ids start from 1 like database sequences. Must be called under lock.
+func (s *Store) NextID(table string) int64 {
+	s.lastIDs[table]++
+	return s.lastIDs[table]
+}
+
+// FindBonus returns index of bonus record by id or -1.
+func (s *Store) FindBonus(id int64) int {
+	for i := range s.Bonuses {
+		if s.Bonuses[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddEvent adds event in outbox. Must be called under lock.
+func (s *Store) AddEvent(event *outboxData.Event) {
+	event.ID = s.NextID("outbox")
+	s.Events = append(s.Events, Event{Event: *event})
+}
+
+// AddBalanceDelta shifts user's materialized balance by delta values. Missing balance is created from delta.
+// Must be called under lock.
+func (s *Store) AddBalanceDelta(delta *bonusesData.Balance) {
+	balance := s.Balances[delta.UserID]
+	balance.UserID = delta.UserID
+	balance.Current += delta.Current
+	balance.Withdrawn += delta.Withdrawn
+	balance.Accrued += delta.Accrued
+	s.Balances[delta.UserID] = balance
+}
+
+// LedgerBalances calculates users balances from bonuses ledger the same way as balances are maintained:
+// current - sum of all records, withdrawn - withdrawals sum(positive), accrued - orders accruals sum.
+// Must be called under lock.
+func (s *Store) LedgerBalances() map[int64]bonusesData.Balance {
+	withdrawalsBonuses := make(map[int64]struct{}, len(s.Withdrawals))
+	for _, withdrawal := range s.Withdrawals {
+		withdrawalsBonuses[withdrawal.BonusID] = struct{}{}
+	}
+	ordersBonuses := make(map[int64]struct{}, len(s.Orders))
+	for _, order := range s.Orders {
+		ordersBonuses[order.BonusID] = struct{}{}
+	}
+
+	res := make(map[int64]bonusesData.Balance)
+	for _, bonus := range s.Bonuses {
+		balance := res[bonus.UserID]
+		balance.UserID = bonus.UserID
+		balance.Current += bonus.Count
+		if _, ok := withdrawalsBonuses[bonus.ID]; ok {
+			balance.Withdrawn -= bonus.Count
+		}
+		if _, ok := ordersBonuses[bonus.ID]; ok {
+			balance.Accrued += bonus.Count
+		}
+		res[bonus.UserID] = balance
+	}
+	return res
+}
@@ -0,0 +1,8 @@
+package accrualjobs
+
+const (
+	AccrualJobsTable = "accrual_jobs"
+)
+
+// ColumnsInAccrualJobsTable slice of main table attributes in database.
+var ColumnsInAccrualJobsTable = []string{"order_id", "next_attempt_at"}
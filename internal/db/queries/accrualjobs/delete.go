@@ -0,0 +1,52 @@
+package accrualjobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// DeleteByOrderID performs direct query request to database to stop order polling.
+func DeleteByOrderID(ctx context.Context, tx *sql.Tx, orderID int64, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("delete accrual job by order id '%d' in '%s'", orderID, AccrualJobsTable) + ": %w"
+
+	stmt, err := createDeleteByOrderIDStmt(ctx, tx)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err = stmt.ExecContext(
+			context,
+			orderID,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createDeleteByOrderIDStmt generates statement for delete query.
+func createDeleteByOrderIDStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlDelete, _, err := psql.Delete(AccrualJobsTable).
+		Where(sq.Eq{"order_id": "?"}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql delete statement for '%s': %w", AccrualJobsTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlDelete)
+}
@@ -0,0 +1,99 @@
+package accrualjobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Insert performs direct query request to database to schedule order polling in accrual system.
+func Insert(ctx context.Context, tx *sql.Tx, orderID int64, nextAttemptAt time.Time, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("insert accrual job for order id '%d' in '%s'", orderID, AccrualJobsTable) + ": %w"
+
+	stmt, err := createInsertStmt(ctx, tx)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err = stmt.ExecContext(
+			context,
+			orderID,
+			nextAttemptAt,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createInsertStmt generates statement for insert query.
+func createInsertStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(AccrualJobsTable).
+		Columns(ColumnsInAccrualJobsTable...).
+		Values(make([]interface{}, len(ColumnsInAccrualJobsTable))...).
+		Suffix("ON CONFLICT (order_id) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", AccrualJobsTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
+
+// Upsert performs direct query request to database to schedule order polling in accrual system at nextAttemptAt.
+// Existing job is rescheduled with reset attempts counter.
+func Upsert(ctx context.Context, tx *sql.Tx, orderID int64, nextAttemptAt time.Time, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("upsert accrual job for order id '%d' in '%s'", orderID, AccrualJobsTable) + ": %w"
+
+	stmt, err := createUpsertStmt(ctx, tx)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err = stmt.ExecContext(
+			context,
+			orderID,
+			nextAttemptAt,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createUpsertStmt generates statement for upsert query.
+func createUpsertStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlUpsert, _, err := psql.Insert(AccrualJobsTable).
+		Columns(ColumnsInAccrualJobsTable...).
+		Values(make([]interface{}, len(ColumnsInAccrualJobsTable))...).
+		Suffix("ON CONFLICT (order_id) DO UPDATE SET attempts = 0, next_attempt_at = EXCLUDED.next_attempt_at").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql upsert statement for '%s': %w", AccrualJobsTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlUpsert)
+}
@@ -0,0 +1,79 @@
+package accrualjobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// SelectDue performs direct query request to database to select up to limit jobs which polling time has come.
+// Selected rows are locked till the end of transaction, rows locked by another instance are skipped.
+func SelectDue(ctx context.Context, tx *sql.Tx, dialect db.Dialect, now time.Time, limit int, log logger.BaseLogger) ([]data.AccrualJob, error) {
+	errMsg := fmt.Sprintf("select due jobs in '%s'", AccrualJobsTable) + ": %w"
+
+	stmt, err := createSelectDueStmt(ctx, tx, dialect, limit)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(context, now)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return fmt.Errorf(errMsg, rows.Err())
+			}
+		}
+
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.AccrualJob
+	for rows.Next() {
+		job := data.AccrualJob{}
+		err = rows.Scan(
+			&job.Order.ID,
+			&job.Attempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, job)
+	}
+
+	return res, nil
+}
+
+// createSelectDueStmt generates statement for select query.
+func createSelectDueStmt(ctx context.Context, tx *sql.Tx, dialect db.Dialect, limit int) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlSelect, _, err := psql.Select("order_id", "attempts").
+		From(AccrualJobsTable).
+		Where("next_attempt_at <= ?").
+		OrderBy("next_attempt_at").
+		Limit(uint64(limit)).
+		Suffix(dialect.RowsLockSuffix()).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", AccrualJobsTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
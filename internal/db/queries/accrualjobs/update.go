@@ -0,0 +1,77 @@
+package accrualjobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Values accrual job's attributes to update. Nil fields are left unchanged.
+type Values struct {
+	Attempts      *int
+	NextAttemptAt *time.Time
+}
+
+// UpdateByOrderID performs direct query request to database to reschedule order polling.
+func UpdateByOrderID(ctx context.Context, tx *sql.Tx, orderID int64, values Values, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update partially accrual job by order id '%d' in '%s'", orderID, AccrualJobsTable) + ": %w"
+
+	stmt, args, err := createUpdateByOrderIDStmt(ctx, tx, orderID, values)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			args...,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	_, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createUpdateByOrderIDStmt generates statement for update query and its arguments.
+func createUpdateByOrderIDStmt(ctx context.Context, tx *sql.Tx, orderID int64, values Values) (*sql.Stmt, []interface{}, error) {
+	psqlUpdate, args, err := buildUpdateByOrderID(orderID, values).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql update statement for '%s': %w", AccrualJobsTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlUpdate)
+	return stmt, args, err
+}
+
+// buildUpdateByOrderID compiles values in update query with bound arguments. Query without values fails on build.
+func buildUpdateByOrderID(orderID int64, values Values) sq.UpdateBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Update(AccrualJobsTable)
+	if values.Attempts != nil {
+		builder = builder.Set("attempts", *values.Attempts)
+	}
+	if values.NextAttemptAt != nil {
+		builder = builder.Set("next_attempt_at", *values.NextAttemptAt)
+	}
+
+	return builder.Where(sq.Eq{"order_id": orderID})
+}
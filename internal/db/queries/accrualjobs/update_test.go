@@ -0,0 +1,53 @@
+package accrualjobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUpdateByOrderID(t *testing.T) {
+	attempts := 3
+	nextAttemptAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		values   Values
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "claim",
+			values:   Values{NextAttemptAt: &nextAttemptAt},
+			wantSQL:  "UPDATE accrual_jobs SET next_attempt_at = $1 WHERE order_id = $2",
+			wantArgs: []interface{}{nextAttemptAt, int64(5)},
+		},
+		{
+			name:     "reschedule",
+			values:   Values{Attempts: &attempts, NextAttemptAt: &nextAttemptAt},
+			wantSQL:  "UPDATE accrual_jobs SET attempts = $1, next_attempt_at = $2 WHERE order_id = $3",
+			wantArgs: []interface{}{3, nextAttemptAt, int64(5)},
+		},
+		{
+			name:    "nothing to update",
+			values:  Values{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildUpdateByOrderID(5, tt.values).ToSql()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
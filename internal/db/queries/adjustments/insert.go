@@ -0,0 +1,66 @@
+package adjustments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	dbBonusesData "github.com/erupshis/bonusbridge/internal/db/queries/bonuses"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Insert performs direct query request to database to add new adjustment record.
+func Insert(ctx context.Context, tx *sql.Tx, adjustment *data.Adjustment, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert adjustment '%s' for userID '%d' by operatorID '%d' in '%s'",
+		adjustment.Sum,
+		adjustment.UserID,
+		adjustment.OperatorID,
+		dbBonusesData.AdjustmentsTable,
+	) + ": %w"
+
+	stmt, err := createInsertAdjustmentStmt(ctx, tx)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var id int64
+	query := func(context context.Context) error {
+		return stmt.QueryRowContext(
+			context,
+			adjustment.UserID,
+			adjustment.BonusID,
+			adjustment.OperatorID,
+			data.GetAdjustmentReasonID(adjustment.Reason),
+			adjustment.Comment,
+			adjustment.CreatedAt,
+		).Scan(&id)
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	return id, nil
+}
+
+// createInsertAdjustmentStmt generates statement for insert query.
+func createInsertAdjustmentStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(dbBonusesData.AdjustmentsTable).
+		Columns(dbBonusesData.ColumnsInAdjustmentsTable...).
+		Values(make([]interface{}, len(dbBonusesData.ColumnsInAdjustmentsTable))...).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", dbBonusesData.AdjustmentsTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
@@ -0,0 +1,118 @@
+package adjustments
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	dbBonusesData "github.com/erupshis/bonusbridge/internal/db/queries/bonuses"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Select performs direct query request to database to select adjustments satisfying filter.
+func Select(ctx context.Context, tx *sql.Tx, filter data.AdjustmentsFilter, log logger.BaseLogger) ([]data.Adjustment, error) {
+	errMsg := fmt.Sprintf("select adjustments with filter '%+v' in '%s'",
+		filter,
+		dbBonusesData.AdjustmentsTable,
+	) + ": %w"
+
+	stmt, args, err := createSelectAdjustmentsStmt(ctx, tx, filter)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(
+			context,
+			args...,
+		)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return fmt.Errorf(errMsg, rows.Err())
+			}
+		}
+
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Adjustment
+	for rows.Next() {
+		adjustment := data.Adjustment{}
+		err = rows.Scan(
+			&adjustment.ID,
+			&adjustment.UserID,
+			&adjustment.BonusID,
+			&adjustment.OperatorID,
+			&adjustment.Sum,
+			&adjustment.Reason,
+			&adjustment.Comment,
+			&adjustment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, adjustment)
+	}
+
+	return res, nil
+}
+
+// createSelectAdjustmentsStmt generates statement for select query and its arguments.
+func createSelectAdjustmentsStmt(ctx context.Context, tx *sql.Tx, filter data.AdjustmentsFilter) (*sql.Stmt, []interface{}, error) {
+	psqlSelect, args, err := buildSelectAdjustments(filter).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql select statement for '%s': %w", dbBonusesData.AdjustmentsTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlSelect)
+	return stmt, args, err
+}
+
+// buildSelectAdjustments compiles filter in select query with bound arguments.
+func buildSelectAdjustments(filter data.AdjustmentsFilter) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	bonusesJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.id = %s.bonus_id",
+		dbBonusesData.BonusesTable,
+		dbBonusesData.AdjustmentsTable,
+	)
+	reasonsJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.id = %s.reason_id",
+		dbBonusesData.AdjustmentReasonsTable,
+		dbBonusesData.AdjustmentsTable,
+	)
+
+	builder := psql.Select(
+		dbBonusesData.AdjustmentsTable+".id",
+		dbBonusesData.AdjustmentsTable+".user_id",
+		dbBonusesData.AdjustmentsTable+".bonus_id",
+		dbBonusesData.AdjustmentsTable+".operator_id",
+		dbBonusesData.BonusesTable+".count",
+		dbBonusesData.AdjustmentReasonsTable+".reason",
+		dbBonusesData.AdjustmentsTable+".comment",
+		dbBonusesData.AdjustmentsTable+".created_at",
+	).
+		From(dbBonusesData.AdjustmentsTable).
+		JoinClause(bonusesJoin).
+		JoinClause(reasonsJoin).
+		OrderBy(dbBonusesData.AdjustmentsTable + ".id")
+
+	if filter.UserID != 0 {
+		builder = builder.Where(sq.Eq{dbBonusesData.AdjustmentsTable + ".user_id": filter.UserID})
+	}
+
+	return builder
+}
@@ -0,0 +1,21 @@
+package adjustments
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSelectAdjustments(t *testing.T) {
+	gotSQL, gotArgs, err := buildSelectAdjustments(data.AdjustmentsFilter{UserID: 2}).ToSql()
+	require.NoError(t, err)
+	assert.Contains(t, gotSQL, "WHERE adjustments.user_id = $1 ORDER BY adjustments.id")
+	assert.Equal(t, []interface{}{int64(2)}, gotArgs)
+
+	gotSQL, gotArgs, err = buildSelectAdjustments(data.AdjustmentsFilter{}).ToSql()
+	require.NoError(t, err)
+	assert.NotContains(t, gotSQL, "WHERE")
+	assert.Empty(t, gotArgs)
+}
@@ -0,0 +1,13 @@
+package balances
+
+const (
+	BalancesTable = "balances"
+
+	// duplicates of bonuses related tables to build ledger aggregate.
+	bonusesTable     = "bonuses"
+	ordersTable      = "orders"
+	withdrawalsTable = "withdrawals"
+)
+
+// ColumnsInBalancesTable slice of main table attributes in database.
+var ColumnsInBalancesTable = []string{"user_id", "current", "withdrawn", "accrued", "updated_at"}
@@ -0,0 +1,203 @@
+package balances
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// SelectByUserID performs direct query request to database to select user's materialized balance.
+// Returns zero balance if user doesn't have balance record yet.
+func SelectByUserID(ctx context.Context, tx *sql.Tx, userID int64, log logger.BaseLogger) (*data.Balance, error) {
+	errMsg := fmt.Sprintf("select balance for userID '%d' in '%s'", userID, BalancesTable) + ": %w"
+
+	stmt, err := createSelectByUserIDStmt(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	balance, err := selectBalance(ctx, stmt, userID, log)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	return balance, nil
+}
+
+// createSelectByUserIDStmt generates statement for select query.
+func createSelectByUserIDStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlSelect, _, err := psql.Select("current", "withdrawn", "accrued").
+		From(BalancesTable).
+		Where(sq.Eq{"user_id": 0}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", BalancesTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
+
+// SelectLedgerByUserID performs direct query request to database to calculate user's balance from bonuses ledger.
+func SelectLedgerByUserID(ctx context.Context, tx *sql.Tx, userID int64, log logger.BaseLogger) (*data.Balance, error) {
+	errMsg := fmt.Sprintf("select ledger balance for userID '%d' in '%s'", userID, bonusesTable) + ": %w"
+
+	stmt, err := createSelectLedgerByUserIDStmt(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	balance, err := selectBalance(ctx, stmt, userID, log)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	return balance, nil
+}
+
+// createSelectLedgerByUserIDStmt generates statement for ledger aggregate query.
+func createSelectLedgerByUserIDStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlSelect, _, err := ledgerBuilder(psql).
+		Where(sq.Eq{bonusesTable + ".user_id": 0}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", bonusesTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
+
+// selectBalance executes single balance select statement. Statement must return (current, withdrawn, accrued).
+func selectBalance(ctx context.Context, stmt *sql.Stmt, userID int64, log logger.BaseLogger) (*data.Balance, error) {
+	balance := &data.Balance{UserID: userID}
+	query := func(context context.Context) error {
+		err := stmt.QueryRowContext(
+			context,
+			userID,
+		).Scan(
+			&balance.Current,
+			&balance.Withdrawn,
+			&balance.Accrued,
+		)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if err := retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// SelectMismatches performs direct query request to database to find materialized balances that differ from bonuses ledger.
+func SelectMismatches(ctx context.Context, tx *sql.Tx, log logger.BaseLogger) ([]data.BalanceMismatch, error) {
+	errMsg := fmt.Sprintf("select mismatches between '%s' and '%s'", BalancesTable, bonusesTable) + ": %w"
+
+	stmt, err := createSelectMismatchesStmt(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(context)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return fmt.Errorf(errMsg, rows.Err())
+			}
+		}
+
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.BalanceMismatch
+	for rows.Next() {
+		mismatch := data.BalanceMismatch{}
+		err = rows.Scan(
+			&mismatch.UserID,
+			&mismatch.Stored.Current,
+			&mismatch.Stored.Withdrawn,
+			&mismatch.Stored.Accrued,
+			&mismatch.Ledger.Current,
+			&mismatch.Ledger.Withdrawn,
+			&mismatch.Ledger.Accrued,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		mismatch.Stored.UserID = mismatch.UserID
+		mismatch.Ledger.UserID = mismatch.UserID
+		res = append(res, mismatch)
+	}
+
+	return res, nil
+}
+
+// createSelectMismatchesStmt generates statement for comparison of ledger aggregate with materialized balances.
+func createSelectMismatchesStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	stored := func(col string) string { return fmt.Sprintf("COALESCE(%s.%s, 0)", BalancesTable, col) }
+	ledger := func(col string) string { return fmt.Sprintf("COALESCE(ledger.%s, 0)", col) }
+	// values are rounded before comparison, because SQLite sums money as floating point numbers.
+	differ := func(col string) sq.Sqlizer {
+		return sq.Expr(fmt.Sprintf("ROUND(%s, 2) <> ROUND(%s, 2)", stored(col), ledger(col)))
+	}
+
+	psqlSelect, _, err := psql.Select(
+		fmt.Sprintf("COALESCE(ledger.user_id, %s.user_id)", BalancesTable),
+		stored("current"), stored("withdrawn"), stored("accrued"),
+		ledger("current"), ledger("withdrawn"), ledger("accrued"),
+	).
+		FromSelect(ledgerBuilder(psql.PlaceholderFormat(sq.Question)).Column(bonusesTable+".user_id").GroupBy(bonusesTable+".user_id"), "ledger").
+		JoinClause(fmt.Sprintf("FULL OUTER JOIN %[1]s ON %[1]s.user_id = ledger.user_id", BalancesTable)).
+		Where(sq.Or{
+			differ("current"),
+			differ("withdrawn"),
+			differ("accrued"),
+		}).
+		OrderBy("1").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", BalancesTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
+
+// ledgerBuilder aggregates bonuses ledger the same way as balances are maintained:
+// current - sum of all records, withdrawn - withdrawals sum(positive), accrued - orders accruals sum.
+func ledgerBuilder(psql sq.StatementBuilderType) sq.SelectBuilder {
+	return psql.Select(
+		fmt.Sprintf("COALESCE(SUM(%s.count), 0) AS current", bonusesTable),
+		fmt.Sprintf("COALESCE(-SUM(%s.count) FILTER (WHERE %s.id IS NOT NULL), 0) AS withdrawn", bonusesTable, withdrawalsTable),
+		fmt.Sprintf("COALESCE(SUM(%s.count) FILTER (WHERE %s.id IS NOT NULL), 0) AS accrued", bonusesTable, ordersTable),
+	).
+		From(bonusesTable).
+		JoinClause(fmt.Sprintf("LEFT JOIN %s ON %[1]s.bonus_id = %s.id", withdrawalsTable, bonusesTable)).
+		JoinClause(fmt.Sprintf("LEFT JOIN %s ON %[1]s.bonus_id = %s.id", ordersTable, bonusesTable))
+}
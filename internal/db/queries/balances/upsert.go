@@ -0,0 +1,81 @@
+package balances
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// AddDelta performs direct query request to database to shift user's balance by delta values.
+// Missing balance record is created from delta.
+func AddDelta(ctx context.Context, tx *sql.Tx, delta *data.Balance, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("add delta '%v' to balance of userID '%d' in '%s'", *delta, delta.UserID, BalancesTable) + ": %w"
+
+	suffix := fmt.Sprintf("ON CONFLICT (user_id) DO UPDATE SET "+
+		"current = %[1]s.current + EXCLUDED.current, withdrawn = %[1]s.withdrawn + EXCLUDED.withdrawn, "+
+		"accrued = %[1]s.accrued + EXCLUDED.accrued, updated_at = EXCLUDED.updated_at", BalancesTable)
+	if err := upsert(ctx, tx, delta, suffix, log); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// Upsert performs direct query request to database to overwrite user's balance.
+func Upsert(ctx context.Context, tx *sql.Tx, balance *data.Balance, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("upsert balance '%v' of userID '%d' in '%s'", *balance, balance.UserID, BalancesTable) + ": %w"
+
+	suffix := "ON CONFLICT (user_id) DO UPDATE SET " +
+		"current = EXCLUDED.current, withdrawn = EXCLUDED.withdrawn, " +
+		"accrued = EXCLUDED.accrued, updated_at = EXCLUDED.updated_at"
+	if err := upsert(ctx, tx, balance, suffix, log); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+func upsert(ctx context.Context, tx *sql.Tx, balance *data.Balance, suffix string, log logger.BaseLogger) error {
+	stmt, err := createUpsertStmt(ctx, tx, suffix)
+	if err != nil {
+		return err
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err := stmt.ExecContext(
+			context,
+			balance.UserID,
+			balance.Current,
+			balance.Withdrawn,
+			balance.Accrued,
+			time.Now(),
+		)
+		return err
+	}
+	return retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+}
+
+// createUpsertStmt generates statement for upsert query.
+func createUpsertStmt(ctx context.Context, tx *sql.Tx, suffix string) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(BalancesTable).
+		Columns(ColumnsInBalancesTable...).
+		Values(make([]interface{}, len(ColumnsInBalancesTable))...).
+		Suffix(suffix).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql upsert statement for '%s': %w", BalancesTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
@@ -3,6 +3,12 @@ package bonuses
 const (
 	BonusesTable     = "bonuses"
 	WithdrawalsTable = "withdrawals"
+
+	AdjustmentsTable       = "adjustments"
+	AdjustmentReasonsTable = "adjustment_reasons"
+
+	// ordersTable duplicates orders.OrdersTable to avoid import cycle.
+	ordersTable = "orders"
 )
 
 // ColumnsInBonusesTable slice of main table attributes in database.
@@ -10,3 +16,6 @@ var ColumnsInBonusesTable = []string{"user_id", "count"}
 
 // ColumnsInWithdrawalsTable slice of main table attributes in database.
 var ColumnsInWithdrawalsTable = []string{"user_id", "order_num", "bonus_id", "processed_at"}
+
+// ColumnsInAdjustmentsTable slice of manual adjustments table attributes in database.
+var ColumnsInAdjustmentsTable = []string{"user_id", "bonus_id", "operator_id", "reason_id", "comment", "created_at"}
@@ -9,12 +9,13 @@ import (
 	"github.com/erupshis/bonusbridge/internal/db"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
 // Insert performs direct query request to database to add new bonuses record.
-func Insert(ctx context.Context, tx *sql.Tx, userID int64, count float32, log logger.BaseLogger) (int64, error) {
-	errMsg := fmt.Sprintf("insert bonuses '%f' for userID '%d' in '%s'", count, userID, BonusesTable) + ": %w"
+func Insert(ctx context.Context, tx *sql.Tx, userID int64, count money.Amount, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert bonuses '%s' for userID '%d' in '%s'", count, userID, BonusesTable) + ": %w"
 
 	stmt, err := createInsertStmt(ctx, tx)
 	if err != nil {
@@ -0,0 +1,51 @@
+package bonuses
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// balanceLockClass first key of advisory lock, separates balance locks from other advisory locks.
+const balanceLockClass = 1
+
+// LockUserBalance takes transaction level advisory lock on user's balance.
+// Concurrent transactions that debit the same user wait until lock owner commits or rolls back.
+// SQLite has no advisory locks, its write transactions are serialized entirely, so nothing is locked there.
+func LockUserBalance(ctx context.Context, tx *sql.Tx, dialect db.Dialect, userID int64, log logger.BaseLogger) error {
+	if dialect == db.DialectSQLite {
+		return nil
+	}
+
+	errMsg := fmt.Sprintf("lock bonuses balance for userID '%d'", userID) + ": %w"
+
+	stmt, err := createLockUserBalanceStmt(ctx, tx)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	// waiting for lock is not a failure, so the call is not wrapped in retryer: its attempt timeout would cancel the wait
+	// and leave transaction aborted.
+	if _, err = stmt.ExecContext(ctx, balanceLockClass, userID); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createLockUserBalanceStmt generates statement for advisory lock query.
+func createLockUserBalanceStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlSelect, _, err := psql.Select("pg_advisory_xact_lock(?::INTEGER, ?::INTEGER)").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql lock statement for '%s': %w", BonusesTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
@@ -6,24 +6,20 @@ import (
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
 	"github.com/erupshis/bonusbridge/internal/db"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
-const (
-	SumTotal = iota
-	SumIn
-	SumOut
-)
-
-func SelectSumByUserID(ctx context.Context, tx *sql.Tx, filter int, userID int64, log logger.BaseLogger) (float32, error) {
-	errMsg := fmt.Sprintf("select bonuses balance for userID '%d' in '%s'", userID, BonusesTable) + ": %w"
+// SelectByUserID performs direct query request to database to select user's bonuses ledger.
+func SelectByUserID(ctx context.Context, tx *sql.Tx, userID int64, log logger.BaseLogger) ([]data.Bonus, error) {
+	errMsg := fmt.Sprintf("select bonuses for userID '%d' in '%s'", userID, BonusesTable) + ": %w"
 
-	stmt, err := createSelectSumByUserIDStmt(ctx, tx, filter)
+	stmt, err := createSelectByUserIDStmt(ctx, tx)
 	if err != nil {
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 	defer helpers.ExecuteWithLogError(stmt.Close, log)
 
@@ -32,7 +28,6 @@ func SelectSumByUserID(ctx context.Context, tx *sql.Tx, filter int, userID int64
 		rows, err = stmt.QueryContext(
 			context,
 			userID,
-			0,
 		)
 
 		if err == nil {
@@ -45,40 +40,55 @@ func SelectSumByUserID(ctx context.Context, tx *sql.Tx, filter int, userID int64
 	}
 	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
 	if err != nil {
-		return -1.0, fmt.Errorf(errMsg, err)
+		return nil, fmt.Errorf(errMsg, err)
 	}
 
 	defer helpers.ExecuteWithLogError(rows.Close, log)
-	var res sql.NullFloat64
+	var res []data.Bonus
 	for rows.Next() {
+		bonus := data.Bonus{}
 		err = rows.Scan(
-			&res,
+			&bonus.ID,
+			&bonus.UserID,
+			&bonus.Order,
+			&bonus.Reason,
+			&bonus.Count,
 		)
 		if err != nil {
-			return -1.0, fmt.Errorf("parse db result: %w", err)
+			return nil, fmt.Errorf("parse db result: %w", err)
 		}
+
+		res = append(res, bonus)
 	}
 
-	return float32(res.Float64), nil
+	return res, nil
 }
 
-func createSelectSumByUserIDStmt(ctx context.Context, tx *sql.Tx, filter int) (*sql.Stmt, error) {
+// createSelectByUserIDStmt generates statement for ledger select query.
+// Order number is taken from related order or withdrawal, reason - from related manual adjustment.
+func createSelectByUserIDStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
-	builder := psql.Select("SUM(count)").
-		From(BonusesTable).
-		Where(sq.Eq{"user_id": 0})
+	ordersJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.bonus_id = %s.id", ordersTable, BonusesTable)
+	withdrawalsJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.bonus_id = %s.id", WithdrawalsTable, BonusesTable)
+	adjustmentsJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.bonus_id = %s.id", AdjustmentsTable, BonusesTable)
+	reasonsJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.id = %s.reason_id", AdjustmentReasonsTable, AdjustmentsTable)
 
-	switch filter {
-	case SumIn:
-		builder = builder.Where(sq.GtOrEq{"count": 0})
-	case SumOut:
-		builder = builder.Where(sq.LtOrEq{"count": 0})
-	default:
-		builder = builder.Where(sq.GtOrEq{"id": 0})
-	}
-
-	psqlSelect, _, err := builder.ToSql()
+	psqlSelect, _, err := psql.Select(
+		BonusesTable+".id",
+		BonusesTable+".user_id",
+		fmt.Sprintf("COALESCE(CAST(%s.num AS TEXT), CAST(%s.order_num AS TEXT), '')", ordersTable, WithdrawalsTable),
+		fmt.Sprintf("COALESCE(%s.reason, '')", AdjustmentReasonsTable),
+		BonusesTable+".count",
+	).
+		From(BonusesTable).
+		JoinClause(ordersJoin).
+		JoinClause(withdrawalsJoin).
+		JoinClause(adjustmentsJoin).
+		JoinClause(reasonsJoin).
+		Where(sq.Eq{BonusesTable + ".user_id": 0}).
+		OrderBy(BonusesTable + ".id").
+		ToSql()
 
 	if err != nil {
 		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", BonusesTable, err)
@@ -7,34 +7,36 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
-// UpdateByID performs direct query request to database to edit existing bonuses record.
-func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values map[string]interface{}, log logger.BaseLogger) error {
-	errMsg := fmt.Sprintf("update partially bonus by id '%d' with data '%v' in '%s'", id, values, BonusesTable) + ": %w"
+// UpdateCounts performs single query request to database to set count of several bonuses records.
+// counts maps bonus id to its new count.
+func UpdateCounts(ctx context.Context, tx *sql.Tx, counts map[int64]money.Amount, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update counts of '%d' bonuses in '%s'", len(counts), BonusesTable) + ": %w"
+	if len(counts) == 0 {
+		return nil
+	}
 
-	var columnsToUpdate []string
-	var valuesToUpdate []interface{}
-	for key, val := range values {
-		columnsToUpdate = append(columnsToUpdate, key)
-		valuesToUpdate = append(valuesToUpdate, val)
+	values := make([]interface{}, 0, 2*len(counts))
+	for id, count := range counts {
+		values = append(values, id, count)
 	}
-	valuesToUpdate = append(valuesToUpdate, id)
 
-	stmt, err := createUpdateByIDStmt(ctx, tx, columnsToUpdate)
+	stmt, err := createUpdateCountsStmt(ctx, tx, len(counts))
 	if err != nil {
 		return fmt.Errorf(errMsg, err)
 	}
 	defer helpers.ExecuteWithLogError(stmt.Close, log)
 
-	var result sql.Result
 	query := func(context context.Context) error {
-		result, err = stmt.ExecContext(
+		_, err = stmt.ExecContext(
 			context,
-			valuesToUpdate...,
+			values...,
 		)
 		return err
 	}
@@ -43,28 +45,21 @@ func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values map[string]int
 		return fmt.Errorf(errMsg, err)
 	}
 
-	_, err = result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf(errMsg, err)
-	}
-
 	return nil
 }
 
-// createUpdateByIDStmt generates statement for update query.
-func createUpdateByIDStmt(ctx context.Context, tx *sql.Tx, values []string) (*sql.Stmt, error) {
+// createUpdateCountsStmt generates statement for multi-row update query.
+func createUpdateCountsStmt(ctx context.Context, tx *sql.Tx, rowsCount int) (*sql.Stmt, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
-	builder := psql.Update(BonusesTable)
-	for _, col := range values {
-		builder = builder.Set(col, "?")
-	}
-	builder = builder.Where(sq.Eq{"id": "?"})
-	psqlUpdate, _, err := builder.ToSql()
+	psqlUpdate, _, err := psql.Update(BonusesTable).
+		Set("count", sq.Expr("v.count")).
+		From(queries.ValuesList(rowsCount, "v", []string{"id", "count"}, []string{"INTEGER", "NUMERIC(9,2)"})).
+		Where(BonusesTable + ".id = v.id").
+		ToSql()
 
 	if err != nil {
-		return nil, fmt.Errorf("squirrel sql update statement for '%s': %w", BonusesTable, err)
-
+		return nil, fmt.Errorf("squirrel sql multi-row update statement for '%s': %w", BonusesTable, err)
 	}
 	return tx.PrepareContext(ctx, psqlUpdate)
 }
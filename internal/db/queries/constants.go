@@ -1,3 +0,0 @@
-package queries
-
-const Custom = "custom"
@@ -0,0 +1,8 @@
+package idempotency
+
+const (
+	IdempotencyKeysTable = "idempotency_keys"
+)
+
+// ColumnsInIdempotencyKeysTable slice of main table attributes in database.
+var ColumnsInIdempotencyKeysTable = []string{"user_id", "idempotency_key", "request_hash", "status_code", "content_type", "body", "created_at", "expires_at"}
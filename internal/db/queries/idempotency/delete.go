@@ -0,0 +1,55 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// DeleteByID performs direct query request to database to remove idempotency key record reserved at createdAt.
+// Record taken over by another request is left as is.
+func DeleteByID(ctx context.Context, tx *sql.Tx, id int64, createdAt time.Time, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("delete idempotency key by id '%d' in '%s'", id, IdempotencyKeysTable) + ": %w"
+
+	stmt, err := createDeleteByIDStmt(ctx, tx)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err = stmt.ExecContext(
+			context,
+			id,
+			createdAt,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createDeleteByIDStmt generates statement for delete query.
+func createDeleteByIDStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlDelete, _, err := psql.Delete(IdempotencyKeysTable).
+		Where("id = ? AND created_at = ?").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql delete statement for '%s': %w", IdempotencyKeysTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlDelete)
+}
@@ -0,0 +1,72 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// InsertOrTakeExpired performs direct query request to database to add new idempotency key record.
+// Expired record with the same (user, key) is overwritten. Returns -1 if live record with the same (user, key) exists.
+func InsertOrTakeExpired(ctx context.Context, tx *sql.Tx, record *data.Record, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert idempotency key '%s' for userID '%d' in '%s'", record.Key, record.UserID, IdempotencyKeysTable) + ": %w"
+
+	stmt, err := createInsertOrTakeExpiredStmt(ctx, tx)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	id := int64(-1)
+	query := func(context context.Context) error {
+		err := stmt.QueryRowContext(
+			context,
+			record.UserID,
+			record.Key,
+			record.RequestHash,
+			record.StatusCode,
+			record.ContentType,
+			record.Body,
+			record.CreatedAt,
+			record.ExpiresAt,
+		).Scan(&id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	return id, nil
+}
+
+// createInsertOrTakeExpiredStmt generates statement for insert query.
+func createInsertOrTakeExpiredStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(IdempotencyKeysTable).
+		Columns(ColumnsInIdempotencyKeysTable...).
+		Values(make([]interface{}, len(ColumnsInIdempotencyKeysTable))...).
+		Suffix(fmt.Sprintf("ON CONFLICT (user_id, idempotency_key) DO UPDATE SET "+
+			"request_hash = EXCLUDED.request_hash, status_code = EXCLUDED.status_code, content_type = EXCLUDED.content_type, "+
+			"body = EXCLUDED.body, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at "+
+			"WHERE %s.expires_at < EXCLUDED.created_at RETURNING id", IdempotencyKeysTable)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", IdempotencyKeysTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
@@ -0,0 +1,107 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Select performs direct query request to database to select idempotency keys records satisfying filter.
+func Select(ctx context.Context, dbConn *sql.DB, filter data.Filter, log logger.BaseLogger) ([]data.Record, error) {
+	errMsg := fmt.Sprintf("select idempotency keys in '%s'", IdempotencyKeysTable) + ": %w"
+
+	stmt, args, err := createSelectStmt(ctx, dbConn, filter)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(
+			context,
+			args...,
+		)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return fmt.Errorf(errMsg, rows.Err())
+			}
+		}
+
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Record
+	for rows.Next() {
+		record := data.Record{}
+		err := rows.Scan(
+			&record.ID,
+			&record.UserID,
+			&record.Key,
+			&record.RequestHash,
+			&record.StatusCode,
+			&record.ContentType,
+			&record.Body,
+			&record.CreatedAt,
+			&record.ExpiresAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, record)
+	}
+
+	return res, nil
+}
+
+// createSelectStmt generates statement for select query and its arguments.
+func createSelectStmt(ctx context.Context, dbConn *sql.DB, filter data.Filter) (*sql.Stmt, []interface{}, error) {
+	psqlSelect, args, err := buildSelect(filter).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql select statement for '%s': %w", IdempotencyKeysTable, err)
+	}
+
+	stmt, err := dbConn.PrepareContext(ctx, psqlSelect)
+	return stmt, args, err
+}
+
+// buildSelect compiles filter in select query with bound arguments.
+func buildSelect(filter data.Filter) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Select(
+		"id",
+		"user_id",
+		"idempotency_key",
+		"request_hash",
+		"status_code",
+		"content_type",
+		"body",
+		"created_at",
+		"expires_at",
+	).
+		From(IdempotencyKeysTable)
+
+	if filter.UserID != 0 {
+		builder = builder.Where(sq.Eq{"user_id": filter.UserID})
+	}
+	if filter.Key != "" {
+		builder = builder.Where(sq.Eq{"idempotency_key": filter.Key})
+	}
+
+	return builder
+}
@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSelect(t *testing.T) {
+	gotSQL, gotArgs, err := buildSelect(data.Filter{UserID: 1, Key: "key'; --"}).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, user_id, idempotency_key, request_hash, status_code, content_type, body, created_at, expires_at "+
+		"FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2", gotSQL)
+	assert.Equal(t, []interface{}{int64(1), "key'; --"}, gotArgs)
+}
+
+func TestBuildUpdateByID(t *testing.T) {
+	statusCode := 200
+	contentType := "application/json"
+	body := []byte("{}")
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		values   Values
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "response",
+			values:   Values{StatusCode: &statusCode, ContentType: &contentType, Body: &body, ExpiresAt: &expiresAt},
+			wantSQL:  "UPDATE idempotency_keys SET status_code = $1, content_type = $2, body = $3, expires_at = $4 WHERE id = $5 AND created_at = $6",
+			wantArgs: []interface{}{200, "application/json", []byte("{}"), expiresAt, int64(1), createdAt},
+		},
+		{
+			name:    "nothing to update",
+			values:  Values{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildUpdateByID(1, createdAt, tt.values).ToSql()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Values idempotency key record's attributes to update. Nil fields are left unchanged.
+type Values struct {
+	StatusCode  *int
+	ContentType *string
+	Body        *[]byte
+	ExpiresAt   *time.Time
+}
+
+// UpdateByID performs direct query request to database to edit idempotency key record reserved at createdAt.
+// Returns false if reservation was taken over by another request.
+func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, createdAt time.Time, values Values, log logger.BaseLogger) (bool, error) {
+	errMsg := fmt.Sprintf("update partially idempotency key by id '%d' in '%s'", id, IdempotencyKeysTable) + ": %w"
+
+	stmt, args, err := createUpdateByIDStmt(ctx, tx, id, createdAt, values)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			args...,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	return affected != 0, nil
+}
+
+// createUpdateByIDStmt generates statement for update query and its arguments.
+func createUpdateByIDStmt(ctx context.Context, tx *sql.Tx, id int64, createdAt time.Time, values Values) (*sql.Stmt, []interface{}, error) {
+	psqlUpdate, args, err := buildUpdateByID(id, createdAt, values).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql update statement for '%s': %w", IdempotencyKeysTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlUpdate)
+	return stmt, args, err
+}
+
+// buildUpdateByID compiles values in update query with bound arguments. Query without values fails on build.
+func buildUpdateByID(id int64, createdAt time.Time, values Values) sq.UpdateBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Update(IdempotencyKeysTable)
+	if values.StatusCode != nil {
+		builder = builder.Set("status_code", *values.StatusCode)
+	}
+	if values.ContentType != nil {
+		builder = builder.Set("content_type", *values.ContentType)
+	}
+	if values.Body != nil {
+		builder = builder.Set("body", *values.Body)
+	}
+	if values.ExpiresAt != nil {
+		builder = builder.Set("expires_at", *values.ExpiresAt)
+	}
+
+	return builder.Where(sq.Eq{"id": id}).Where(sq.Eq{"created_at": createdAt})
+}
@@ -7,38 +7,29 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/erupshis/bonusbridge/internal/db"
-	"github.com/erupshis/bonusbridge/internal/db/queries"
 	dbBonusesData "github.com/erupshis/bonusbridge/internal/db/queries/bonuses"
 	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/keyset"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
-// Select performs direct query request to database to select orders satisfying filters.
-func Select(ctx context.Context, tx *sql.Tx, filters map[string]interface{}, log logger.BaseLogger) ([]data.Order, error) {
-	errMsg := fmt.Sprintf("select orders with filter '%v' in '%s'", filters, OrdersTable) + ": %w"
+// Select performs direct query request to database to select orders satisfying filter.
+func Select(ctx context.Context, tx *sql.Tx, filter data.Filter, log logger.BaseLogger) ([]data.Order, error) {
+	errMsg := fmt.Sprintf("select orders with filter '%+v' in '%s'", filter, OrdersTable) + ": %w"
 
-	stmt, err := createSelectOrdersStmt(ctx, tx, filters)
+	stmt, args, err := createSelectOrdersStmt(ctx, tx, filter)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
 	defer helpers.ExecuteWithLogError(stmt.Close, log)
 
-	var valuesToUpdate []interface{}
-	for key, val := range filters {
-		if key == queries.Custom {
-			continue
-		}
-
-		valuesToUpdate = append(valuesToUpdate, val)
-	}
-
 	var rows *sql.Rows
 	query := func(context context.Context) error {
 		rows, err = stmt.QueryContext(
 			context,
-			valuesToUpdate...,
+			args...,
 		)
 
 		if err == nil {
@@ -77,12 +68,23 @@ func Select(ctx context.Context, tx *sql.Tx, filters map[string]interface{}, log
 	return res, nil
 }
 
-// createSelectOrdersStmt generates statement for select query.
-func createSelectOrdersStmt(ctx context.Context, tx *sql.Tx, filters map[string]interface{}) (*sql.Stmt, error) {
+// createSelectOrdersStmt generates statement for select query and its arguments.
+func createSelectOrdersStmt(ctx context.Context, tx *sql.Tx, filter data.Filter) (*sql.Stmt, []interface{}, error) {
+	psqlSelect, args, err := buildSelectOrders(filter).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql select statement for '%s': %w", OrdersTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlSelect)
+	return stmt, args, err
+}
+
+// buildSelectOrders compiles filter in select query with bound arguments.
+func buildSelectOrders(filter data.Filter) sq.SelectBuilder {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
-	statusesJoin := fmt.Sprintf("RIGHT JOIN %s ON %[1]s.id = %s.status_id", StatusesTable, OrdersTable)
-	bonusesJoin := fmt.Sprintf("RIGHT JOIN %s ON %[1]s.id = %s.bonus_id", dbBonusesData.BonusesTable, OrdersTable)
+	statusesJoin := fmt.Sprintf("JOIN %s ON %[1]s.id = %s.status_id", StatusesTable, OrdersTable)
+	bonusesJoin := fmt.Sprintf("JOIN %s ON %[1]s.id = %s.bonus_id", dbBonusesData.BonusesTable, OrdersTable)
 
 	builder := psql.Select(
 		OrdersTable+".id",
@@ -97,34 +99,42 @@ func createSelectOrdersStmt(ctx context.Context, tx *sql.Tx, filters map[string]
 		JoinClause(statusesJoin).
 		JoinClause(bonusesJoin)
 
-	if len(filters) != 0 {
-		for key, val := range filters {
-			switch key {
-			case "id":
-				key = OrdersTable + ".id"
-			case "number":
-				key = OrdersTable + ".num"
-			case "user_id":
-				key = OrdersTable + ".user_id"
-			case "status_id":
-				key = OrdersTable + ".status_id"
-			case "status":
-				key = StatusesTable + ".status"
-			case "accrual":
-				key = dbBonusesData.BonusesTable + ".count"
-			case "uploaded_at":
-				key = OrdersTable + ".uploaded_at"
-			case queries.Custom:
-				builder = builder.Where(val)
-				continue
-			}
-			builder = builder.Where(sq.Eq{key: "?"})
+	if len(filter.IDs) != 0 {
+		builder = builder.Where(sq.Eq{OrdersTable + ".id": filter.IDs})
+	}
+	if filter.Number != "" {
+		builder = builder.Where(sq.Eq{OrdersTable + ".num": filter.Number})
+	}
+	if filter.UserID != 0 {
+		builder = builder.Where(sq.Eq{OrdersTable + ".user_id": filter.UserID})
+	}
+	if len(filter.Statuses) != 0 {
+		builder = builder.Where(sq.Eq{StatusesTable + ".status": filter.Statuses})
+	}
+	if !filter.UploadedFrom.IsZero() {
+		builder = builder.Where(sq.GtOrEq{OrdersTable + ".uploaded_at": filter.UploadedFrom})
+	}
+	if !filter.UploadedTo.IsZero() {
+		builder = builder.Where(sq.Lt{OrdersTable + ".uploaded_at": filter.UploadedTo})
+	}
+
+	// keyset pagination: orders with the same upload time are ordered by id.
+	switch filter.Sort {
+	case keyset.SortNewestFirst:
+		if filter.After != nil {
+			builder = builder.Where(fmt.Sprintf("(%[1]s.uploaded_at, %[1]s.id) < (?, ?)", OrdersTable), filter.After.Time, filter.After.ID)
+		}
+		builder = builder.OrderBy(OrdersTable+".uploaded_at DESC", OrdersTable+".id DESC")
+	case keyset.SortOldestFirst:
+		if filter.After != nil {
+			builder = builder.Where(fmt.Sprintf("(%[1]s.uploaded_at, %[1]s.id) > (?, ?)", OrdersTable), filter.After.Time, filter.After.ID)
 		}
+		builder = builder.OrderBy(OrdersTable+".uploaded_at ASC", OrdersTable+".id ASC")
 	}
-	psqlSelect, _, err := builder.ToSql()
 
-	if err != nil {
-		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", OrdersTable, err)
+	if filter.Limit > 0 {
+		builder = builder.Limit(uint64(filter.Limit))
 	}
-	return tx.PrepareContext(ctx, psqlSelect)
+
+	return builder
 }
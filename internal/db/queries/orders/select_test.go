@@ -0,0 +1,66 @@
+package orders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSelectOrders(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := &keyset.Cursor{Time: from, ID: 7}
+	columns := "SELECT orders.id, orders.num, orders.user_id, statuses.status, orders.bonus_id, bonuses.count, orders.uploaded_at " +
+		"FROM orders JOIN statuses ON statuses.id = orders.status_id JOIN bonuses ON bonuses.id = orders.bonus_id"
+
+	tests := []struct {
+		name     string
+		filter   data.Filter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "by ids",
+			filter:   data.Filter{IDs: []int{1, 2}},
+			wantSQL:  columns + " WHERE orders.id IN ($1,$2)",
+			wantArgs: []interface{}{1, 2},
+		},
+		{
+			name:     "by number",
+			filter:   data.Filter{Number: "12345"},
+			wantSQL:  columns + " WHERE orders.num = $1",
+			wantArgs: []interface{}{"12345"},
+		},
+		{
+			name: "user's page",
+			filter: data.Filter{
+				UserID:       3,
+				Statuses:     []string{"NEW", "PROCESSED"},
+				UploadedFrom: from,
+				Sort:         keyset.SortNewestFirst,
+				After:        cursor,
+				Limit:        10,
+			},
+			wantSQL: columns + " WHERE orders.user_id = $1 AND statuses.status IN ($2,$3) AND orders.uploaded_at >= $4" +
+				" AND (orders.uploaded_at, orders.id) < ($5, $6) ORDER BY orders.uploaded_at DESC, orders.id DESC LIMIT 10",
+			wantArgs: []interface{}{int64(3), "NEW", "PROCESSED", from, from, int64(7)},
+		},
+		{
+			name:     "oldest first",
+			filter:   data.Filter{UploadedTo: from, Sort: keyset.SortOldestFirst, After: cursor},
+			wantSQL:  columns + " WHERE orders.uploaded_at < $1 AND (orders.uploaded_at, orders.id) > ($2, $3) ORDER BY orders.uploaded_at ASC, orders.id ASC",
+			wantArgs: []interface{}{from, from, int64(7)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildSelectOrders(tt.filter).ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
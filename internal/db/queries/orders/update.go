@@ -7,34 +7,35 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
-// UpdateByID performs direct query request to database to edit existing order's record.
-func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values map[string]interface{}, log logger.BaseLogger) error {
-	errMsg := fmt.Sprintf("update partially order by id '%d' with data '%v' in '%s'", id, values, OrdersTable) + ": %w"
+// UpdateStatuses performs single query request to database to set statuses of several orders.
+// statuses maps order's id to its new status id.
+func UpdateStatuses(ctx context.Context, tx *sql.Tx, statuses map[int64]int, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update statuses of '%d' orders in '%s'", len(statuses), OrdersTable) + ": %w"
+	if len(statuses) == 0 {
+		return nil
+	}
 
-	var columnsToUpdate []string
-	var valuesToUpdate []interface{}
-	for key, val := range values {
-		columnsToUpdate = append(columnsToUpdate, key)
-		valuesToUpdate = append(valuesToUpdate, val)
+	values := make([]interface{}, 0, 2*len(statuses))
+	for id, statusID := range statuses {
+		values = append(values, id, statusID)
 	}
-	valuesToUpdate = append(valuesToUpdate, id)
 
-	stmt, err := createUpdateOrderByIDStmt(ctx, tx, columnsToUpdate)
+	stmt, err := createUpdateStatusesStmt(ctx, tx, len(statuses))
 	if err != nil {
 		return fmt.Errorf(errMsg, err)
 	}
 	defer helpers.ExecuteWithLogError(stmt.Close, log)
 
-	var result sql.Result
 	query := func(context context.Context) error {
-		result, err = stmt.ExecContext(
+		_, err = stmt.ExecContext(
 			context,
-			valuesToUpdate...,
+			values...,
 		)
 		return err
 	}
@@ -43,28 +44,21 @@ func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values map[string]int
 		return fmt.Errorf(errMsg, err)
 	}
 
-	_, err = result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf(errMsg, err)
-	}
-
 	return nil
 }
 
-// createUpdateOrderByIDStmt generates statement for update query.
-func createUpdateOrderByIDStmt(ctx context.Context, tx *sql.Tx, values []string) (*sql.Stmt, error) {
+// createUpdateStatusesStmt generates statement for multi-row update query.
+func createUpdateStatusesStmt(ctx context.Context, tx *sql.Tx, rowsCount int) (*sql.Stmt, error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
-	builder := psql.Update(OrdersTable)
-	for _, col := range values {
-		builder = builder.Set(col, "?")
-	}
-	builder = builder.Where(sq.Eq{"id": "?"})
-	psqlUpdate, _, err := builder.ToSql()
+	psqlUpdate, _, err := psql.Update(OrdersTable).
+		Set("status_id", sq.Expr("v.status_id")).
+		From(queries.ValuesList(rowsCount, "v", []string{"id", "status_id"}, []string{"INTEGER", "SMALLINT"})).
+		Where(OrdersTable + ".id = v.id").
+		ToSql()
 
 	if err != nil {
-		return nil, fmt.Errorf("squirrel sql update statement for '%s': %w", OrdersTable, err)
-
+		return nil, fmt.Errorf("squirrel sql multi-row update statement for '%s': %w", OrdersTable, err)
 	}
 	return tx.PrepareContext(ctx, psqlUpdate)
 }
@@ -0,0 +1,8 @@
+package outbox
+
+const (
+	OutboxTable = "outbox"
+)
+
+// ColumnsInOutboxTable slice of main table attributes in database.
+var ColumnsInOutboxTable = []string{"user_id", "event_type", "payload", "created_at"}
@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// DeletePublished performs direct query request to database to remove events published before the moment.
+// Returns count of removed events.
+func DeletePublished(ctx context.Context, tx *sql.Tx, before time.Time, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("delete events published before '%s' in '%s'", before.Format(time.RFC3339), OutboxTable) + ": %w"
+
+	stmt, err := createDeletePublishedStmt(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			before,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return 0, fmt.Errorf(errMsg, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(errMsg, err)
+	}
+
+	return deleted, nil
+}
+
+// createDeletePublishedStmt generates statement for delete query.
+func createDeletePublishedStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlDelete, _, err := psql.Delete(OutboxTable).
+		Where("published_at IS NOT NULL AND published_at < ?").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql delete statement for '%s': %w", OutboxTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlDelete)
+}
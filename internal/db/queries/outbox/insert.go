@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Insert performs direct query request to database to add new outbox event.
+func Insert(ctx context.Context, tx *sql.Tx, event *data.Event, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert event '%s' for userID '%d' in '%s'", event.Type, event.UserID, OutboxTable) + ": %w"
+
+	stmt, err := createInsertStmt(ctx, tx)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var id int64
+	query := func(context context.Context) error {
+		return stmt.QueryRowContext(
+			context,
+			event.UserID,
+			event.Type,
+			[]byte(event.Payload),
+			event.CreatedAt,
+		).Scan(&id)
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	return id, nil
+}
+
+// createInsertStmt generates statement for insert query.
+func createInsertStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(OutboxTable).
+		Columns(ColumnsInOutboxTable...).
+		Values(make([]interface{}, len(ColumnsInOutboxTable))...).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", OutboxTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// SelectUnpublished performs direct query request to database to select oldest unpublished events which are neither
+// dead lettered nor claimed by another relay at the moment now.
+// Selected rows are locked till the end of transaction, rows locked by another relay are skipped.
+func SelectUnpublished(ctx context.Context, tx *sql.Tx, dialect db.Dialect, now time.Time, limit int, log logger.BaseLogger) ([]data.Event, error) {
+	errMsg := fmt.Sprintf("select unpublished events in '%s'", OutboxTable) + ": %w"
+
+	stmt, err := createSelectUnpublishedStmt(ctx, tx, dialect, limit)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(context, now)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return fmt.Errorf(errMsg, rows.Err())
+			}
+		}
+
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Event
+	for rows.Next() {
+		event := data.Event{}
+		var payload []byte
+		err = rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Type,
+			&payload,
+			&event.Attempts,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		event.Payload = payload
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// createSelectUnpublishedStmt generates statement for select query.
+func createSelectUnpublishedStmt(ctx context.Context, tx *sql.Tx, dialect db.Dialect, limit int) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlSelect, _, err := psql.Select("id", "user_id", "event_type", "payload", "attempts", "created_at").
+		From(OutboxTable).
+		Where("published_at IS NULL AND dead_lettered_at IS NULL").
+		Where("(locked_until IS NULL OR locked_until <= ?)").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Suffix(dialect.RowsLockSuffix()).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", OutboxTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
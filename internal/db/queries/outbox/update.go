@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Values outbox event's attributes to update. Nil fields are left unchanged.
+type Values struct {
+	Attempts    *int
+	PublishedAt *time.Time
+	LockedUntil *time.Time
+	// DeadLetteredAt moment when event is excluded from publishing after too many failed attempts.
+	DeadLetteredAt *time.Time
+}
+
+// UpdateByID performs direct query request to database to edit existing outbox event.
+func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values Values, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update partially outbox event by id '%d' in '%s'", id, OutboxTable) + ": %w"
+
+	stmt, args, err := createUpdateByIDStmt(ctx, tx, id, values)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			args...,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	_, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createUpdateByIDStmt generates statement for update query and its arguments.
+func createUpdateByIDStmt(ctx context.Context, tx *sql.Tx, id int64, values Values) (*sql.Stmt, []interface{}, error) {
+	psqlUpdate, args, err := buildUpdateByID(id, values).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql update statement for '%s': %w", OutboxTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlUpdate)
+	return stmt, args, err
+}
+
+// buildUpdateByID compiles values in update query with bound arguments. Query without values fails on build.
+func buildUpdateByID(id int64, values Values) sq.UpdateBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Update(OutboxTable)
+	if values.Attempts != nil {
+		builder = builder.Set("attempts", *values.Attempts)
+	}
+	if values.PublishedAt != nil {
+		builder = builder.Set("published_at", *values.PublishedAt)
+	}
+	if values.LockedUntil != nil {
+		builder = builder.Set("locked_until", *values.LockedUntil)
+	}
+	if values.DeadLetteredAt != nil {
+		builder = builder.Set("dead_lettered_at", *values.DeadLetteredAt)
+	}
+
+	return builder.Where(sq.Eq{"id": id})
+}
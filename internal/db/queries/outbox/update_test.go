@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUpdateByID(t *testing.T) {
+	attempts := 2
+	publishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		values   Values
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "published",
+			values:   Values{PublishedAt: &publishedAt},
+			wantSQL:  "UPDATE outbox SET published_at = $1 WHERE id = $2",
+			wantArgs: []interface{}{publishedAt, int64(1)},
+		},
+		{
+			name:     "failed attempt",
+			values:   Values{Attempts: &attempts},
+			wantSQL:  "UPDATE outbox SET attempts = $1 WHERE id = $2",
+			wantArgs: []interface{}{2, int64(1)},
+		},
+		{
+			name:     "failed attempt releases claim",
+			values:   Values{Attempts: &attempts, LockedUntil: &publishedAt},
+			wantSQL:  "UPDATE outbox SET attempts = $1, locked_until = $2 WHERE id = $3",
+			wantArgs: []interface{}{2, publishedAt, int64(1)},
+		},
+		{
+			name:     "dead lettered",
+			values:   Values{Attempts: &attempts, LockedUntil: &publishedAt, DeadLetteredAt: &publishedAt},
+			wantSQL:  "UPDATE outbox SET attempts = $1, locked_until = $2, dead_lettered_at = $3 WHERE id = $4",
+			wantArgs: []interface{}{2, publishedAt, publishedAt, int64(1)},
+		},
+		{
+			name:    "nothing to update",
+			values:  Values{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildUpdateByID(1, tt.values).ToSql()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package sessions
+
+const (
+	SessionsTable                = "sessions"
+	SupersededRefreshTokensTable = "superseded_refresh_tokens"
+)
+
+// ColumnsInSessionsTable slice of main table attributes in database.
+var ColumnsInSessionsTable = []string{"user_id", "refresh_token_hash", "expires_at", "revoked"}
+
+// ColumnsInSupersededRefreshTokensTable slice of refresh tokens replaced by rotation attributes in database.
+var ColumnsInSupersededRefreshTokensTable = []string{"refresh_token_hash", "session_id"}
@@ -0,0 +1,99 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Insert performs direct query request to database to add new session.
+func Insert(ctx context.Context, tx *sql.Tx, session *data.Session, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert session for userID '%d' in '%s'", session.UserID, SessionsTable) + ": %w"
+
+	stmt, err := createInsertSessionStmt(ctx, tx)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	newSessionID := int64(0)
+	query := func(context context.Context) error {
+		return stmt.QueryRowContext(
+			context,
+			session.UserID,
+			session.RefreshTokenHash,
+			session.ExpiresAt,
+			session.Revoked,
+		).Scan(&newSessionID)
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	return newSessionID, nil
+}
+
+// createInsertSessionStmt generates statement for insert query.
+func createInsertSessionStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(SessionsTable).
+		Columns(ColumnsInSessionsTable...).
+		Values(make([]interface{}, len(ColumnsInSessionsTable))...).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", SessionsTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
+
+// InsertSupersededRefreshToken performs direct query request to database to keep refresh token replaced by rotation.
+func InsertSupersededRefreshToken(ctx context.Context, tx *sql.Tx, sessionID int64, refreshTokenHash string, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("insert superseded refresh token of sessionID '%d' in '%s'", sessionID, SupersededRefreshTokensTable) + ": %w"
+
+	stmt, err := createInsertSupersededRefreshTokenStmt(ctx, tx)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err := stmt.ExecContext(
+			context,
+			refreshTokenHash,
+			sessionID,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createInsertSupersededRefreshTokenStmt generates statement for superseded refresh token insert query.
+func createInsertSupersededRefreshTokenStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(SupersededRefreshTokensTable).
+		Columns(ColumnsInSupersededRefreshTokensTable...).
+		Values(make([]interface{}, len(ColumnsInSupersededRefreshTokensTable))...).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", SupersededRefreshTokensTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
@@ -0,0 +1,104 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Select performs direct query request to database to select sessions satisfying filter.
+func Select(ctx context.Context, dbConn *sql.DB, filter data.Filter, log logger.BaseLogger) ([]data.Session, error) {
+	errMsg := fmt.Sprintf("select sessions in '%s'", SessionsTable) + ": %w"
+
+	stmt, args, err := createSelectSessionsStmt(ctx, dbConn, filter)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(
+			context,
+			args...,
+		)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return fmt.Errorf(errMsg, rows.Err())
+			}
+		}
+
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Session
+	for rows.Next() {
+		session := data.Session{}
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.RefreshTokenHash,
+			&session.ExpiresAt,
+			&session.Revoked,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, session)
+	}
+
+	return res, nil
+}
+
+// createSelectSessionsStmt generates statement for select query and its arguments.
+func createSelectSessionsStmt(ctx context.Context, dbConn *sql.DB, filter data.Filter) (*sql.Stmt, []interface{}, error) {
+	psqlSelect, args, err := buildSelectSessions(filter).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql select statement for '%s': %w", SessionsTable, err)
+	}
+
+	stmt, err := dbConn.PrepareContext(ctx, psqlSelect)
+	return stmt, args, err
+}
+
+// buildSelectSessions compiles filter in select query with bound arguments.
+func buildSelectSessions(filter data.Filter) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Select(
+		"id",
+		"user_id",
+		"refresh_token_hash",
+		"expires_at",
+		"revoked",
+	).
+		From(SessionsTable)
+
+	if filter.ID != 0 {
+		builder = builder.Where(sq.Eq{"id": filter.ID})
+	}
+	if filter.RefreshTokenHash != "" {
+		builder = builder.Where(sq.Eq{"refresh_token_hash": filter.RefreshTokenHash})
+	}
+	if filter.SupersededRefreshTokenHash != "" {
+		builder = builder.Where(
+			sq.Expr("id IN (SELECT session_id FROM "+SupersededRefreshTokensTable+" WHERE refresh_token_hash = ?)", filter.SupersededRefreshTokenHash),
+		)
+	}
+
+	return builder
+}
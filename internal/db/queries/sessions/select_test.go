@@ -0,0 +1,101 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSelectSessions(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   data.Filter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "by id",
+			filter:   data.Filter{ID: 3},
+			wantSQL:  "SELECT id, user_id, refresh_token_hash, expires_at, revoked FROM sessions WHERE id = $1",
+			wantArgs: []interface{}{int64(3)},
+		},
+		{
+			name:     "by refresh token hash",
+			filter:   data.Filter{RefreshTokenHash: "hash"},
+			wantSQL:  "SELECT id, user_id, refresh_token_hash, expires_at, revoked FROM sessions WHERE refresh_token_hash = $1",
+			wantArgs: []interface{}{"hash"},
+		},
+		{
+			name:     "by superseded refresh token hash",
+			filter:   data.Filter{SupersededRefreshTokenHash: "old"},
+			wantSQL:  "SELECT id, user_id, refresh_token_hash, expires_at, revoked FROM sessions WHERE id IN (SELECT session_id FROM superseded_refresh_tokens WHERE refresh_token_hash = $1)",
+			wantArgs: []interface{}{"old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildSelectSessions(tt.filter).ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBuildUpdateSessionByID(t *testing.T) {
+	hash := "hash"
+	expiresAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	revoked := true
+
+	tests := []struct {
+		name     string
+		values   Values
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "refresh token rotation",
+			values:   Values{RefreshTokenHash: &hash, ExpiresAt: &expiresAt},
+			wantSQL:  "UPDATE sessions SET refresh_token_hash = $1, expires_at = $2 WHERE id = $3",
+			wantArgs: []interface{}{"hash", expiresAt, int64(1)},
+		},
+		{
+			name:     "revocation",
+			values:   Values{Revoked: &revoked},
+			wantSQL:  "UPDATE sessions SET revoked = $1 WHERE id = $2",
+			wantArgs: []interface{}{true, int64(1)},
+		},
+		{
+			name:    "nothing to update",
+			values:  Values{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildUpdateSessionByID(1, tt.values).ToSql()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBuildUpdateSessionByIDAndRefreshToken(t *testing.T) {
+	hash := "new"
+	expiresAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	gotSQL, gotArgs, err := buildUpdateSessionByIDAndRefreshToken(1, "old", Values{RefreshTokenHash: &hash, ExpiresAt: &expiresAt}).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE sessions SET refresh_token_hash = $1, expires_at = $2 WHERE id = $3 AND refresh_token_hash = $4", gotSQL)
+	assert.Equal(t, []interface{}{"new", expiresAt, int64(1), "old"}, gotArgs)
+}
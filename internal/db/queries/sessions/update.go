@@ -0,0 +1,97 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Values session's attributes to update. Nil fields are left unchanged.
+type Values struct {
+	RefreshTokenHash *string
+	ExpiresAt        *time.Time
+	Revoked          *bool
+}
+
+// UpdateByID performs direct query request to database to edit existing session's record.
+func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values Values, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update partially session by id '%d' in '%s'", id, SessionsTable) + ": %w"
+
+	if _, err := execUpdate(ctx, tx, buildUpdateSessionByID(id, values), log); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// UpdateByIDAndRefreshToken performs direct query request to database to edit session's record only if it still has
+// refreshTokenHash. Returns false if the token has already been rotated.
+func UpdateByIDAndRefreshToken(ctx context.Context, tx *sql.Tx, id int64, refreshTokenHash string, values Values, log logger.BaseLogger) (bool, error) {
+	errMsg := fmt.Sprintf("update partially session by id '%d' and refresh token in '%s'", id, SessionsTable) + ": %w"
+
+	affected, err := execUpdate(ctx, tx, buildUpdateSessionByIDAndRefreshToken(id, refreshTokenHash, values), log)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	return affected != 0, nil
+}
+
+// execUpdate executes update query. Returns count of updated records.
+func execUpdate(ctx context.Context, tx *sql.Tx, builder sq.UpdateBuilder, log logger.BaseLogger) (int64, error) {
+	psqlUpdate, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("squirrel sql update statement for '%s': %w", SessionsTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlUpdate)
+	if err != nil {
+		return 0, err
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			args...,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// buildUpdateSessionByID compiles values in update query with bound arguments. Query without values fails on build.
+func buildUpdateSessionByID(id int64, values Values) sq.UpdateBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Update(SessionsTable)
+	if values.RefreshTokenHash != nil {
+		builder = builder.Set("refresh_token_hash", *values.RefreshTokenHash)
+	}
+	if values.ExpiresAt != nil {
+		builder = builder.Set("expires_at", *values.ExpiresAt)
+	}
+	if values.Revoked != nil {
+		builder = builder.Set("revoked", *values.Revoked)
+	}
+
+	return builder.Where(sq.Eq{"id": id})
+}
+
+// buildUpdateSessionByIDAndRefreshToken compiles values in update query conditioned by current refresh token.
+func buildUpdateSessionByIDAndRefreshToken(id int64, refreshTokenHash string, values Values) sq.UpdateBuilder {
+	return buildUpdateSessionByID(id, values).Where(sq.Eq{"refresh_token_hash": refreshTokenHash})
+}
@@ -15,7 +15,7 @@ import (
 
 // Insert performs direct query request to database to add new user.
 func Insert(ctx context.Context, tx *sql.Tx, userData *data.User, log logger.BaseLogger) error {
-	errMsg := fmt.Sprintf("insert user '%v' in '%s'", *userData, UsersTable) + ": %w"
+	errMsg := fmt.Sprintf("insert user '%s' in '%s'", userData.Login, UsersTable) + ": %w"
 
 	stmt, err := createInsertUserStmt(ctx, tx)
 	if err != nil {
@@ -13,26 +13,21 @@ import (
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
-// Select performs direct query request to database to select users satisfying filters.
-func Select(ctx context.Context, dbConn *sql.DB, filters map[string]interface{}, log logger.BaseLogger) ([]data.User, error) {
-	errMsg := fmt.Sprintf("select orders with filter '%v' in '%s'", filters, UsersTable) + ": %w"
+// Select performs direct query request to database to select users satisfying filter.
+func Select(ctx context.Context, dbConn *sql.DB, filter data.Filter, log logger.BaseLogger) ([]data.User, error) {
+	errMsg := fmt.Sprintf("select users with filter '%+v' in '%s'", filter, UsersTable) + ": %w"
 
-	stmt, err := createSelectUsersStmt(ctx, dbConn, filters)
+	stmt, args, err := createSelectUsersStmt(ctx, dbConn, filter)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
 	defer helpers.ExecuteWithLogError(stmt.Close, log)
 
-	var valuesToUpdate []interface{}
-	for _, val := range filters {
-		valuesToUpdate = append(valuesToUpdate, val)
-	}
-
 	var rows *sql.Rows
 	query := func(context context.Context) error {
 		rows, err = stmt.QueryContext(
 			context,
-			valuesToUpdate...,
+			args...,
 		)
 
 		if err == nil {
@@ -68,8 +63,19 @@ func Select(ctx context.Context, dbConn *sql.DB, filters map[string]interface{},
 	return res, nil
 }
 
-// createSelectUsersStmt generates statement for select query.
-func createSelectUsersStmt(ctx context.Context, dbConn *sql.DB, filters map[string]interface{}) (*sql.Stmt, error) {
+// createSelectUsersStmt generates statement for select query and its arguments.
+func createSelectUsersStmt(ctx context.Context, dbConn *sql.DB, filter data.Filter) (*sql.Stmt, []interface{}, error) {
+	psqlSelect, args, err := buildSelectUsers(filter).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql select statement for '%s': %w", UsersTable, err)
+	}
+
+	stmt, err := dbConn.PrepareContext(ctx, psqlSelect)
+	return stmt, args, err
+}
+
+// buildSelectUsers compiles filter in select query with bound arguments.
+func buildSelectUsers(filter data.Filter) sq.SelectBuilder {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 	builder := psql.Select(
@@ -79,15 +85,13 @@ func createSelectUsersStmt(ctx context.Context, dbConn *sql.DB, filters map[stri
 		"role_id",
 	).
 		From(UsersTable)
-	if len(filters) != 0 {
-		for key := range filters {
-			builder = builder.Where(sq.Eq{key: "?"})
-		}
-	}
-	psqlSelect, _, err := builder.ToSql()
 
-	if err != nil {
-		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", UsersTable, err)
+	if filter.ID != 0 {
+		builder = builder.Where(sq.Eq{"id": filter.ID})
+	}
+	if filter.Login != "" {
+		builder = builder.Where(sq.Eq{"login": filter.Login})
 	}
-	return dbConn.PrepareContext(ctx, psqlSelect)
+
+	return builder
 }
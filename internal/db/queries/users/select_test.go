@@ -0,0 +1,83 @@
+package users
+
+import (
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSelectUsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   data.Filter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "by id",
+			filter:   data.Filter{ID: 3},
+			wantSQL:  "SELECT id, login, password, role_id FROM users WHERE id = $1",
+			wantArgs: []interface{}{int64(3)},
+		},
+		{
+			name:     "login is bound argument",
+			filter:   data.Filter{Login: "user'; DROP TABLE users; --"},
+			wantSQL:  "SELECT id, login, password, role_id FROM users WHERE login = $1",
+			wantArgs: []interface{}{"user'; DROP TABLE users; --"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildSelectUsers(tt.filter).ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBuildUpdateUserByID(t *testing.T) {
+	password := "hash"
+	role := data.RoleAdmin
+
+	tests := []struct {
+		name     string
+		values   Values
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "password",
+			values:   Values{Password: &password},
+			wantSQL:  "UPDATE users SET password = $1 WHERE id = $2",
+			wantArgs: []interface{}{"hash", int64(1)},
+		},
+		{
+			name:     "password and role",
+			values:   Values{Password: &password, RoleID: &role},
+			wantSQL:  "UPDATE users SET password = $1, role_id = $2 WHERE id = $3",
+			wantArgs: []interface{}{"hash", data.RoleAdmin, int64(1)},
+		},
+		{
+			name:    "nothing to update",
+			values:  Values{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildUpdateUserByID(1, tt.values).ToSql()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
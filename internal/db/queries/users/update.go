@@ -0,0 +1,76 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// Values user's attributes to update. Nil fields are left unchanged.
+type Values struct {
+	Password *string
+	RoleID   *int
+}
+
+// UpdateByID performs direct query request to database to edit existing user's record.
+func UpdateByID(ctx context.Context, tx *sql.Tx, id int64, values Values, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update partially user by id '%d' in '%s'", id, UsersTable) + ": %w"
+
+	stmt, args, err := createUpdateUserByIDStmt(ctx, tx, id, values)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			args...,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	_, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createUpdateUserByIDStmt generates statement for update query and its arguments.
+func createUpdateUserByIDStmt(ctx context.Context, tx *sql.Tx, id int64, values Values) (*sql.Stmt, []interface{}, error) {
+	psqlUpdate, args, err := buildUpdateUserByID(id, values).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql update statement for '%s': %w", UsersTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlUpdate)
+	return stmt, args, err
+}
+
+// buildUpdateUserByID compiles values in update query with bound arguments. Query without values fails on build.
+func buildUpdateUserByID(id int64, values Values) sq.UpdateBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Update(UsersTable)
+	if values.Password != nil {
+		builder = builder.Set("password", *values.Password)
+	}
+	if values.RoleID != nil {
+		builder = builder.Set("role_id", *values.RoleID)
+	}
+
+	return builder.Where(sq.Eq{"id": id})
+}
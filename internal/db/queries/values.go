@@ -0,0 +1,26 @@
+package queries
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValuesList generates rows list with placeholders for rowsCount rows to use it as table in FROM clause.
+// Rows are combined by UNION ALL of casted placeholders instead of VALUES list, because SQLite doesn't support
+// VALUES columns aliases.
+func ValuesList(rowsCount int, alias string, columns []string, types []string) string {
+	firstRow := make([]string, len(types))
+	row := make([]string, len(types))
+	for i, columnType := range types {
+		row[i] = fmt.Sprintf("CAST(? AS %s)", columnType)
+		firstRow[i] = row[i] + " AS " + columns[i]
+	}
+
+	rows := make([]string, 0, rowsCount)
+	rows = append(rows, "SELECT "+strings.Join(firstRow, ", "))
+	for i := 1; i < rowsCount; i++ {
+		rows = append(rows, "SELECT "+strings.Join(row, ", "))
+	}
+
+	return fmt.Sprintf("(%s) AS %s", strings.Join(rows, " UNION ALL "), alias)
+}
@@ -0,0 +1,25 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesList(t *testing.T) {
+	tests := []struct {
+		name      string
+		rowsCount int
+		want      string
+	}{
+		{name: "single row", rowsCount: 1, want: "(SELECT CAST(? AS INTEGER) AS id, CAST(? AS SMALLINT) AS status_id) AS v"},
+		{name: "several rows", rowsCount: 3, want: "(SELECT CAST(? AS INTEGER) AS id, CAST(? AS SMALLINT) AS status_id" +
+			" UNION ALL SELECT CAST(? AS INTEGER), CAST(? AS SMALLINT) UNION ALL SELECT CAST(? AS INTEGER), CAST(? AS SMALLINT)) AS v"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValuesList(tt.rowsCount, "v", []string{"id", "status_id"}, []string{"INTEGER", "SMALLINT"})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
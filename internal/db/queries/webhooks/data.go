@@ -0,0 +1,12 @@
+package webhooks
+
+const (
+	WebhooksTable   = "webhooks"
+	DeliveriesTable = "webhook_deliveries"
+)
+
+// ColumnsInWebhooksTable slice of main table attributes in database.
+var ColumnsInWebhooksTable = []string{"user_id", "url", "secret", "created_at"}
+
+// ColumnsInDeliveriesTable slice of deliveries log table attributes in database.
+var ColumnsInDeliveriesTable = []string{"webhook_id", "user_id", "event_id", "event_type", "payload", "attempts", "status_code", "error", "delivered", "next_attempt_at", "created_at"}
@@ -0,0 +1,60 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// DeleteByID performs direct query request to database to remove user's webhook. Returns false if webhook is missing.
+func DeleteByID(ctx context.Context, tx *sql.Tx, userID int64, id int64, log logger.BaseLogger) (bool, error) {
+	errMsg := fmt.Sprintf("delete webhook by id '%d' for userID '%d' in '%s'", id, userID, WebhooksTable) + ": %w"
+
+	stmt, err := createDeleteByIDStmt(ctx, tx)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var result sql.Result
+	query := func(context context.Context) error {
+		result, err = stmt.ExecContext(
+			context,
+			id,
+			userID,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	return affected != 0, nil
+}
+
+// createDeleteByIDStmt generates statement for delete query.
+func createDeleteByIDStmt(ctx context.Context, tx *sql.Tx) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlDelete, _, err := psql.Delete(WebhooksTable).
+		Where(sq.Eq{"id": "?"}).
+		Where(sq.Eq{"user_id": "?"}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql delete statement for '%s': %w", WebhooksTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlDelete)
+}
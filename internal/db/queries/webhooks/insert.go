@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+// Insert performs direct query request to database to add new webhook. Returns -1 if user already has webhook with the same url.
+func Insert(ctx context.Context, tx *sql.Tx, webhook *data.Webhook, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert webhook '%s' for userID '%d' in '%s'", webhook.URL, webhook.UserID, WebhooksTable) + ": %w"
+
+	stmt, err := createInsertStmt(ctx, tx, WebhooksTable, ColumnsInWebhooksTable, "ON CONFLICT (user_id, url) DO NOTHING RETURNING id")
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	id := int64(-1)
+	query := func(context context.Context) error {
+		err := stmt.QueryRowContext(
+			context,
+			webhook.UserID,
+			webhook.URL,
+			webhook.Secret,
+			webhook.CreatedAt,
+		).Scan(&id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	return id, nil
+}
+
+// InsertDelivery performs direct query request to database to queue webhook delivery.
+// Returns -1 if event delivery to the webhook has already been queued.
+func InsertDelivery(ctx context.Context, tx *sql.Tx, delivery *data.Delivery, log logger.BaseLogger) (int64, error) {
+	errMsg := fmt.Sprintf("insert delivery of event '%d' to webhook '%d' in '%s'", delivery.EventID, delivery.WebhookID, DeliveriesTable) + ": %w"
+
+	stmt, err := createInsertStmt(ctx, tx, DeliveriesTable, ColumnsInDeliveriesTable, "ON CONFLICT (webhook_id, event_id) DO NOTHING RETURNING id")
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	id := int64(-1)
+	query := func(context context.Context) error {
+		err := stmt.QueryRowContext(
+			context,
+			delivery.WebhookID,
+			delivery.UserID,
+			delivery.EventID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Attempts,
+			delivery.StatusCode,
+			delivery.Error,
+			delivery.Delivered,
+			delivery.NextAttemptAt,
+			delivery.CreatedAt,
+		).Scan(&id)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	return id, nil
+}
+
+// createInsertStmt generates statement for insert query.
+func createInsertStmt(ctx context.Context, tx *sql.Tx, table string, columns []string, suffix string) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlInsert, _, err := psql.Insert(table).
+		Columns(columns...).
+		Values(make([]interface{}, len(columns))...).
+		Suffix(suffix).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql insert statement for '%s': %w", table, err)
+	}
+	return tx.PrepareContext(ctx, psqlInsert)
+}
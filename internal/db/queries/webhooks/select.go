@@ -0,0 +1,273 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+// SelectByUserID performs direct query request to database to select user's webhooks.
+func SelectByUserID(ctx context.Context, tx *sql.Tx, userID int64, log logger.BaseLogger) ([]data.Webhook, error) {
+	errMsg := fmt.Sprintf("select webhooks for userID '%d' in '%s'", userID, WebhooksTable) + ": %w"
+
+	stmt, err := createSelectByUserIDStmt(ctx, tx, WebhooksTable, append([]string{"id"}, ColumnsInWebhooksTable...), "id", 0)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	rows, err := queryByUserID(ctx, stmt, userID, log)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Webhook
+	for rows.Next() {
+		webhook := data.Webhook{}
+		err = rows.Scan(
+			&webhook.ID,
+			&webhook.UserID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, webhook)
+	}
+
+	return res, nil
+}
+
+// SelectDeliveriesByUserID performs direct query request to database to select user's latest webhooks deliveries.
+func SelectDeliveriesByUserID(ctx context.Context, tx *sql.Tx, userID int64, limit int, log logger.BaseLogger) ([]data.Delivery, error) {
+	errMsg := fmt.Sprintf("select webhooks deliveries for userID '%d' in '%s'", userID, DeliveriesTable) + ": %w"
+
+	columns := []string{"id", "webhook_id", "user_id", "event_id", "event_type", "attempts", "status_code", "error", "delivered", "next_attempt_at", "created_at"}
+	stmt, err := createSelectByUserIDStmt(ctx, tx, DeliveriesTable, columns, "id DESC", limit)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	rows, err := queryByUserID(ctx, stmt, userID, log)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Delivery
+	for rows.Next() {
+		delivery := data.Delivery{}
+		var nextAttemptAt sql.NullTime
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.UserID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Attempts,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Delivered,
+			&nextAttemptAt,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		if nextAttemptAt.Valid {
+			delivery.NextAttemptAt = &nextAttemptAt.Time
+		}
+		res = append(res, delivery)
+	}
+
+	return res, nil
+}
+
+// SelectByIDs performs direct query request to database to select webhooks by ids.
+func SelectByIDs(ctx context.Context, tx *sql.Tx, ids []int64, log logger.BaseLogger) ([]data.Webhook, error) {
+	errMsg := fmt.Sprintf("select webhooks by ids in '%s'", WebhooksTable) + ": %w"
+
+	psqlSelect, args, err := buildSelectByIDs(ids).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, fmt.Errorf("squirrel sql select statement for '%s': %w", WebhooksTable, err))
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlSelect)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(context, args...)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return rows.Err()
+			}
+		}
+
+		return err
+	}
+	if err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Webhook
+	for rows.Next() {
+		webhook := data.Webhook{}
+		err = rows.Scan(
+			&webhook.ID,
+			&webhook.UserID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, webhook)
+	}
+
+	return res, nil
+}
+
+// buildSelectByIDs compiles webhooks ids in select query with bound arguments.
+func buildSelectByIDs(ids []int64) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	return psql.Select(append([]string{"id"}, ColumnsInWebhooksTable...)...).
+		From(WebhooksTable).
+		Where(sq.Eq{"id": ids}).
+		OrderBy("id")
+}
+
+// SelectDueDeliveries performs direct query request to database to select up to limit queued deliveries which attempt
+// time has come. Selected rows are locked till the end of transaction, rows locked by another instance are skipped.
+func SelectDueDeliveries(ctx context.Context, tx *sql.Tx, dialect db.Dialect, now time.Time, limit int, log logger.BaseLogger) ([]data.Delivery, error) {
+	errMsg := fmt.Sprintf("select due deliveries in '%s'", DeliveriesTable) + ": %w"
+
+	stmt, err := createSelectDueDeliveriesStmt(ctx, tx, dialect, limit)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	var rows *sql.Rows
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(context, now)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return rows.Err()
+			}
+		}
+
+		return err
+	}
+	if err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	defer helpers.ExecuteWithLogError(rows.Close, log)
+	var res []data.Delivery
+	for rows.Next() {
+		delivery := data.Delivery{}
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.UserID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse db result: %w", err)
+		}
+
+		res = append(res, delivery)
+	}
+
+	return res, nil
+}
+
+// createSelectDueDeliveriesStmt generates statement for select query.
+func createSelectDueDeliveriesStmt(ctx context.Context, tx *sql.Tx, dialect db.Dialect, limit int) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	psqlSelect, _, err := psql.Select("id", "webhook_id", "user_id", "event_id", "event_type", "payload", "attempts", "created_at").
+		From(DeliveriesTable).
+		Where("next_attempt_at <= ?").
+		OrderBy("next_attempt_at").
+		Limit(uint64(limit)).
+		Suffix(dialect.RowsLockSuffix()).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", DeliveriesTable, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
+
+func queryByUserID(ctx context.Context, stmt *sql.Stmt, userID int64, log logger.BaseLogger) (*sql.Rows, error) {
+	var rows *sql.Rows
+	var err error
+	query := func(context context.Context) error {
+		rows, err = stmt.QueryContext(
+			context,
+			userID,
+		)
+
+		if err == nil {
+			if rows.Err() != nil {
+				return rows.Err()
+			}
+		}
+
+		return err
+	}
+	if err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// createSelectByUserIDStmt generates statement for select query. Zero limit means no limit.
+func createSelectByUserIDStmt(ctx context.Context, tx *sql.Tx, table string, columns []string, orderBy string, limit int) (*sql.Stmt, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Select(columns...).
+		From(table).
+		Where(sq.Eq{"user_id": 0}).
+		OrderBy(orderBy)
+
+	if limit > 0 {
+		builder = builder.Limit(uint64(limit))
+	}
+
+	psqlSelect, _, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", table, err)
+	}
+	return tx.PrepareContext(ctx, psqlSelect)
+}
@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/retryer"
+)
+
+// DeliveryValues delivery's attributes to update. Nil fields are left unchanged.
+type DeliveryValues struct {
+	Attempts      *int
+	StatusCode    *int
+	Error         *string
+	Delivered     *bool
+	NextAttemptAt *sql.NullTime // NextAttemptAt null value removes delivery from queue.
+}
+
+// UpdateDeliveryByID performs direct query request to database to save delivery attempt result and reschedule it.
+func UpdateDeliveryByID(ctx context.Context, tx *sql.Tx, id int64, values DeliveryValues, log logger.BaseLogger) error {
+	errMsg := fmt.Sprintf("update partially delivery by id '%d' in '%s'", id, DeliveriesTable) + ": %w"
+
+	stmt, args, err := createUpdateDeliveryByIDStmt(ctx, tx, id, values)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	query := func(context context.Context) error {
+		_, err = stmt.ExecContext(
+			context,
+			args...,
+		)
+		return err
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	return nil
+}
+
+// createUpdateDeliveryByIDStmt generates statement for update query and its arguments.
+func createUpdateDeliveryByIDStmt(ctx context.Context, tx *sql.Tx, id int64, values DeliveryValues) (*sql.Stmt, []interface{}, error) {
+	psqlUpdate, args, err := buildUpdateDeliveryByID(id, values).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql update statement for '%s': %w", DeliveriesTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlUpdate)
+	return stmt, args, err
+}
+
+// buildUpdateDeliveryByID compiles values in update query with bound arguments. Query without values fails on build.
+func buildUpdateDeliveryByID(id int64, values DeliveryValues) sq.UpdateBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	builder := psql.Update(DeliveriesTable)
+	if values.Attempts != nil {
+		builder = builder.Set("attempts", *values.Attempts)
+	}
+	if values.StatusCode != nil {
+		builder = builder.Set("status_code", *values.StatusCode)
+	}
+	if values.Error != nil {
+		builder = builder.Set("error", *values.Error)
+	}
+	if values.Delivered != nil {
+		builder = builder.Set("delivered", *values.Delivered)
+	}
+	if values.NextAttemptAt != nil {
+		builder = builder.Set("next_attempt_at", *values.NextAttemptAt)
+	}
+
+	return builder.Where(sq.Eq{"id": id})
+}
@@ -16,7 +16,7 @@ import (
 
 // Insert performs direct query request to database to add new withdrawal record.
 func Insert(ctx context.Context, tx *sql.Tx, withdrawal *data.Withdrawal, log logger.BaseLogger) error {
-	errMsg := fmt.Sprintf("insert withdrawal '%f' for userID '%d' in '%s'",
+	errMsg := fmt.Sprintf("insert withdrawal '%s' for userID '%d' in '%s'",
 		withdrawal.Sum,
 		withdrawal.UserID,
 		dbBonusesData.WithdrawalsTable,
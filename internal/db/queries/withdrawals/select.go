@@ -10,33 +10,29 @@ import (
 	"github.com/erupshis/bonusbridge/internal/db"
 	dbBonusesData "github.com/erupshis/bonusbridge/internal/db/queries/bonuses"
 	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/keyset"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/retryer"
 )
 
-// Select performs direct query request to database to select withdrawals satisfying filters.
-func Select(ctx context.Context, tx *sql.Tx, filters map[string]interface{}, log logger.BaseLogger) ([]data.Withdrawal, error) {
-	errMsg := fmt.Sprintf("select withdrawals with filter '%v' in '%s'",
-		filters,
+// Select performs direct query request to database to select withdrawals satisfying filter.
+func Select(ctx context.Context, tx *sql.Tx, filter data.WithdrawalsFilter, log logger.BaseLogger) ([]data.Withdrawal, error) {
+	errMsg := fmt.Sprintf("select withdrawals with filter '%+v' in '%s'",
+		filter,
 		dbBonusesData.WithdrawalsTable,
 	) + ": %w"
 
-	stmt, err := createSelectWithdrawalsStmt(ctx, tx, filters)
+	stmt, args, err := createSelectWithdrawalsStmt(ctx, tx, filter)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
 	defer helpers.ExecuteWithLogError(stmt.Close, log)
 
-	var valuesToUpdate []interface{}
-	for _, val := range filters {
-		valuesToUpdate = append(valuesToUpdate, val)
-	}
-
 	var rows *sql.Rows
 	query := func(context context.Context) error {
 		rows, err = stmt.QueryContext(
 			context,
-			valuesToUpdate...,
+			args...,
 		)
 
 		if err == nil {
@@ -73,36 +69,112 @@ func Select(ctx context.Context, tx *sql.Tx, filters map[string]interface{}, log
 	return res, nil
 }
 
-// createSelectBonusesStmt generates statement for select query.
-func createSelectWithdrawalsStmt(ctx context.Context, tx *sql.Tx, filters map[string]interface{}) (*sql.Stmt, error) {
-	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-
-	bonusesJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.id = %s.bonus_id",
-		dbBonusesData.BonusesTable,
+// SelectSummary performs direct query request to database to count withdrawals satisfying filter and sum them up.
+// Pagination fields of filter are ignored.
+func SelectSummary(ctx context.Context, tx *sql.Tx, filter data.WithdrawalsFilter, log logger.BaseLogger) (*data.WithdrawalsSummary, error) {
+	errMsg := fmt.Sprintf("select withdrawals summary with filter '%+v' in '%s'",
+		filter,
 		dbBonusesData.WithdrawalsTable,
-	)
+	) + ": %w"
+
+	psqlSelect, args, err := buildSelectSummary(filter).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, fmt.Errorf("squirrel sql select statement for '%s': %w", dbBonusesData.WithdrawalsTable, err))
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlSelect)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+	defer helpers.ExecuteWithLogError(stmt.Close, log)
+
+	summary := &data.WithdrawalsSummary{}
+	query := func(context context.Context) error {
+		return stmt.QueryRowContext(
+			context,
+			args...,
+		).Scan(&summary.Count, &summary.Sum)
+	}
+	err = retryer.RetryCallWithTimeoutErrorOnly(ctx, log, []int{1, 1, 3}, db.DatabaseErrorsToRetry, query)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	return summary, nil
+}
 
-	builder := psql.Select(
+// createSelectWithdrawalsStmt generates statement for select query and its arguments.
+func createSelectWithdrawalsStmt(ctx context.Context, tx *sql.Tx, filter data.WithdrawalsFilter) (*sql.Stmt, []interface{}, error) {
+	psqlSelect, args, err := buildSelectWithdrawals(filter).ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("squirrel sql select statement for '%s': %w", dbBonusesData.WithdrawalsTable, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, psqlSelect)
+	return stmt, args, err
+}
+
+// buildSelectWithdrawals compiles filter in select query with bound arguments.
+func buildSelectWithdrawals(filter data.WithdrawalsFilter) sq.SelectBuilder {
+	builder := withFilter(sq.Select(
 		dbBonusesData.WithdrawalsTable+".id",
 		dbBonusesData.WithdrawalsTable+".user_id",
 		dbBonusesData.WithdrawalsTable+".order_num",
 		fmt.Sprintf("ABS(%s) AS sum", dbBonusesData.BonusesTable+".count"),
 		dbBonusesData.WithdrawalsTable+".processed_at",
-	).
+	), filter)
+
+	// keyset pagination: withdrawals with the same processing time are ordered by id.
+	key := fmt.Sprintf("(%[1]s.processed_at, %[1]s.id)", dbBonusesData.WithdrawalsTable)
+	switch filter.Sort {
+	case keyset.SortNewestFirst:
+		if filter.After != nil {
+			builder = builder.Where(key+" < (?, ?)", filter.After.Time, filter.After.ID)
+		}
+		builder = builder.OrderBy(dbBonusesData.WithdrawalsTable+".processed_at DESC", dbBonusesData.WithdrawalsTable+".id DESC")
+	case keyset.SortOldestFirst:
+		if filter.After != nil {
+			builder = builder.Where(key+" > (?, ?)", filter.After.Time, filter.After.ID)
+		}
+		builder = builder.OrderBy(dbBonusesData.WithdrawalsTable+".processed_at ASC", dbBonusesData.WithdrawalsTable+".id ASC")
+	}
+
+	if filter.Limit > 0 {
+		builder = builder.Limit(uint64(filter.Limit))
+	}
+
+	return builder
+}
+
+// buildSelectSummary compiles filter in aggregate query with bound arguments.
+func buildSelectSummary(filter data.WithdrawalsFilter) sq.SelectBuilder {
+	return withFilter(sq.Select(
+		"COUNT(*)",
+		fmt.Sprintf("COALESCE(SUM(ABS(%s)), 0)", dbBonusesData.BonusesTable+".count"),
+	), filter)
+}
+
+// withFilter adds source tables and filter conditions of withdrawals to select query.
+func withFilter(builder sq.SelectBuilder, filter data.WithdrawalsFilter) sq.SelectBuilder {
+	bonusesJoin := fmt.Sprintf("LEFT JOIN %s ON %[1]s.id = %s.bonus_id",
+		dbBonusesData.BonusesTable,
+		dbBonusesData.WithdrawalsTable,
+	)
+
+	builder = builder.
+		PlaceholderFormat(sq.Dollar).
 		From(dbBonusesData.WithdrawalsTable).
 		JoinClause(bonusesJoin)
 
-	for key := range filters {
-		switch key {
-		case "user_id":
-			key = dbBonusesData.WithdrawalsTable + ".user_id"
-		}
-		builder = builder.Where(sq.Eq{key: "?"})
+	if filter.UserID != 0 {
+		builder = builder.Where(sq.Eq{dbBonusesData.WithdrawalsTable + ".user_id": filter.UserID})
 	}
-	psqlSelect, _, err := builder.ToSql()
-
-	if err != nil {
-		return nil, fmt.Errorf("squirrel sql select statement for '%s': %w", dbBonusesData.WithdrawalsTable, err)
+	if !filter.From.IsZero() {
+		builder = builder.Where(sq.GtOrEq{dbBonusesData.WithdrawalsTable + ".processed_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		builder = builder.Where(sq.Lt{dbBonusesData.WithdrawalsTable + ".processed_at": filter.To})
 	}
-	return tx.PrepareContext(ctx, psqlSelect)
+
+	return builder
 }
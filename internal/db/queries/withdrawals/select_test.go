@@ -0,0 +1,60 @@
+package withdrawals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSelectWithdrawals(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	cursor := &keyset.Cursor{Time: from, ID: 7}
+	columns := "SELECT withdrawals.id, withdrawals.user_id, withdrawals.order_num, ABS(bonuses.count) AS sum, withdrawals.processed_at " +
+		"FROM withdrawals LEFT JOIN bonuses ON bonuses.id = withdrawals.bonus_id"
+
+	tests := []struct {
+		name     string
+		filter   data.WithdrawalsFilter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "all user's withdrawals",
+			filter:   data.WithdrawalsFilter{UserID: 1, Sort: keyset.SortNewestFirst},
+			wantSQL:  columns + " WHERE withdrawals.user_id = $1 ORDER BY withdrawals.processed_at DESC, withdrawals.id DESC",
+			wantArgs: []interface{}{int64(1)},
+		},
+		{
+			name:   "page for period",
+			filter: data.WithdrawalsFilter{UserID: 1, From: from, To: to, Sort: keyset.SortOldestFirst, After: cursor, Limit: 5},
+			wantSQL: columns + " WHERE withdrawals.user_id = $1 AND withdrawals.processed_at >= $2 AND withdrawals.processed_at < $3" +
+				" AND (withdrawals.processed_at, withdrawals.id) > ($4, $5) ORDER BY withdrawals.processed_at ASC, withdrawals.id ASC LIMIT 5",
+			wantArgs: []interface{}{int64(1), from, to, from, int64(7)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := buildSelectWithdrawals(tt.filter).ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSQL, gotSQL)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestBuildSelectSummary(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// pagination doesn't narrow summary.
+	filter := data.WithdrawalsFilter{UserID: 1, From: from, Sort: keyset.SortNewestFirst, After: &keyset.Cursor{ID: 3}, Limit: 5}
+	gotSQL, gotArgs, err := buildSelectSummary(filter).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*), COALESCE(SUM(ABS(bonuses.count)), 0) FROM withdrawals "+
+		"LEFT JOIN bonuses ON bonuses.id = withdrawals.bonus_id WHERE withdrawals.user_id = $1 AND withdrawals.processed_at >= $2", gotSQL)
+	assert.Equal(t, []interface{}{int64(1), from}, gotArgs)
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	sqliteDriver "modernc.org/sqlite"
+)
+
+// OpenSQLite opens SQLite database by DSN with sqlite:// scheme.
+// SQLite stores timestamps as text with offset of bound time and compares them as strings, so every bound time
+// is converted to UTC to keep stored and compared values in one offset regardless of process time zone.
+func OpenSQLite(dsn string) *sql.DB {
+	return sql.OpenDB(&utcConnector{dataSource: SQLiteDataSource(dsn), driver: &sqliteDriver.Driver{}})
+}
+
+// utcConnector opens SQLite connections binding times in UTC.
+type utcConnector struct {
+	dataSource string
+	driver     driver.Driver
+}
+
+func (c *utcConnector) Connect(_ context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dataSource)
+	if err != nil {
+		return nil, err
+	}
+	return &utcConn{Conn: conn}, nil
+}
+
+func (c *utcConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// utcConn SQLite connection converting bound times to UTC. Context aware methods are passed to SQLite connection.
+type utcConn struct {
+	driver.Conn
+}
+
+// CheckNamedValue converts argument with default converter and moves time to UTC. Implements driver.NamedValueChecker.
+func (c *utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	value, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC()
+	}
+	nv.Value = value
+	return nil
+}
+
+func (c *utcConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *utcConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *utcConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *utcConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *utcConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
@@ -0,0 +1,33 @@
+package health
+
+import (
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/accrual/ratelimiter"
+	"github.com/erupshis/bonusbridge/internal/health/handlers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+type Controller struct {
+	accrualBreaker *breaker.Breaker
+	limiter        *ratelimiter.Limiter
+	workersPool    handlers.WorkersPool
+
+	log logger.BaseLogger
+}
+
+func CreateController(accrualBreaker *breaker.Breaker, limiter *ratelimiter.Limiter, workersPool handlers.WorkersPool,
+	baseLogger logger.BaseLogger) Controller {
+	return Controller{
+		accrualBreaker: accrualBreaker,
+		limiter:        limiter,
+		workersPool:    workersPool,
+		log:            baseLogger,
+	}
+}
+
+func (c *Controller) Route() *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/", handlers.Health(c.accrualBreaker, c.limiter, c.workersPool, c.log))
+	return r
+}
@@ -0,0 +1,38 @@
+package data
+
+import (
+	"time"
+)
+
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+)
+
+//go:generate easyjson -all data.go
+type Health struct {
+	Status  string  `json:"status"`
+	Accrual Accrual `json:"accrual"`
+}
+
+// Accrual state of interaction with accrual system.
+type Accrual struct {
+	Breaker             string     `json:"breaker"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	TotalFailures       int64      `json:"total_failures"`
+	RejectedRequests    int64      `json:"rejected_requests"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	RequestsPerMinute   int        `json:"requests_per_minute"`
+	Workers             Workers    `json:"workers"`
+}
+
+// Workers accrual system polling workers counters.
+type Workers struct {
+	Workers       int   `json:"workers"`
+	QueueLength   int   `json:"queue_length"`
+	QueueCapacity int   `json:"queue_capacity"`
+	InFlight      int64 `json:"in_flight"`
+	Completed     int64 `json:"completed"`
+	Failed        int64 `json:"failed"`
+}
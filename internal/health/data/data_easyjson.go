@@ -0,0 +1,319 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package data
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData(in *jlexer.Lexer, out *Workers) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "workers":
+			out.Workers = int(in.Int())
+		case "queue_length":
+			out.QueueLength = int(in.Int())
+		case "queue_capacity":
+			out.QueueCapacity = int(in.Int())
+		case "in_flight":
+			out.InFlight = int64(in.Int64())
+		case "completed":
+			out.Completed = int64(in.Int64())
+		case "failed":
+			out.Failed = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData(out *jwriter.Writer, in Workers) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"workers\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Workers))
+	}
+	{
+		const prefix string = ",\"queue_length\":"
+		out.RawString(prefix)
+		out.Int(int(in.QueueLength))
+	}
+	{
+		const prefix string = ",\"queue_capacity\":"
+		out.RawString(prefix)
+		out.Int(int(in.QueueCapacity))
+	}
+	{
+		const prefix string = ",\"in_flight\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.InFlight))
+	}
+	{
+		const prefix string = ",\"completed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Completed))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Failed))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Workers) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Workers) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Workers) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Workers) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData1(in *jlexer.Lexer, out *Health) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "accrual":
+			(out.Accrual).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData1(out *jwriter.Writer, in Health) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"accrual\":"
+		out.RawString(prefix)
+		(in.Accrual).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Health) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Health) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Health) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Health) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData1(l, v)
+}
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData2(in *jlexer.Lexer, out *Accrual) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "breaker":
+			out.Breaker = string(in.String())
+		case "consecutive_failures":
+			out.ConsecutiveFailures = int(in.Int())
+		case "total_failures":
+			out.TotalFailures = int64(in.Int64())
+		case "rejected_requests":
+			out.RejectedRequests = int64(in.Int64())
+		case "opened_at":
+			if in.IsNull() {
+				in.Skip()
+				out.OpenedAt = nil
+			} else {
+				if out.OpenedAt == nil {
+					out.OpenedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.OpenedAt).UnmarshalJSON(data))
+				}
+			}
+		case "last_error":
+			out.LastError = string(in.String())
+		case "requests_per_minute":
+			out.RequestsPerMinute = int(in.Int())
+		case "workers":
+			(out.Workers).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData2(out *jwriter.Writer, in Accrual) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"breaker\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Breaker))
+	}
+	{
+		const prefix string = ",\"consecutive_failures\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConsecutiveFailures))
+	}
+	{
+		const prefix string = ",\"total_failures\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalFailures))
+	}
+	{
+		const prefix string = ",\"rejected_requests\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.RejectedRequests))
+	}
+	if in.OpenedAt != nil {
+		const prefix string = ",\"opened_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.OpenedAt).MarshalJSON())
+	}
+	if in.LastError != "" {
+		const prefix string = ",\"last_error\":"
+		out.RawString(prefix)
+		out.String(string(in.LastError))
+	}
+	{
+		const prefix string = ",\"requests_per_minute\":"
+		out.RawString(prefix)
+		out.Int(int(in.RequestsPerMinute))
+	}
+	{
+		const prefix string = ",\"workers\":"
+		out.RawString(prefix)
+		(in.Workers).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Accrual) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Accrual) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalHealthData2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Accrual) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Accrual) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalHealthData2(l, v)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/accrual/ratelimiter"
+	"github.com/erupshis/bonusbridge/internal/accrual/workerspool"
+	"github.com/erupshis/bonusbridge/internal/health/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// WorkersPool source of accrual workers pool counters.
+type WorkersPool interface {
+	Stats() workerspool.Stats
+}
+
+// Health reports service state. Service is degraded while accrual system polling is stopped by circuit breaker.
+func Health(accrualBreaker *breaker.Breaker, limiter *ratelimiter.Limiter, workersPool WorkersPool, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats := accrualBreaker.Stats()
+		poolStats := workersPool.Stats()
+
+		health := data.Health{
+			Status: data.StatusOK,
+			Accrual: data.Accrual{
+				Breaker:             string(stats.State),
+				ConsecutiveFailures: stats.ConsecutiveFailures,
+				TotalFailures:       stats.TotalFailures,
+				RejectedRequests:    stats.Rejected,
+				LastError:           stats.LastError,
+				RequestsPerMinute:   limiter.RequestsPerMinute(),
+				Workers: data.Workers{
+					Workers:       poolStats.Workers,
+					QueueLength:   poolStats.QueueLength,
+					QueueCapacity: poolStats.QueueCapacity,
+					InFlight:      poolStats.InFlight,
+					Completed:     poolStats.Completed,
+					Failed:        poolStats.Failed,
+				},
+			},
+		}
+		if stats.State != breaker.StateClosed {
+			health.Status = data.StatusDegraded
+			health.Accrual.OpenedAt = &stats.OpenedAt
+		}
+
+		respBody, err := json.Marshal(health)
+		if err != nil {
+			log.Info("[health:handlers:Health] failed convert health to JSON: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(respBody)))
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(respBody); err != nil {
+			log.Info("[health:handlers:Health] failed to write response body: %v", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/accrual/breaker"
+	"github.com/erupshis/bonusbridge/internal/accrual/ratelimiter"
+	"github.com/erupshis/bonusbridge/internal/accrual/workerspool"
+	"github.com/erupshis/bonusbridge/internal/health/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	accrualBreaker := breaker.Create(1, time.Minute, log)
+	limiter := ratelimiter.Create(10, 1, log)
+
+	workersPool := workerspool.Create[int](context.Background(), 2, 10, workerspool.PolicyReject, log)
+	defer func() {
+		_ = workersPool.Shutdown(context.Background())
+	}()
+
+	ts := httptest.NewServer(Health(accrualBreaker, limiter, workersPool, log))
+	defer ts.Close()
+
+	getHealth := func() data.Health {
+		resp, err := ts.Client().Get(ts.URL)
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		health := data.Health{}
+		require.NoError(t, health.UnmarshalJSON(body))
+		return health
+	}
+
+	health := getHealth()
+	assert.Equal(t, data.StatusOK, health.Status)
+	assert.Equal(t, string(breaker.StateClosed), health.Accrual.Breaker)
+	assert.Equal(t, 10, health.Accrual.RequestsPerMinute)
+	assert.Nil(t, health.Accrual.OpenedAt)
+	assert.Equal(t, data.Workers{Workers: 2, QueueCapacity: 10}, health.Accrual.Workers)
+
+	accrualBreaker.Failure(fmt.Errorf("connection refused"))
+	health = getHealth()
+	assert.Equal(t, data.StatusDegraded, health.Status)
+	assert.Equal(t, string(breaker.StateOpen), health.Accrual.Breaker)
+	assert.Equal(t, 1, health.Accrual.ConsecutiveFailures)
+	assert.Equal(t, "connection refused", health.Accrual.LastError)
+	assert.NotNil(t, health.Accrual.OpenedAt)
+}
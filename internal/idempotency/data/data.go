@@ -0,0 +1,39 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+// HeaderKey request header with client generated idempotency key.
+const HeaderKey = "Idempotency-Key"
+
+// ErrRequestInProgress request with the same key is still processed.
+var ErrRequestInProgress = fmt.Errorf("request with the same idempotency key is in progress")
+
+// ErrRequestMismatch key was used before with another request.
+var ErrRequestMismatch = fmt.Errorf("idempotency key was used with another request")
+
+// ErrReservationLost request took longer than reservation lease and the key was taken over by retry.
+var ErrReservationLost = fmt.Errorf("idempotency key reservation was taken over")
+
+// Record first response for (user, key). Zero StatusCode means that request is still processed.
+// ExpiresAt of processed request is the end of its reservation lease, of completed one - the end of replaying.
+type Record struct {
+	ID          int64
+	UserID      int64
+	Key         string
+	RequestHash string
+
+	StatusCode  int
+	ContentType string
+	Body        []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// IsCompleted checks if response was saved and can be replayed.
+func (r *Record) IsCompleted() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,7 @@
+package data
+
+// Filter idempotency keys records selection conditions. Zero value fields are not applied.
+type Filter struct {
+	UserID int64
+	Key    string
+}
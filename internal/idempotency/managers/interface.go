@@ -0,0 +1,15 @@
+package managers
+
+import (
+	"context"
+
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+)
+
+//go:generate mockgen -destination=../../../mocks/mock_BaseIdempotencyManager.go -package=mocks github.com/erupshis/bonusbridge/internal/idempotency/managers BaseIdempotencyManager
+type BaseIdempotencyManager interface {
+	ReserveKey(ctx context.Context, record *data.Record) (bool, error)
+	GetRecord(ctx context.Context, userID int64, key string) (*data.Record, error)
+	CompleteRecord(ctx context.Context, record *data.Record) (bool, error)
+	DeleteRecord(ctx context.Context, record *data.Record) error
+}
@@ -0,0 +1,103 @@
+package managers
+
+import (
+	"context"
+
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+type memoryManager struct {
+	store *memory.Store
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation keeping idempotency keys in memory.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseIdempotencyManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+// ReserveKey adds record for user's key. Expired record of the same key is overwritten.
+// Returns false if the key is still reserved by another record.
+func (p *memoryManager) ReserveKey(_ context.Context, record *data.Record) (bool, error) {
+	p.log.Info("[idempotency:memoryManager:ReserveKey] reserve key '%s' for userID '%d'", record.Key, record.UserID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	if i := p.findRecord(record.UserID, record.Key); i != -1 {
+		existing := &p.store.IdempotencyRecords[i]
+		if !existing.ExpiresAt.Before(record.CreatedAt) {
+			return false, nil
+		}
+
+		record.ID = existing.ID
+		*existing = *record
+		return true, nil
+	}
+
+	record.ID = p.store.NextID("idempotency_keys")
+	p.store.IdempotencyRecords = append(p.store.IdempotencyRecords, *record)
+	return true, nil
+}
+
+func (p *memoryManager) GetRecord(_ context.Context, userID int64, key string) (*data.Record, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	i := p.findRecord(userID, key)
+	if i == -1 {
+		return nil, nil
+	}
+
+	record := p.store.IdempotencyRecords[i]
+	return &record, nil
+}
+
+// CompleteRecord saves response in reserved record. Returns false if reservation was taken over by another request.
+func (p *memoryManager) CompleteRecord(_ context.Context, record *data.Record) (bool, error) {
+	p.log.Info("[idempotency:memoryManager:CompleteRecord] complete record id '%d'", record.ID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.IdempotencyRecords {
+		if existing := &p.store.IdempotencyRecords[i]; existing.ID == record.ID && existing.CreatedAt.Equal(record.CreatedAt) {
+			existing.StatusCode = record.StatusCode
+			existing.ContentType = record.ContentType
+			existing.Body = record.Body
+			existing.ExpiresAt = record.ExpiresAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteRecord removes reserved record. Record taken over by another request is left as is.
+func (p *memoryManager) DeleteRecord(_ context.Context, record *data.Record) error {
+	p.log.Info("[idempotency:memoryManager:DeleteRecord] delete record id '%d'", record.ID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.IdempotencyRecords {
+		if existing := p.store.IdempotencyRecords[i]; existing.ID == record.ID && existing.CreatedAt.Equal(record.CreatedAt) {
+			p.store.IdempotencyRecords = append(p.store.IdempotencyRecords[:i], p.store.IdempotencyRecords[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// findRecord returns index of user's key record or -1. Must be called under store lock.
+func (p *memoryManager) findRecord(userID int64, key string) int {
+	for i := range p.store.IdempotencyRecords {
+		if p.store.IdempotencyRecords[i].UserID == userID && p.store.IdempotencyRecords[i].Key == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,122 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries/idempotency"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// manager storageManager implementation for PostgreSQL.
+type manager struct {
+	*db.Conn
+
+	log logger.BaseLogger
+}
+
+// Create creates manager implementation.
+func Create(dbConn *db.Conn, log logger.BaseLogger) BaseIdempotencyManager {
+	return &manager{
+		Conn: dbConn,
+		log:  log,
+	}
+}
+
+// ReserveKey adds record for (user, key) or takes over expired one. Returns false if live record already exists.
+func (p *manager) ReserveKey(ctx context.Context, record *data.Record) (bool, error) {
+	p.log.Info("[idempotency:manager:ReserveKey] start transaction for userID '%d', key '%s'", record.UserID, record.Key)
+	errMsg := "reserve idempotency key in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	id, err := idempotency.InsertOrTakeExpired(ctx, tx, record, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[idempotency:manager:ReserveKey] transaction successful")
+	if id == -1 {
+		return false, nil
+	}
+
+	record.ID = id
+	return true, nil
+}
+
+func (p *manager) GetRecord(ctx context.Context, userID int64, key string) (*data.Record, error) {
+	p.log.Info("[idempotency:manager:GetRecord] perform request")
+
+	records, err := idempotency.Select(ctx, p.DB, data.Filter{UserID: userID, Key: key}, p.log)
+	if err != nil {
+		return nil, fmt.Errorf("get idempotency record: %w", err)
+	}
+
+	p.log.Info("[idempotency:manager:GetRecord] request successful")
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return &records[0], nil
+}
+
+// CompleteRecord saves response in reserved record. Returns false if reservation was taken over by another request.
+func (p *manager) CompleteRecord(ctx context.Context, record *data.Record) (bool, error) {
+	p.log.Info("[idempotency:manager:CompleteRecord] start transaction for id '%d'", record.ID)
+	errMsg := "complete idempotency record in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	values := idempotency.Values{
+		StatusCode:  &record.StatusCode,
+		ContentType: &record.ContentType,
+		Body:        &record.Body,
+		ExpiresAt:   &record.ExpiresAt,
+	}
+	completed, err := idempotency.UpdateByID(ctx, tx, record.ID, record.CreatedAt, values, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[idempotency:manager:CompleteRecord] transaction successful")
+	return completed, nil
+}
+
+// DeleteRecord removes reserved record. Record taken over by another request is left as is.
+func (p *manager) DeleteRecord(ctx context.Context, record *data.Record) error {
+	p.log.Info("[idempotency:manager:DeleteRecord] start transaction for id '%d'", record.ID)
+	errMsg := "delete idempotency record in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = idempotency.DeleteByID(ctx, tx, record.ID, record.CreatedAt, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[idempotency:manager:DeleteRecord] transaction successful")
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package idempotency replays the first response for retried requests with the same 'Idempotency-Key' header.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/idempotency/storage"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// maxKeyLength limit of key length in database.
+const maxKeyLength = 255
+
+// HeaderReplayed response header that marks replayed responses.
+const HeaderReplayed = "Idempotent-Replayed"
+
+// Middleware handles requests with 'Idempotency-Key' header. Requests without the header are passed as is.
+// The first response with non 5xx status is stored per (user, key) and replayed for retries.
+// Must be used after user's authorization.
+func Middleware(strg storage.BaseIdempotencyStorage, log logger.BaseLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(data.HeaderKey)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				log.Info("[idempotency:Middleware] too long idempotency key")
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			userID, err := auth.GetUserIDFromContext(r.Context())
+			if err != nil {
+				log.Info("[idempotency:Middleware] failed to extract userID: %v", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				log.Info("[idempotency:Middleware] failed to read request body: %v", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			record, err := strg.Begin(r.Context(), userID, key, hashRequest(r, body))
+			if err != nil {
+				switch {
+				case errors.Is(err, data.ErrRequestInProgress):
+					w.WriteHeader(http.StatusConflict)
+				case errors.Is(err, data.ErrRequestMismatch):
+					w.WriteHeader(http.StatusUnprocessableEntity)
+				default:
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+				log.Info("[idempotency:Middleware] failed to begin request: %v", err)
+				return
+			}
+
+			if record.IsCompleted() {
+				log.Info("[idempotency:Middleware] replay response for userID '%d', key '%s'", userID, key)
+				replay(w, record, log)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			// response is saved even if client has already gone, otherwise the key stays reserved till lease expiration.
+			ctxWithoutCancel := context.WithoutCancel(r.Context())
+			if recorder.statusCode() >= http.StatusInternalServerError {
+				if err = strg.Release(ctxWithoutCancel, record); err != nil {
+					log.Info("[idempotency:Middleware] failed to release key: %v", err)
+				}
+				return
+			}
+
+			record.StatusCode = recorder.statusCode()
+			record.ContentType = w.Header().Get("Content-Type")
+			record.Body = recorder.body.Bytes()
+			if err = strg.Complete(ctxWithoutCancel, record); err != nil {
+				log.Info("[idempotency:Middleware] failed to save response: %v", err)
+			}
+		})
+	}
+}
+
+// hashRequest request fingerprint to detect key reuse with another request.
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, record *data.Record, log logger.BaseLogger) {
+	if record.ContentType != "" {
+		w.Header().Set("Content-Type", record.ContentType)
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(record.StatusCode)
+	if _, err := w.Write(record.Body); err != nil {
+		log.Info("[idempotency:replay] failed to write response body: %v", err)
+	}
+}
+
+// responseRecorder copies response status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
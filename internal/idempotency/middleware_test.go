@@ -0,0 +1,143 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	usersData "github.com/erupshis/bonusbridge/internal/auth/users/data"
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	completed := &data.Record{ID: 1, StatusCode: http.StatusAccepted, ContentType: "text/plain", Body: []byte("first")}
+
+	mockStorage := mocks.NewMockBaseIdempotencyStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().Begin(gomock.Any(), int64(1), "k1", gomock.Any()).Return(&data.Record{ID: 1}, nil),
+		mockStorage.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record *data.Record) error {
+			// response is saved regardless of client's disconnection.
+			assert.Nil(t, ctx.Done())
+			assert.Equal(t, http.StatusAccepted, record.StatusCode)
+			assert.Equal(t, "text/plain", record.ContentType)
+			assert.Equal(t, []byte("handled"), record.Body)
+			return nil
+		}),
+		mockStorage.EXPECT().Begin(gomock.Any(), int64(1), "k1", gomock.Any()).Return(completed, nil),
+		mockStorage.EXPECT().Begin(gomock.Any(), int64(1), "k2", gomock.Any()).Return(nil, fmt.Errorf("wrap: %w", data.ErrRequestInProgress)),
+		mockStorage.EXPECT().Begin(gomock.Any(), int64(1), "k3", gomock.Any()).Return(nil, fmt.Errorf("wrap: %w", data.ErrRequestMismatch)),
+		mockStorage.EXPECT().Begin(gomock.Any(), int64(1), "k4", gomock.Any()).Return(nil, fmt.Errorf("db error")),
+		mockStorage.EXPECT().Begin(gomock.Any(), int64(1), "fail", gomock.Any()).Return(&data.Record{ID: 2}, nil),
+		mockStorage.EXPECT().Release(gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("handled"))
+	})
+
+	withUserID := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString(usersData.UserID), "1")
+		Middleware(mockStorage, log)(handler).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+
+	ts := httptest.NewServer(withUserID)
+	defer ts.Close()
+
+	type args struct {
+		key  string
+		body string
+	}
+	type want struct {
+		statusCode int
+		body       string
+		replayed   bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "first request",
+			args: args{key: "k1", body: "req"},
+			want: want{statusCode: http.StatusAccepted, body: "handled"},
+		},
+		{
+			name: "retry is replayed",
+			args: args{key: "k1", body: "req"},
+			want: want{statusCode: http.StatusAccepted, body: "first", replayed: true},
+		},
+		{
+			name: "request in progress",
+			args: args{key: "k2", body: "req"},
+			want: want{statusCode: http.StatusConflict},
+		},
+		{
+			name: "key reused with another body",
+			args: args{key: "k3", body: "req"},
+			want: want{statusCode: http.StatusUnprocessableEntity},
+		},
+		{
+			name: "storage error",
+			args: args{key: "k4", body: "req"},
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "server error releases key",
+			args: args{key: "fail", body: "fail"},
+			want: want{statusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "without key",
+			args: args{key: "", body: "req"},
+			want: want{statusCode: http.StatusAccepted, body: "handled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString(tt.args.body))
+			require.NoError(t, errReq)
+			if tt.args.key != "" {
+				req.Header.Set(data.HeaderKey, tt.args.key)
+			}
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+			assert.Equal(t, tt.want.replayed, resp.Header.Get(HeaderReplayed) == "true")
+			if tt.want.body != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want.body, string(body))
+			}
+		})
+	}
+}
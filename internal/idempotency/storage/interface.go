@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+)
+
+//go:generate mockgen -destination=../../../mocks/mock_BaseIdempotencyStorage.go -package=mocks github.com/erupshis/bonusbridge/internal/idempotency/storage BaseIdempotencyStorage
+type BaseIdempotencyStorage interface {
+	Begin(ctx context.Context, userID int64, key string, requestHash string) (*data.Record, error)
+	Complete(ctx context.Context, record *data.Record) error
+	Release(ctx context.Context, record *data.Record) error
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/idempotency/managers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+)
+
+// reservationLease time while key is reserved by processed request. Retries take over the key after lease expiration
+// if request's handler hung or instance failed before completing it.
+const reservationLease = time.Minute
+
+type Storage struct {
+	manager managers.BaseIdempotencyManager
+
+	keyTTL int
+
+	log logger.BaseLogger
+}
+
+// Create creates idempotency keys storage. keyTTL - time in hours while the first response is replayed for retries.
+func Create(manager managers.BaseIdempotencyManager, keyTTL int, baseLogger logger.BaseLogger) BaseIdempotencyStorage {
+	return &Storage{
+		manager: manager,
+		keyTTL:  keyTTL,
+		log:     baseLogger,
+	}
+}
+
+// Begin reserves key for request. Returns reserved record to be completed after request handling
+// or completed record with the first response that should be replayed.
+func (s *Storage) Begin(ctx context.Context, userID int64, key string, requestHash string) (*data.Record, error) {
+	errMsg := fmt.Sprintf("begin request with idempotency key '%s' for userID '%d'", key, userID) + ": %w"
+
+	now := time.Now()
+	record := &data.Record{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(reservationLease),
+	}
+
+	reserved, err := s.manager.ReserveKey(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if reserved {
+		return record, nil
+	}
+
+	existing, err := s.manager.GetRecord(ctx, userID, key)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if existing == nil {
+		// record was released right after reservation attempt.
+		return nil, fmt.Errorf(errMsg, data.ErrRequestInProgress)
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, fmt.Errorf(errMsg, data.ErrRequestMismatch)
+	}
+
+	if !existing.IsCompleted() {
+		return nil, fmt.Errorf(errMsg, data.ErrRequestInProgress)
+	}
+
+	return existing, nil
+}
+
+// Complete saves response for replaying during key's TTL.
+func (s *Storage) Complete(ctx context.Context, record *data.Record) error {
+	errMsg := fmt.Sprintf("complete request with idempotency key '%s'", record.Key) + ": %w"
+
+	record.ExpiresAt = time.Now().Add(time.Duration(s.keyTTL) * time.Hour)
+	completed, err := s.manager.CompleteRecord(ctx, record)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if !completed {
+		return fmt.Errorf(errMsg, data.ErrReservationLost)
+	}
+
+	return nil
+}
+
+// Release removes reservation, so the request can be retried with the same key.
+func (s *Storage) Release(ctx context.Context, record *data.Record) error {
+	if err := s.manager.DeleteRecord(ctx, record); err != nil {
+		return fmt.Errorf("release idempotency key '%s': %w", record.Key, err)
+	}
+
+	return nil
+}
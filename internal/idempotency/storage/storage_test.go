@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/idempotency/data"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Begin(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	completed := &data.Record{ID: 1, UserID: 1, Key: "k", RequestHash: "h", StatusCode: 200, Body: []byte("ok")}
+	inProgress := &data.Record{ID: 1, UserID: 1, Key: "k", RequestHash: "h"}
+
+	mockManager := mocks.NewMockBaseIdempotencyManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().ReserveKey(gomock.Any(), gomock.Any()).Return(true, nil),
+		mockManager.EXPECT().ReserveKey(gomock.Any(), gomock.Any()).Return(false, nil),
+		mockManager.EXPECT().GetRecord(gomock.Any(), int64(1), "k").Return(completed, nil),
+		mockManager.EXPECT().ReserveKey(gomock.Any(), gomock.Any()).Return(false, nil),
+		mockManager.EXPECT().GetRecord(gomock.Any(), int64(1), "k").Return(inProgress, nil),
+		mockManager.EXPECT().ReserveKey(gomock.Any(), gomock.Any()).Return(false, nil),
+		mockManager.EXPECT().GetRecord(gomock.Any(), int64(1), "k").Return(completed, nil),
+		mockManager.EXPECT().ReserveKey(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("db error")),
+		mockManager.EXPECT().ReserveKey(gomock.Any(), gomock.Any()).Return(false, nil),
+		mockManager.EXPECT().GetRecord(gomock.Any(), int64(1), "k").Return(nil, fmt.Errorf("db error")),
+	)
+
+	type args struct {
+		requestHash string
+	}
+	type want struct {
+		completed bool
+		err       error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name:    "new key",
+			args:    args{requestHash: "h"},
+			want:    want{completed: false},
+			wantErr: false,
+		},
+		{
+			name:    "replay completed request",
+			args:    args{requestHash: "h"},
+			want:    want{completed: true},
+			wantErr: false,
+		},
+		{
+			name:    "request in progress",
+			args:    args{requestHash: "h"},
+			want:    want{err: data.ErrRequestInProgress},
+			wantErr: true,
+		},
+		{
+			name:    "key reused with another request",
+			args:    args{requestHash: "other"},
+			want:    want{err: data.ErrRequestMismatch},
+			wantErr: true,
+		},
+		{
+			name:    "reserve error",
+			args:    args{requestHash: "h"},
+			wantErr: true,
+		},
+		{
+			name:    "select error",
+			args:    args{requestHash: "h"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Create(mockManager, 24, log)
+			record, err := s.Begin(context.Background(), 1, "k", tt.args.requestHash)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.want.err != nil {
+					assert.True(t, errors.Is(err, tt.want.err))
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.completed, record.IsCompleted())
+			if !tt.want.completed {
+				// reservation is taken over after short lease, not after key's TTL.
+				assert.Equal(t, reservationLease, record.ExpiresAt.Sub(record.CreatedAt))
+			}
+		})
+	}
+}
+
+func TestStorage_Complete(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockBaseIdempotencyManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().CompleteRecord(gomock.Any(), gomock.Any()).Return(true, nil),
+		mockManager.EXPECT().CompleteRecord(gomock.Any(), gomock.Any()).Return(false, nil),
+		mockManager.EXPECT().CompleteRecord(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("db error")),
+	)
+
+	s := Create(mockManager, 24, log)
+
+	record := &data.Record{ID: 1, Key: "k", StatusCode: 200, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(reservationLease)}
+	assert.NoError(t, s.Complete(context.Background(), record))
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), record.ExpiresAt, time.Minute)
+
+	assert.ErrorIs(t, s.Complete(context.Background(), record), data.ErrReservationLost)
+	assert.Error(t, s.Complete(context.Background(), record))
+}
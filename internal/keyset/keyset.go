@@ -0,0 +1,57 @@
+// Package keyset implements keyset pagination cursors shared by paginated lists.
+package keyset
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = fmt.Errorf("invalid cursor")
+
+// Sort list sorting by (time, id) key.
+type Sort string
+
+const (
+	SortNewestFirst Sort = "desc"
+	SortOldestFirst Sort = "asc"
+)
+
+// Cursor position of the last item on the page. Next page starts right after it.
+type Cursor struct {
+	Time time.Time
+	ID   int64
+}
+
+// String encodes cursor in opaque URL safe token.
+func (c Cursor) String() string {
+	raw := fmt.Sprintf("%d_%d", c.Time.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes cursor token created by Cursor.String. Cursor time is returned in UTC.
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	nanos, id, found := strings.Cut(string(raw), "_")
+	if !found {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidCursor, token)
+	}
+
+	unixNanos, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	itemID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return &Cursor{Time: time.Unix(0, unixNanos).UTC(), ID: itemID}, nil
+}
@@ -0,0 +1,37 @@
+package keyset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	cursor := Cursor{Time: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), ID: 42}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *Cursor
+		wantErr bool
+	}{
+		{name: "valid", token: cursor.String(), want: &cursor},
+		{name: "not base64", token: "!!!", wantErr: true},
+		{name: "without separator", token: "MTIz", wantErr: true},
+		{name: "invalid id", token: Cursor{}.String() + "eA", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCursor)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
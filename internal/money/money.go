@@ -0,0 +1,133 @@
+// Package money fixed-point money amount with two fractional digits.
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale count of minor units in one unit.
+const Scale = 100
+
+var scaleRat = big.NewRat(Scale, 1)
+
+// Amount money in minor units(cents). Matches database NUMERIC(9,2) and keeps JSON number format on the wire.
+type Amount int64
+
+// New creates amount from units and minor units, e.g. New(729, 98) is 729.98.
+func New(units int64, cents int64) Amount {
+	return Amount(units*Scale + cents)
+}
+
+// Parse converts decimal number string into amount. Digits beyond cents are rounded half away from zero.
+func Parse(s string) (Amount, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("parse money amount '%s': invalid number", s)
+	}
+
+	rat.Mul(rat, scaleRat)
+	num, denom := rat.Num(), rat.Denom()
+
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	if rem.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom) >= 0 {
+		quo.Add(quo, big.NewInt(int64(num.Sign())))
+	}
+
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("parse money amount '%s': out of range", s)
+	}
+
+	return Amount(quo.Int64()), nil
+}
+
+// MustParse same as Parse but panics on error.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// String formats amount as decimal number without trailing zeros in fraction: 729.98, 729.5, 500.
+func (a Amount) String() string {
+	sign := ""
+	abs := uint64(a)
+	if a < 0 {
+		sign = "-"
+		abs = uint64(-a) // wraps correctly for math.MinInt64.
+	}
+
+	units, cents := abs/Scale, abs%Scale
+	if cents == 0 {
+		return sign + strconv.FormatUint(units, 10)
+	}
+
+	return strings.TrimRight(fmt.Sprintf("%s%d.%02d", sign, units, cents), "0")
+}
+
+// MarshalJSON writes amount as JSON number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON reads amount from JSON number. Quoted numbers are accepted as well.
+func (a *Amount) UnmarshalJSON(raw []byte) error {
+	str := string(raw)
+	if str == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		str = unquoted
+	}
+
+	parsed, err := Parse(str)
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner. NULL is scanned as zero amount, e.g. for SUM over empty set.
+func (a *Amount) Scan(src interface{}) error {
+	var parsed Amount
+	var err error
+
+	switch val := src.(type) {
+	case nil:
+		parsed = 0
+	case int64:
+		if val > math.MaxInt64/Scale || val < math.MinInt64/Scale {
+			return fmt.Errorf("scan money amount '%d': out of range", val)
+		}
+		parsed = Amount(val * Scale)
+	case float64:
+		parsed, err = Parse(strconv.FormatFloat(val, 'f', -1, 64))
+	case string:
+		parsed, err = Parse(val)
+	case []byte:
+		parsed, err = Parse(string(val))
+	default:
+		return fmt.Errorf("scan money amount: unsupported type '%T'", src)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. Amount is passed to database as exact decimal string.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
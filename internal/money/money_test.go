@@ -0,0 +1,108 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		want    Amount
+		wantErr bool
+	}{
+		{name: "integer", str: "500", want: New(500, 0)},
+		{name: "cents", str: "729.98", want: New(729, 98)},
+		{name: "one fractional digit", str: "729.5", want: New(729, 50)},
+		{name: "negative", str: "-0.01", want: -1},
+		{name: "exponent", str: "1e2", want: New(100, 0)},
+		{name: "rounding up", str: "0.005", want: 1},
+		{name: "rounding down", str: "0.0049", want: 0},
+		{name: "negative rounding", str: "-0.005", want: -1},
+		{name: "invalid", str: "abc", wantErr: true},
+		{name: "out of range", str: "1e30", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.str)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		want   string
+	}{
+		{name: "zero", amount: 0, want: "0"},
+		{name: "integer", amount: New(500, 0), want: "500"},
+		{name: "cents", amount: New(729, 98), want: "729.98"},
+		{name: "trailing zero", amount: New(729, 50), want: "729.5"},
+		{name: "small", amount: 5, want: "0.05"},
+		{name: "negative", amount: -New(10, 1), want: "-10.01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.String())
+		})
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type body struct {
+		Sum     Amount `json:"sum"`
+		Accrual Amount `json:"accrual,omitempty"`
+	}
+
+	raw, err := json.Marshal(body{Sum: New(729, 98)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sum":729.98}`, string(raw))
+
+	var parsed body
+	require.NoError(t, json.Unmarshal([]byte(`{"sum":729.98,"accrual":"0.1"}`), &parsed))
+	assert.Equal(t, New(729, 98), parsed.Sum)
+	assert.Equal(t, New(0, 10), parsed.Accrual)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"sum":true}`), &parsed))
+}
+
+func TestAmount_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Amount
+		wantErr bool
+	}{
+		{name: "null", src: nil, want: 0},
+		{name: "numeric string", src: "729.98", want: New(729, 98)},
+		{name: "numeric bytes", src: []byte("-30.00"), want: -New(30, 0)},
+		{name: "integer", src: int64(3), want: New(3, 0)},
+		{name: "float", src: 729.98, want: New(729, 98)},
+		{name: "unsupported", src: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Amount
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,28 +1,37 @@
 package orders
 
 import (
+	"github.com/erupshis/bonusbridge/internal/idempotency"
+	idempotencyStorage "github.com/erupshis/bonusbridge/internal/idempotency/storage"
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders/handlers"
 	"github.com/erupshis/bonusbridge/internal/orders/storage"
+	"github.com/erupshis/bonusbridge/internal/stream"
 	"github.com/go-chi/chi/v5"
 )
 
 type Controller struct {
-	storage storage.BaseOrdersStorage
+	storage         storage.BaseOrdersStorage
+	idempotencyStrg idempotencyStorage.BaseIdempotencyStorage
+	broker          *stream.Broker
 
 	log logger.BaseLogger
 }
 
-func CreateController(storage storage.BaseOrdersStorage, baseLogger logger.BaseLogger) Controller {
+func CreateController(storage storage.BaseOrdersStorage, idempotencyStrg idempotencyStorage.BaseIdempotencyStorage,
+	broker *stream.Broker, baseLogger logger.BaseLogger) Controller {
 	return Controller{
-		storage: storage,
-		log:     baseLogger,
+		storage:         storage,
+		idempotencyStrg: idempotencyStrg,
+		broker:          broker,
+		log:             baseLogger,
 	}
 }
 
 func (c *Controller) Route() *chi.Mux {
 	r := chi.NewRouter()
-	r.Post("/", handlers.AddOrder(c.storage, c.log))
+	r.With(idempotency.Middleware(c.idempotencyStrg, c.log)).Post("/", handlers.AddOrder(c.storage, c.log))
 	r.Get("/", handlers.GetOrders(c.storage, c.log))
+	r.Get("/stream", handlers.Stream(c.broker, c.log))
 	return r
 }
@@ -3,11 +3,16 @@ package data
 import (
 	"fmt"
 	"time"
+
+	"github.com/erupshis/bonusbridge/internal/money"
 )
 
 var ErrOrderWasAddedByAnotherUser = fmt.Errorf("order has already been added by another user")
 var ErrOrderWasAddedBefore = fmt.Errorf("order has already been added")
 
+// ErrAccrualSpent order's accrual can't be taken back, user has already withdrawn it.
+var ErrAccrualSpent = fmt.Errorf("order's accrual has already been withdrawn")
+
 const (
 	StatusNew = iota + 1
 	StatusProcessing
@@ -34,13 +39,24 @@ func GetOrderStatusID(statusStr string) int {
 	return res
 }
 
+// IsFinalStatus returns true if order accrual calculation is finished and order doesn't need polling anymore.
+func IsFinalStatus(statusID int) bool {
+	return statusID == StatusInvalid || statusID == StatusProcessed
+}
+
 //go:generate easyjson -all data.go
 type Order struct {
-	ID         int       `json:"-"`
-	Number     string    `json:"number"`
-	UserID     int64     `json:"-"`
-	BonusID    int64     `json:"-"`
-	Status     string    `json:"status"`
-	Accrual    float32   `json:"accrual,omitempty"`
-	UploadedAt time.Time `json:"uploaded_at"`
+	ID         int          `json:"-"`
+	Number     string       `json:"number"`
+	UserID     int64        `json:"-"`
+	BonusID    int64        `json:"-"`
+	Status     string       `json:"status"`
+	Accrual    money.Amount `json:"accrual,omitempty"`
+	UploadedAt time.Time    `json:"uploaded_at"`
+}
+
+// AccrualJob order's polling schedule in accrual system.
+type AccrualJob struct {
+	Order    Order
+	Attempts int
 }
@@ -41,7 +41,9 @@ func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalOrdersData(in *jl
 		case "status":
 			out.Status = string(in.String())
 		case "accrual":
-			out.Accrual = float32(in.Float32())
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Accrual).UnmarshalJSON(data))
+			}
 		case "uploaded_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.UploadedAt).UnmarshalJSON(data))
@@ -78,7 +80,7 @@ func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalOrdersData(out *j
 	if in.Accrual != 0 {
 		const prefix string = ",\"accrual\":"
 		out.RawString(prefix)
-		out.Float32(float32(in.Accrual))
+		out.Raw((in.Accrual).MarshalJSON())
 	}
 	{
 		const prefix string = ",\"uploaded_at\":"
@@ -111,3 +113,76 @@ func (v *Order) UnmarshalJSON(data []byte) error {
 func (v *Order) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalOrdersData(l, v)
 }
+func easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalOrdersData1(in *jlexer.Lexer, out *AccrualJob) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Order":
+			(out.Order).UnmarshalEasyJSON(in)
+		case "Attempts":
+			out.Attempts = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalOrdersData1(out *jwriter.Writer, in AccrualJob) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Order\":"
+		out.RawString(prefix[1:])
+		(in.Order).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"Attempts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Attempts))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AccrualJob) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalOrdersData1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AccrualJob) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson794297d0EncodeGithubComErupshisBonusbridgeInternalOrdersData1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AccrualJob) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalOrdersData1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AccrualJob) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson794297d0DecodeGithubComErupshisBonusbridgeInternalOrdersData1(l, v)
+}
@@ -0,0 +1,26 @@
+package data
+
+import (
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/keyset"
+)
+
+// CursorOf returns keyset cursor pointing to order.
+func CursorOf(order *Order) keyset.Cursor {
+	return keyset.Cursor{Time: order.UploadedAt, ID: int64(order.ID)}
+}
+
+// Filter orders selection conditions. Zero value fields are not applied.
+type Filter struct {
+	IDs          []int
+	Number       string
+	UserID       int64
+	Statuses     []string
+	UploadedFrom time.Time // UploadedFrom inclusive lower bound of upload time.
+	UploadedTo   time.Time // UploadedTo exclusive upper bound of upload time.
+
+	Sort  keyset.Sort
+	After *keyset.Cursor // After selects orders following cursor in Sort order, requires Sort.
+	Limit int
+}
@@ -2,13 +2,31 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/keyset"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
 	"github.com/erupshis/bonusbridge/internal/orders/storage"
 )
 
+const (
+	// maxPageLimit max count of orders on page.
+	maxPageLimit = 1000
+
+	// HeaderNextCursor response header with cursor of the next page.
+	HeaderNextCursor = "X-Next-Cursor"
+)
+
+// GetOrders returns user's orders, newest first by default.
+// Supported query parameters: limit, after(cursor), status(repeated or comma separated), uploaded_from, uploaded_to(RFC3339), sort(asc|desc).
+// If next page exists its cursor is returned in X-Next-Cursor and Link headers.
 func GetOrders(strg storage.BaseOrdersStorage, log logger.BaseLogger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID, err := auth.GetUserIDFromContext(r.Context())
@@ -18,7 +36,21 @@ func GetOrders(strg storage.BaseOrdersStorage, log logger.BaseLogger) http.Handl
 			return
 		}
 
-		orders, err := strg.GetOrders(r.Context(), map[string]interface{}{"user_id": userID})
+		filter, err := parseOrdersFilter(r.URL.Query())
+		if err != nil {
+			log.Info("[orders:handlers:GetOrders] failed to parse user's '%d' orders query: %v", userID, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filter.UserID = userID
+
+		limit := filter.Limit
+		if limit > 0 {
+			// extra order shows whether the next page exists.
+			filter.Limit++
+		}
+
+		orders, err := strg.GetOrders(r.Context(), filter)
 		if err != nil {
 			log.Info("[orders:handlers:GetOrders] failed to get user's '%d' orders: %v", userID, err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -31,6 +63,11 @@ func GetOrders(strg storage.BaseOrdersStorage, log logger.BaseLogger) http.Handl
 			return
 		}
 
+		if limit > 0 && len(orders) > limit {
+			orders = orders[:limit]
+			setNextPageHeaders(w, r, data.CursorOf(&orders[limit-1]))
+		}
+
 		respBody, err := json.Marshal(orders)
 		if err != nil {
 			log.Info("[orders:handlers:GetOrders] failed to marshal user '%d' orders: %v", userID, err)
@@ -47,3 +84,76 @@ func GetOrders(strg storage.BaseOrdersStorage, log logger.BaseLogger) http.Handl
 		}
 	}
 }
+
+// parseOrdersFilter converts request query parameters in orders filter.
+func parseOrdersFilter(query url.Values) (data.Filter, error) {
+	filter := data.Filter{Sort: keyset.SortNewestFirst}
+
+	if limit := query.Get("limit"); limit != "" {
+		val, err := strconv.Atoi(limit)
+		if err != nil || val < 1 || val > maxPageLimit {
+			return filter, fmt.Errorf("limit '%s' should be in range [1, %d]", limit, maxPageLimit)
+		}
+		filter.Limit = val
+	}
+
+	switch sort := keyset.Sort(query.Get("sort")); sort {
+	case "":
+	case keyset.SortNewestFirst, keyset.SortOldestFirst:
+		filter.Sort = sort
+	default:
+		return filter, fmt.Errorf("unknown sort '%s'", sort)
+	}
+
+	if after := query.Get("after"); after != "" {
+		cursor, err := keyset.ParseCursor(after)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = cursor
+	}
+
+	for _, statuses := range query["status"] {
+		for _, status := range strings.Split(statuses, ",") {
+			status = strings.ToUpper(strings.TrimSpace(status))
+			if data.GetOrderStatusID(status) == data.StatusUndefined {
+				return filter, fmt.Errorf("unknown status '%s'", status)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	var err error
+	if filter.UploadedFrom, err = parseTimeParam(query, "uploaded_from"); err != nil {
+		return filter, err
+	}
+	if filter.UploadedTo, err = parseTimeParam(query, "uploaded_to"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parseTimeParam parses optional RFC3339 time query parameter.
+func parseTimeParam(query url.Values, name string) (time.Time, error) {
+	val := query.Get(name)
+	if val == "" {
+		return time.Time{}, nil
+	}
+
+	res, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse '%s': %w", name, err)
+	}
+	return res, nil
+}
+
+// setNextPageHeaders adds next page cursor and link to it in response headers.
+func setNextPageHeaders(w http.ResponseWriter, r *http.Request, cursor keyset.Cursor) {
+	query := r.URL.Query()
+	query.Set("after", cursor.String())
+	next := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+
+	w.Header().Set(HeaderNextCursor, cursor.String())
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+}
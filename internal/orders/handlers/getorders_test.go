@@ -2,14 +2,18 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/keyset"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
 	"github.com/erupshis/bonusbridge/mocks"
 	"github.com/golang/mock/gomock"
@@ -28,7 +32,7 @@ func TestGetOrders(t *testing.T) {
 		{
 			Number:  "12344",
 			Status:  "NEW",
-			Accrual: 500,
+			Accrual: money.New(500, 0),
 		},
 	}
 
@@ -131,3 +135,89 @@ func TestGetOrders(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOrders_pagination(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uploadedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	orders := []data.Order{
+		{ID: 3, Number: "3", Status: "NEW", UploadedAt: uploadedAt},
+		{ID: 2, Number: "2", Status: "NEW", UploadedAt: uploadedAt},
+		{ID: 1, Number: "1", Status: "NEW", UploadedAt: uploadedAt},
+	}
+	cursor := data.CursorOf(&orders[1])
+
+	mockStorage := mocks.NewMockBaseOrdersStorage(ctrl)
+	gomock.InOrder(
+		// one order more than limit is requested to detect next page.
+		mockStorage.EXPECT().GetOrders(gomock.Any(), data.Filter{UserID: 1, Sort: keyset.SortNewestFirst, Limit: 3}).Return(orders, nil),
+		mockStorage.EXPECT().GetOrders(gomock.Any(), data.Filter{
+			UserID:       1,
+			Statuses:     []string{"NEW", "PROCESSED"},
+			UploadedFrom: uploadedAt,
+			Sort:         keyset.SortOldestFirst,
+			After:        &cursor,
+			Limit:        3,
+		}).Return(orders[:1], nil),
+	)
+
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		GetOrders(mockStorage, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+	ts := httptest.NewServer(handlerFunc)
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		query          string
+		wantStatusCode int
+		wantCount      int
+		wantNextCursor string
+	}{
+		{
+			name:           "first page",
+			query:          "limit=2",
+			wantStatusCode: http.StatusOK,
+			wantCount:      2,
+			wantNextCursor: cursor.String(),
+		},
+		{
+			name:           "last page with filters",
+			query:          "limit=2&status=new,processed&uploaded_from=2024-01-02T03:04:05Z&sort=asc&after=" + cursor.String(),
+			wantStatusCode: http.StatusOK,
+			wantCount:      1,
+		},
+		{name: "invalid limit", query: "limit=0", wantStatusCode: http.StatusBadRequest},
+		{name: "unknown status", query: "status=DONE", wantStatusCode: http.StatusBadRequest},
+		{name: "unknown sort", query: "sort=random", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid cursor", query: "after=!!!", wantStatusCode: http.StatusBadRequest},
+		{name: "invalid date", query: "uploaded_to=yesterday", wantStatusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ts.Client().Get(ts.URL + "/?" + tt.query)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantNextCursor, resp.Header.Get(HeaderNextCursor))
+			if tt.wantNextCursor != "" {
+				assert.Contains(t, resp.Header.Get("Link"), "after="+tt.wantNextCursor)
+			}
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var got []data.Order
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+			assert.Len(t, got, tt.wantCount)
+		})
+	}
+}
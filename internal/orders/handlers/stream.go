@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/stream"
+)
+
+// keepAliveInterval interval of comments sent to keep idle connection open through proxies.
+const keepAliveInterval = 15 * time.Second
+
+// Stream keeps Server-Sent Events connection and pushes user's orders and balance events.
+// Connection is closed by client or on server stop.
+func Stream(broker *stream.Broker, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := auth.GetUserIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[orders:handlers:Stream] failed to extract userID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			log.Info("[orders:handlers:Stream] response writer doesn't support streaming")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		events, unsubscribe := broker.Subscribe(userID)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(keepAliveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					log.Info("[orders:handlers:Stream] stream of user '%d' is closed by server", userID)
+					return
+				}
+
+				if err = stream.WriteEvent(w, event); err != nil {
+					log.Info("[orders:handlers:Stream] failed to send event to user '%d': %v", userID, err)
+					return
+				}
+			case <-ticker.C:
+				if _, err = w.Write([]byte(": keep-alive\n\n")); err != nil {
+					log.Info("[orders:handlers:Stream] failed to send keep-alive to user '%d': %v", userID, err)
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
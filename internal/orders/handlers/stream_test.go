@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	broker := stream.CreateBroker(4, log)
+
+	subscribed := make(chan struct{})
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		close(subscribed)
+		Stream(broker, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+
+	t.Run("without userID in context", func(t *testing.T) {
+		ts := httptest.NewServer(Stream(broker, log))
+		defer ts.Close()
+
+		resp, err := ts.Client().Get(ts.URL)
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("events are pushed till broker is closed", func(t *testing.T) {
+		ts := httptest.NewServer(handlerFunc)
+		defer ts.Close()
+
+		resp, err := ts.Client().Get(ts.URL)
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		<-subscribed
+		// response headers are flushed after subscription, so events published now are not lost.
+		require.NoError(t, broker.Publish(context.Background(), &data.Event{ID: 5, UserID: 2, Type: data.TypeBonusesWithdrawn}))
+		require.NoError(t, broker.Publish(context.Background(), &data.Event{ID: 6, UserID: 1, Type: data.TypeOrderStatusChanged}))
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "id: 6\n", line)
+
+		line, err = reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "event: order.status_changed\n", line)
+
+		broker.Close()
+		done := make(chan struct{})
+		go func() {
+			for {
+				if _, err := reader.ReadString('\n'); err != nil {
+					close(done)
+					return
+				}
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "stream wasn't closed")
+		}
+	})
+}
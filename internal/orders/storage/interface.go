@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/orders/data"
 )
@@ -10,5 +11,11 @@ import (
 type BaseOrdersStorage interface {
 	AddOrder(ctx context.Context, number string, userID int64) error
 	UpdateOrder(ctx context.Context, order *data.Order) error
-	GetOrders(ctx context.Context, filter map[string]interface{}) ([]data.Order, error)
+	UpdateOrders(ctx context.Context, orders []data.Order) error
+	ResetOrder(ctx context.Context, order *data.Order) error
+	GetOrders(ctx context.Context, filter data.Filter) ([]data.Order, error)
+
+	ClaimAccrualJobs(ctx context.Context, limit int, lease time.Duration) ([]data.AccrualJob, error)
+	RescheduleAccrualJob(ctx context.Context, job *data.AccrualJob, delay time.Duration) error
+	DeleteAccrualJob(ctx context.Context, orderID int64) error
 }
@@ -0,0 +1,336 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+// Order's accrual is kept equal to the count of its bonus record like in database.
+type memoryManager struct {
+	store *memory.Store
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation keeping orders in memory.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseOrdersManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+func (p *memoryManager) AddOrder(_ context.Context, number string, userID int64) (int64, error) {
+	p.log.Info("[orders:memoryManager:AddOrder] add order '%s' for userID '%d'", number, userID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for _, order := range p.store.Orders {
+		if order.Number != number {
+			continue
+		}
+
+		if order.UserID == userID {
+			return int64(order.ID), fmt.Errorf("add order in storage: %w", data.ErrOrderWasAddedBefore)
+		}
+		return int64(order.ID), fmt.Errorf("add order in storage: %w", data.ErrOrderWasAddedByAnotherUser)
+	}
+
+	bonus := bonusesData.Bonus{
+		ID:     p.store.NextID("bonuses"),
+		UserID: userID,
+		Order:  number,
+	}
+	p.store.Bonuses = append(p.store.Bonuses, bonus)
+
+	newOrder := data.Order{
+		ID:         int(p.store.NextID("orders")),
+		Number:     number,
+		UserID:     userID,
+		BonusID:    bonus.ID,
+		Status:     "NEW",
+		UploadedAt: time.Now(),
+	}
+	p.store.Orders = append(p.store.Orders, newOrder)
+	p.addAccrualJob(int64(newOrder.ID), newOrder.UploadedAt)
+
+	return int64(newOrder.ID), nil
+}
+
+func (p *memoryManager) UpdateOrder(ctx context.Context, order *data.Order) error {
+	return p.UpdateOrders(ctx, []data.Order{*order})
+}
+
+// UpdateOrders saves orders statuses and accruals atomically. Orders without status or accrual change are skipped.
+func (p *memoryManager) UpdateOrders(_ context.Context, ordersToUpdate []data.Order) error {
+	p.log.Info("[orders:memoryManager:UpdateOrders] update '%d' orders", len(ordersToUpdate))
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	// the latest data wins if order is met several times.
+	latestOrders := make(map[int]data.Order, len(ordersToUpdate))
+	orderedIDs := make([]int, 0, len(ordersToUpdate))
+	for _, order := range ordersToUpdate {
+		if _, ok := latestOrders[order.ID]; !ok {
+			orderedIDs = append(orderedIDs, order.ID)
+		}
+		latestOrders[order.ID] = order
+	}
+
+	// nothing is changed if any order is missing.
+	indexes := make(map[int]int, len(orderedIDs))
+	for _, orderID := range orderedIDs {
+		i := p.findOrder(orderID)
+		if i == -1 {
+			return fmt.Errorf("update orders in memory: order '%d' is missing", orderID)
+		}
+		indexes[orderID] = i
+	}
+
+	for _, orderID := range orderedIDs {
+		order := latestOrders[orderID]
+		stored := &p.store.Orders[indexes[orderID]]
+		prevOrder := *stored
+
+		if prevOrder.Status != order.Status {
+			stored.Status = order.Status
+
+			// orders with final status leave polling schedule, reopened ones are returned in it.
+			statusID := data.GetOrderStatusID(order.Status)
+			if data.IsFinalStatus(statusID) {
+				p.deleteAccrualJob(int64(orderID))
+			} else if data.IsFinalStatus(data.GetOrderStatusID(prevOrder.Status)) {
+				p.addAccrualJob(int64(orderID), time.Now())
+			}
+
+			event, err := outboxData.CreateEvent(prevOrder.UserID, outboxData.TypeOrderStatusChanged, &outboxData.OrderStatusChanged{
+				Number:         prevOrder.Number,
+				PreviousStatus: prevOrder.Status,
+				Status:         order.Status,
+				Accrual:        order.Accrual,
+			})
+			if err != nil {
+				return fmt.Errorf("update orders in memory: %w", err)
+			}
+			p.store.AddEvent(event)
+		}
+
+		if accrualDif := order.Accrual - prevOrder.Accrual; accrualDif != 0 {
+			stored.Accrual = order.Accrual
+			if i := p.store.FindBonus(prevOrder.BonusID); i != -1 {
+				p.store.Bonuses[i].Count = order.Accrual
+			}
+			p.store.AddBalanceDelta(&bonusesData.Balance{UserID: prevOrder.UserID, Current: accrualDif, Accrued: accrualDif})
+		}
+	}
+
+	return nil
+}
+
+// ResetOrder returns order in NEW status without accrual and schedules its polling in accrual system right away.
+// Fails with data.ErrAccrualSpent if user's balance becomes negative without order's accrual.
+func (p *memoryManager) ResetOrder(_ context.Context, order *data.Order) error {
+	p.log.Info("[orders:memoryManager:ResetOrder] reset order id '%d'", order.ID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	i := p.findOrder(order.ID)
+	if i == -1 {
+		return fmt.Errorf("reset order in memory: order '%d' is missing", order.ID)
+	}
+
+	stored := &p.store.Orders[i]
+	if p.store.Balances[stored.UserID].Current < stored.Accrual {
+		return fmt.Errorf("reset order in memory: %w", data.ErrAccrualSpent)
+	}
+
+	if stored.Status != "NEW" {
+		event, err := outboxData.CreateEvent(stored.UserID, outboxData.TypeOrderStatusChanged, &outboxData.OrderStatusChanged{
+			Number:         stored.Number,
+			PreviousStatus: stored.Status,
+			Status:         "NEW",
+		})
+		if err != nil {
+			return fmt.Errorf("reset order in memory: %w", err)
+		}
+		p.store.AddEvent(event)
+		stored.Status = "NEW"
+	}
+
+	if stored.Accrual != 0 {
+		if j := p.store.FindBonus(stored.BonusID); j != -1 {
+			p.store.Bonuses[j].Count = 0
+		}
+		p.store.AddBalanceDelta(&bonusesData.Balance{UserID: stored.UserID, Current: -stored.Accrual, Accrued: -stored.Accrual})
+		stored.Accrual = 0
+	}
+
+	p.deleteAccrualJob(int64(order.ID))
+	p.addAccrualJob(int64(order.ID), time.Now())
+	return nil
+}
+
+func (p *memoryManager) GetOrders(_ context.Context, filter data.Filter) ([]data.Order, error) {
+	p.log.Info("[orders:memoryManager:GetOrders] select orders with filter '%+v'", filter)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Order
+	for _, order := range p.store.Orders {
+		if matchOrder(&order, &filter) {
+			res = append(res, order)
+		}
+	}
+
+	// keyset pagination: orders with the same upload time are ordered by id.
+	switch filter.Sort {
+	case keyset.SortNewestFirst:
+		sort.SliceStable(res, func(i, j int) bool { return isOrderBefore(&res[j], &res[i]) })
+	case keyset.SortOldestFirst:
+		sort.SliceStable(res, func(i, j int) bool { return isOrderBefore(&res[i], &res[j]) })
+	}
+
+	if filter.Limit > 0 && len(res) > filter.Limit {
+		res = res[:filter.Limit]
+	}
+	return res, nil
+}
+
+// ClaimAccrualJobs selects up to limit orders which polling time has come and postpones their next attempt for lease.
+func (p *memoryManager) ClaimAccrualJobs(_ context.Context, limit int, lease time.Duration) ([]data.AccrualJob, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	now := time.Now()
+	var due []*memory.AccrualJob
+	for i := range p.store.AccrualJobs {
+		if !p.store.AccrualJobs[i].NextAttemptAt.After(now) {
+			due = append(due, &p.store.AccrualJobs[i])
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+
+	var res []data.AccrualJob
+	for _, job := range due {
+		if len(res) == limit {
+			break
+		}
+
+		job.NextAttemptAt = now.Add(lease)
+		res = append(res, data.AccrualJob{
+			Order:    p.store.Orders[p.findOrder(int(job.OrderID))],
+			Attempts: job.Attempts,
+		})
+	}
+
+	p.log.Info("[orders:memoryManager:ClaimAccrualJobs] '%d' jobs claimed", len(res))
+	return res, nil
+}
+
+// RescheduleAccrualJob increases job's attempts counter and postpones order's next polling for delay.
+func (p *memoryManager) RescheduleAccrualJob(_ context.Context, job *data.AccrualJob, delay time.Duration) error {
+	p.log.Info("[orders:memoryManager:RescheduleAccrualJob] reschedule order id '%d'", job.Order.ID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.AccrualJobs {
+		if stored := &p.store.AccrualJobs[i]; stored.OrderID == int64(job.Order.ID) {
+			stored.Attempts = job.Attempts + 1
+			stored.NextAttemptAt = time.Now().Add(delay)
+			break
+		}
+	}
+	return nil
+}
+
+// DeleteAccrualJob stops order's polling in accrual system.
+func (p *memoryManager) DeleteAccrualJob(_ context.Context, orderID int64) error {
+	p.log.Info("[orders:memoryManager:DeleteAccrualJob] delete job of order id '%d'", orderID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	p.deleteAccrualJob(orderID)
+	return nil
+}
+
+// findOrder returns index of order by id or -1. Must be called under store lock.
+func (p *memoryManager) findOrder(id int) int {
+	for i := range p.store.Orders {
+		if p.store.Orders[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// addAccrualJob schedules order's polling if it isn't scheduled yet. Must be called under store lock.
+func (p *memoryManager) addAccrualJob(orderID int64, nextAttemptAt time.Time) {
+	for _, job := range p.store.AccrualJobs {
+		if job.OrderID == orderID {
+			return
+		}
+	}
+	p.store.AccrualJobs = append(p.store.AccrualJobs, memory.AccrualJob{OrderID: orderID, NextAttemptAt: nextAttemptAt})
+}
+
+// deleteAccrualJob must be called under store lock.
+func (p *memoryManager) deleteAccrualJob(orderID int64) {
+	for i := range p.store.AccrualJobs {
+		if p.store.AccrualJobs[i].OrderID == orderID {
+			p.store.AccrualJobs = append(p.store.AccrualJobs[:i], p.store.AccrualJobs[i+1:]...)
+			return
+		}
+	}
+}
+
+// matchOrder checks order against filter conditions the same way as database select does.
+func matchOrder(order *data.Order, filter *data.Filter) bool {
+	if len(filter.IDs) != 0 && !slices.Contains(filter.IDs, order.ID) {
+		return false
+	}
+	if filter.Number != "" && order.Number != filter.Number {
+		return false
+	}
+	if filter.UserID != 0 && order.UserID != filter.UserID {
+		return false
+	}
+	if len(filter.Statuses) != 0 && !slices.Contains(filter.Statuses, order.Status) {
+		return false
+	}
+	if !filter.UploadedFrom.IsZero() && order.UploadedAt.Before(filter.UploadedFrom) {
+		return false
+	}
+	if !filter.UploadedTo.IsZero() && !order.UploadedAt.Before(filter.UploadedTo) {
+		return false
+	}
+
+	if filter.After != nil {
+		after := data.Order{ID: int(filter.After.ID), UploadedAt: filter.After.Time}
+		switch filter.Sort {
+		case keyset.SortNewestFirst:
+			return isOrderBefore(order, &after)
+		case keyset.SortOldestFirst:
+			return isOrderBefore(&after, order)
+		}
+	}
+	return true
+}
+
+// isOrderBefore compares orders by (uploaded_at, id).
+func isOrderBefore(lhs *data.Order, rhs *data.Order) bool {
+	if !lhs.UploadedAt.Equal(rhs.UploadedAt) {
+		return lhs.UploadedAt.Before(rhs.UploadedAt)
+	}
+	return lhs.ID < rhs.ID
+}
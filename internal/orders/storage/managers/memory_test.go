@@ -0,0 +1,113 @@
+package managers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
+	bonusesManagers "github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryManager_AddOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testAddOrder(t, CreateInMemory(memory.Create(), log), 1, 2)
+}
+
+func TestMemoryManager_UpdateOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	store := memory.Create()
+	testUpdateOrders(t, CreateInMemory(store, log), 1)
+
+	// accrual is added to balance and ledger, every status change is published.
+	assert.Equal(t, bonusesData.Balance{UserID: 1, Current: money.New(500, 0), Accrued: money.New(500, 0)}, store.Balances[1])
+	assert.Equal(t, store.LedgerBalances()[1], store.Balances[1])
+	require.Len(t, store.Events, 2)
+	assert.Equal(t, outboxData.TypeOrderStatusChanged, store.Events[0].Type)
+
+	// orders with final statuses aren't polled anymore.
+	assert.Empty(t, store.AccrualJobs)
+}
+
+func TestMemoryManager_StatusChangedEventUser(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	store := memory.Create()
+	testStatusChangedEventUser(t, CreateInMemory(store, log), 1, func(userID int64) []outboxData.Event {
+		var res []outboxData.Event
+		for _, event := range store.Events {
+			if event.UserID == userID {
+				res = append(res, event.Event)
+			}
+		}
+		return res
+	})
+}
+
+func TestMemoryManager_ResetOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	store := memory.Create()
+	testResetOrder(t, CreateInMemory(store, log), bonusesManagers.CreateInMemory(store, log), 1)
+	assert.Equal(t, store.LedgerBalances()[1], store.Balances[1])
+}
+
+func TestMemoryManager_GetOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testGetOrders(t, CreateInMemory(memory.Create(), log), 1)
+}
+
+func TestMemoryManager_GetOrdersInTimeZone(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	testGetOrdersInTimeZone(t, CreateInMemory(memory.Create(), log), 1)
+}
+
+func TestMemoryManager_AccrualJobs(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctx := context.Background()
+	manager := CreateInMemory(memory.Create(), log)
+	for _, number := range []string{"12345678903", "2377225624", "4561261212345467"} {
+		_, err := manager.AddOrder(ctx, number, 1)
+		require.NoError(t, err)
+	}
+
+	jobs, err := manager.ClaimAccrualJobs(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "12345678903", jobs[0].Order.Number)
+	assert.Equal(t, "2377225624", jobs[1].Order.Number)
+
+	// claimed jobs are skipped till lease expires or job is rescheduled.
+	require.NoError(t, manager.RescheduleAccrualJob(ctx, &jobs[0], 0))
+	require.NoError(t, manager.DeleteAccrualJob(ctx, int64(jobs[1].Order.ID)))
+
+	jobs, err = manager.ClaimAccrualJobs(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, 0, jobs[0].Attempts)
+	assert.Equal(t, "4561261212345467", jobs[0].Order.Number)
+	assert.Equal(t, "12345678903", jobs[1].Order.Number)
+	assert.Equal(t, 1, jobs[1].Attempts)
+
+	jobs, err = manager.ClaimAccrualJobs(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
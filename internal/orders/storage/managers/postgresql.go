@@ -3,15 +3,23 @@ package managers
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"sort"
 	"time"
 
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
 	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries/accrualjobs"
+	"github.com/erupshis/bonusbridge/internal/db/queries/balances"
 	"github.com/erupshis/bonusbridge/internal/db/queries/bonuses"
 	"github.com/erupshis/bonusbridge/internal/db/queries/orders"
+	"github.com/erupshis/bonusbridge/internal/db/queries/outbox"
 	"github.com/erupshis/bonusbridge/internal/helpers"
 	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/money"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/jackc/pgx/v4/stdlib"
 )
@@ -39,7 +47,7 @@ func (p *manager) AddOrder(ctx context.Context, number string, userID int64) (in
 		return -1, fmt.Errorf(errMsg, err)
 	}
 
-	ordersSelected, err := orders.Select(ctx, tx, map[string]interface{}{"number": number}, p.log)
+	ordersSelected, err := orders.Select(ctx, tx, data.Filter{Number: number}, p.log)
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return -1, fmt.Errorf(errMsg, err)
@@ -73,6 +81,11 @@ func (p *manager) AddOrder(ctx context.Context, number string, userID int64) (in
 		return -1, fmt.Errorf(errMsg, err)
 	}
 
+	if err = accrualjobs.Insert(ctx, tx, id, newOrder.UploadedAt, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return -1, fmt.Errorf(errMsg, err)
@@ -83,46 +96,238 @@ func (p *manager) AddOrder(ctx context.Context, number string, userID int64) (in
 }
 
 func (p *manager) UpdateOrder(ctx context.Context, order *data.Order) error {
-	p.log.Info("[orders:manager:UpdateOrder] start transaction for order data '%v'", *order)
-	errMsg := "update order in db: %w"
+	return p.UpdateOrders(ctx, []data.Order{*order})
+}
+
+// UpdateOrders saves orders statuses and accruals in single transaction. Orders without status or accrual change are skipped.
+func (p *manager) UpdateOrders(ctx context.Context, ordersToUpdate []data.Order) error {
+	p.log.Info("[orders:manager:UpdateOrders] start transaction for '%d' orders", len(ordersToUpdate))
+	errMsg := "update orders in db: %w"
+	if len(ordersToUpdate) == 0 {
+		return nil
+	}
+
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf(errMsg, err)
 	}
 
-	ordersValuesToUpdate := map[string]interface{}{
-		"status_id": data.GetOrderStatusID(order.Status),
+	statusesCount, accrualsCount, err := p.updateOrders(ctx, tx, ordersToUpdate)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if statusesCount == 0 && accrualsCount == 0 {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		p.log.Info("[orders:manager:UpdateOrders] orders are not changed")
+		return nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[orders:manager:UpdateOrders] transaction successful, '%d' statuses and '%d' accruals updated", statusesCount, accrualsCount)
+	return nil
+}
+
+// ResetOrder returns order in NEW status without accrual and schedules its polling in accrual system right away.
+// Fails with data.ErrAccrualSpent if user's balance becomes negative without order's accrual.
+func (p *manager) ResetOrder(ctx context.Context, order *data.Order) error {
+	p.log.Info("[orders:manager:ResetOrder] start transaction for order id '%d'", order.ID)
+	errMsg := "reset order in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
 	}
-	if err = orders.UpdateByID(ctx, tx, int64(order.ID), ordersValuesToUpdate, p.log); err != nil {
+
+	reset := data.Order{ID: order.ID, Status: "NEW"}
+	if _, _, err = p.updateOrders(ctx, tx, []data.Order{reset}); err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return fmt.Errorf(errMsg, err)
 	}
 
-	bonusesValuesToUpdate := map[string]interface{}{
-		"count": order.Accrual,
+	stored, err := orders.Select(ctx, tx, data.Filter{IDs: []int{order.ID}}, p.log)
+	if err == nil && len(stored) == 0 {
+		err = fmt.Errorf("order '%d' is missing", order.ID)
 	}
-	if err = bonuses.UpdateByID(ctx, tx, order.BonusID, bonusesValuesToUpdate, p.log); err != nil {
+	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return fmt.Errorf(errMsg, err)
 	}
-	err = tx.Commit()
+
+	// owner's balance is locked by update, so withdrawals can't spend it concurrently.
+	balance, err := balances.SelectByUserID(ctx, tx, stored[0].UserID, p.log)
 	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if balance.Current < 0 {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, data.ErrAccrualSpent)
+	}
+
+	if err = accrualjobs.Upsert(ctx, tx, int64(order.ID), time.Now(), p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return fmt.Errorf(errMsg, err)
 	}
 
-	p.log.Info("[orders:manager:UpdateOrder] transaction successful")
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[orders:manager:ResetOrder] transaction successful")
+	return nil
+}
+
+// updateOrders saves orders statuses and accruals within transaction. Returns counts of updated statuses and accruals.
+func (p *manager) updateOrders(ctx context.Context, tx *sql.Tx, ordersToUpdate []data.Order) (int, int, error) {
+	// the latest data wins if order is met several times.
+	latestOrders := make(map[int]data.Order, len(ordersToUpdate))
+	orderedIDs := make([]int, 0, len(ordersToUpdate))
+	for _, order := range ordersToUpdate {
+		if _, ok := latestOrders[order.ID]; !ok {
+			orderedIDs = append(orderedIDs, order.ID)
+		}
+		latestOrders[order.ID] = order
+	}
+
+	// accrual change shifts materialized balance of stored orders owners, so concurrent balance changes of the users
+	// have to wait. Updated orders may not carry user, owners are taken from stored orders which are selected again
+	// under locks to get changes committed while waiting.
+	owners, err := orders.Select(ctx, tx, data.Filter{IDs: orderedIDs}, p.log)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err = p.lockUsersBalances(ctx, tx, owners); err != nil {
+		return 0, 0, err
+	}
+
+	prevOrders, err := orders.Select(ctx, tx, data.Filter{IDs: orderedIDs}, p.log)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	prevOrdersByID := make(map[int]data.Order, len(prevOrders))
+	for _, prevOrder := range prevOrders {
+		prevOrdersByID[prevOrder.ID] = prevOrder
+	}
+
+	statuses := make(map[int64]int)
+	counts := make(map[int64]money.Amount)
+	balanceDeltas := make(map[int64]money.Amount)
+	for _, orderID := range orderedIDs {
+		order := latestOrders[orderID]
+		prevOrder, ok := prevOrdersByID[orderID]
+		if !ok {
+			return 0, 0, fmt.Errorf("order '%d' is missing", orderID)
+		}
+
+		if prevOrder.Status == order.Status && prevOrder.Accrual == order.Accrual {
+			continue
+		}
+
+		if prevOrder.Status != order.Status {
+			statusID := data.GetOrderStatusID(order.Status)
+			statuses[int64(orderID)] = statusID
+
+			// orders with final status leave polling schedule, reopened ones are returned in it.
+			if data.IsFinalStatus(statusID) {
+				err = accrualjobs.DeleteByOrderID(ctx, tx, int64(orderID), p.log)
+			} else if data.IsFinalStatus(data.GetOrderStatusID(prevOrder.Status)) {
+				err = accrualjobs.Insert(ctx, tx, int64(orderID), time.Now(), p.log)
+			}
+			if err == nil {
+				err = p.addStatusChangedEvent(ctx, tx, &prevOrder, &order)
+			}
+			if err != nil {
+				return 0, 0, err
+			}
+		}
+
+		if accrualDif := order.Accrual - prevOrder.Accrual; accrualDif != 0 {
+			counts[prevOrder.BonusID] = order.Accrual
+			balanceDeltas[prevOrder.UserID] += accrualDif
+		}
+	}
+
+	if len(statuses) == 0 && len(counts) == 0 {
+		return 0, 0, nil
+	}
+
+	if err = orders.UpdateStatuses(ctx, tx, statuses, p.log); err != nil {
+		return 0, 0, err
+	}
+
+	if err = bonuses.UpdateCounts(ctx, tx, counts, p.log); err != nil {
+		return 0, 0, err
+	}
+
+	for userID, accrualDif := range balanceDeltas {
+		if accrualDif == 0 {
+			continue
+		}
+
+		delta := &bonusesData.Balance{UserID: userID, Current: accrualDif, Accrued: accrualDif}
+		if err = balances.AddDelta(ctx, tx, delta, p.log); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return len(statuses), len(counts), nil
+}
+
+// lockUsersBalances takes balance locks of orders owners in ascending users order to avoid deadlocks between batches.
+func (p *manager) lockUsersBalances(ctx context.Context, tx *sql.Tx, ownedOrders []data.Order) error {
+	usersIDs := make([]int64, 0, len(ownedOrders))
+	seen := make(map[int64]struct{}, len(ownedOrders))
+	for _, order := range ownedOrders {
+		if _, ok := seen[order.UserID]; ok {
+			continue
+		}
+		seen[order.UserID] = struct{}{}
+		usersIDs = append(usersIDs, order.UserID)
+	}
+	sort.Slice(usersIDs, func(i, j int) bool { return usersIDs[i] < usersIDs[j] })
+
+	for _, userID := range usersIDs {
+		if err := bonuses.LockUserBalance(ctx, tx, p.Dialect, userID, p.log); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (p *manager) GetOrders(ctx context.Context, filters map[string]interface{}) ([]data.Order, error) {
-	p.log.Info("[orders:manager:GetOrders] start transaction with filters '%v'", filters)
+// addStatusChangedEvent writes order status change event into outbox within order update transaction.
+// Event is addressed to the owner of stored order, updated order may not carry user.
+func (p *manager) addStatusChangedEvent(ctx context.Context, tx *sql.Tx, prevOrder *data.Order, order *data.Order) error {
+	event, err := outboxData.CreateEvent(prevOrder.UserID, outboxData.TypeOrderStatusChanged, &outboxData.OrderStatusChanged{
+		Number:         prevOrder.Number,
+		PreviousStatus: prevOrder.Status,
+		Status:         order.Status,
+		Accrual:        order.Accrual,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = outbox.Insert(ctx, tx, event, p.log)
+	return err
+}
+
+func (p *manager) GetOrders(ctx context.Context, filter data.Filter) ([]data.Order, error) {
+	p.log.Info("[orders:manager:GetOrders] start transaction with filter '%+v'", filter)
 	errMsg := "select orders in db: %w"
 	tx, err := p.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf(errMsg, err)
 	}
 
-	ordersSelected, err := orders.Select(ctx, tx, filters, p.log)
+	ordersSelected, err := orders.Select(ctx, tx, filter, p.log)
 	if err != nil {
 		helpers.ExecuteWithLogError(tx.Rollback, p.log)
 		return nil, fmt.Errorf(errMsg, err)
@@ -136,3 +341,107 @@ func (p *manager) GetOrders(ctx context.Context, filters map[string]interface{})
 	p.log.Info("[orders:manager:GetOrders] transaction successful")
 	return ordersSelected, nil
 }
+
+// ClaimAccrualJobs selects up to limit orders which polling time has come and postpones their next attempt for lease.
+// Claimed orders are skipped by other instances till the lease expires or job is rescheduled.
+func (p *manager) ClaimAccrualJobs(ctx context.Context, limit int, lease time.Duration) ([]data.AccrualJob, error) {
+	p.log.Info("[orders:manager:ClaimAccrualJobs] start transaction")
+	errMsg := "claim accrual jobs in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	now := time.Now()
+	jobs, err := accrualjobs.SelectDue(ctx, tx, p.Dialect, now, limit, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if len(jobs) == 0 {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, nil
+	}
+
+	leaseEnd := now.Add(lease)
+	ordersIDs := make([]int, 0, len(jobs))
+	for i := range jobs {
+		if err = accrualjobs.UpdateByOrderID(ctx, tx, int64(jobs[i].Order.ID), accrualjobs.Values{NextAttemptAt: &leaseEnd}, p.log); err != nil {
+			helpers.ExecuteWithLogError(tx.Rollback, p.log)
+			return nil, fmt.Errorf(errMsg, err)
+		}
+
+		ordersIDs = append(ordersIDs, jobs[i].Order.ID)
+	}
+
+	ordersSelected, err := orders.Select(ctx, tx, data.Filter{IDs: ordersIDs}, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	ordersByID := make(map[int]data.Order, len(ordersSelected))
+	for _, order := range ordersSelected {
+		ordersByID[order.ID] = order
+	}
+	for i := range jobs {
+		jobs[i].Order = ordersByID[jobs[i].Order.ID]
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[orders:manager:ClaimAccrualJobs] transaction successful, '%d' jobs claimed", len(jobs))
+	return jobs, nil
+}
+
+// RescheduleAccrualJob increases job's attempts counter and postpones order's next polling for delay.
+func (p *manager) RescheduleAccrualJob(ctx context.Context, job *data.AccrualJob, delay time.Duration) error {
+	p.log.Info("[orders:manager:RescheduleAccrualJob] start transaction for order id '%d'", job.Order.ID)
+	errMsg := "reschedule accrual job in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	attempts, nextAttemptAt := job.Attempts+1, time.Now().Add(delay)
+	values := accrualjobs.Values{
+		Attempts:      &attempts,
+		NextAttemptAt: &nextAttemptAt,
+	}
+	if err = accrualjobs.UpdateByOrderID(ctx, tx, int64(job.Order.ID), values, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[orders:manager:RescheduleAccrualJob] transaction successful")
+	return nil
+}
+
+// DeleteAccrualJob stops order's polling in accrual system.
+func (p *manager) DeleteAccrualJob(ctx context.Context, orderID int64) error {
+	p.log.Info("[orders:manager:DeleteAccrualJob] start transaction for order id '%d'", orderID)
+	errMsg := "delete accrual job in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = accrualjobs.DeleteByOrderID(ctx, tx, orderID, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[orders:manager:DeleteAccrualJob] transaction successful")
+	return nil
+}
@@ -0,0 +1,99 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	usersData "github.com/erupshis/bonusbridge/internal/auth/users/data"
+	usersManagers "github.com/erupshis/bonusbridge/internal/auth/users/managers"
+	bonusesManagers "github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/dbtest"
+	"github.com/erupshis/bonusbridge/internal/db/queries/outbox"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestUser(t *testing.T, conn *db.Conn, prefix string, log logger.BaseLogger) int64 {
+	t.Helper()
+
+	login := fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	userID, err := usersManagers.Create(conn, log).AddUser(context.Background(), &usersData.User{Login: login, Password: "p", Role: usersData.RoleUser})
+	require.NoError(t, err)
+	return userID
+}
+
+// selectTestEvents returns unpublished outbox events of user.
+func selectTestEvents(t *testing.T, conn *db.Conn, userID int64, log logger.BaseLogger) []outboxData.Event {
+	t.Helper()
+
+	tx, err := conn.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	events, err := outbox.SelectUnpublished(context.Background(), tx, conn.Dialect, time.Now(), 10000, log)
+	require.NoError(t, err)
+
+	var res []outboxData.Event
+	for _, event := range events {
+		if event.UserID == userID {
+			res = append(res, event)
+		}
+	}
+	return res
+}
+
+func TestManager_AddOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testAddOrder(t, Create(conn, log), createTestUser(t, conn, "add_order", log), createTestUser(t, conn, "add_order_another", log))
+}
+
+func TestManager_UpdateOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testUpdateOrders(t, Create(conn, log), createTestUser(t, conn, "update_orders", log))
+}
+
+func TestManager_StatusChangedEventUser(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testStatusChangedEventUser(t, Create(conn, log), createTestUser(t, conn, "event_user", log), func(userID int64) []outboxData.Event {
+		return selectTestEvents(t, conn, userID, log)
+	})
+}
+
+func TestManager_ResetOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testResetOrder(t, Create(conn, log), bonusesManagers.Create(conn, log), createTestUser(t, conn, "reset_order", log))
+}
+
+func TestManager_GetOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testGetOrders(t, Create(conn, log), createTestUser(t, conn, "get_orders", log))
+}
+
+func TestManager_GetOrdersInTimeZone(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+	testGetOrdersInTimeZone(t, Create(conn, log), createTestUser(t, conn, "get_orders_tz", log))
+}
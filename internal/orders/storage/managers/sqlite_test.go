@@ -0,0 +1,60 @@
+package managers
+
+import (
+	"testing"
+
+	bonusesManagers "github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
+	"github.com/erupshis/bonusbridge/internal/db/dbtest"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+func TestSQLiteManager_AddOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testAddOrder(t, Create(conn, log), createTestUser(t, conn, "add_order", log), createTestUser(t, conn, "add_order_another", log))
+}
+
+func TestSQLiteManager_UpdateOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testUpdateOrders(t, Create(conn, log), createTestUser(t, conn, "update_orders", log))
+}
+
+func TestSQLiteManager_StatusChangedEventUser(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testStatusChangedEventUser(t, Create(conn, log), createTestUser(t, conn, "event_user", log), func(userID int64) []outboxData.Event {
+		return selectTestEvents(t, conn, userID, log)
+	})
+}
+
+func TestSQLiteManager_ResetOrder(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testResetOrder(t, Create(conn, log), bonusesManagers.Create(conn, log), createTestUser(t, conn, "reset_order", log))
+}
+
+func TestSQLiteManager_GetOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testGetOrders(t, Create(conn, log), createTestUser(t, conn, "get_orders", log))
+}
+
+func TestSQLiteManager_GetOrdersInTimeZone(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreateSQLiteConnection(t)
+	testGetOrdersInTimeZone(t, Create(conn, log), createTestUser(t, conn, "get_orders_tz", log))
+}
@@ -0,0 +1,226 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	bonusesData "github.com/erupshis/bonusbridge/internal/bonuses/data"
+	bonusesManagers "github.com/erupshis/bonusbridge/internal/bonuses/storage/managers"
+	"github.com/erupshis/bonusbridge/internal/keyset"
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/erupshis/bonusbridge/internal/orders/data"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAddOrder checks order numbers uniqueness. Scenarios are shared by managers implementations.
+func testAddOrder(t *testing.T, manager BaseOrdersManager, userID int64, anotherUserID int64) {
+	ctx := context.Background()
+	number := testOrderNumber(0)
+
+	id, err := manager.AddOrder(ctx, number, userID)
+	require.NoError(t, err)
+
+	duplicateID, err := manager.AddOrder(ctx, number, userID)
+	assert.ErrorIs(t, err, data.ErrOrderWasAddedBefore)
+	assert.Equal(t, id, duplicateID)
+
+	duplicateID, err = manager.AddOrder(ctx, number, anotherUserID)
+	assert.ErrorIs(t, err, data.ErrOrderWasAddedByAnotherUser)
+	assert.Equal(t, id, duplicateID)
+
+	orders, err := manager.GetOrders(ctx, data.Filter{Number: number})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, int(id), orders[0].ID)
+	assert.Equal(t, userID, orders[0].UserID)
+	assert.Equal(t, "NEW", orders[0].Status)
+	assert.Equal(t, money.Amount(0), orders[0].Accrual)
+}
+
+// testUpdateOrders checks batch update of orders statuses and accruals.
+func testUpdateOrders(t *testing.T, manager BaseOrdersManager, userID int64) {
+	ctx := context.Background()
+
+	var ids []int
+	for i := 0; i < 2; i++ {
+		id, err := manager.AddOrder(ctx, testOrderNumber(i), userID)
+		require.NoError(t, err)
+		ids = append(ids, int(id))
+	}
+
+	orders, err := manager.GetOrders(ctx, data.Filter{IDs: ids, Sort: keyset.SortOldestFirst})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+
+	// the latest data of the order wins.
+	processing := orders[0]
+	processing.Status = "PROCESSING"
+	processed := orders[0]
+	processed.Status = "PROCESSED"
+	processed.Accrual = money.New(500, 0)
+	invalid := orders[1]
+	invalid.Status = "INVALID"
+	require.NoError(t, manager.UpdateOrders(ctx, []data.Order{processing, invalid, processed}))
+
+	// unchanged orders are skipped.
+	require.NoError(t, manager.UpdateOrder(ctx, &invalid))
+
+	// batch with missing order isn't saved.
+	reopened := processed
+	reopened.Status = "PROCESSING"
+	assert.Error(t, manager.UpdateOrders(ctx, []data.Order{reopened, {ID: -1, Status: "PROCESSED"}}))
+
+	orders, err = manager.GetOrders(ctx, data.Filter{IDs: ids, Sort: keyset.SortOldestFirst})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, "PROCESSED", orders[0].Status)
+	assert.Equal(t, money.New(500, 0), orders[0].Accrual)
+	assert.Equal(t, "INVALID", orders[1].Status)
+	assert.Equal(t, money.Amount(0), orders[1].Accrual)
+}
+
+// testGetOrders checks orders filter and keyset pagination.
+func testGetOrders(t *testing.T, manager BaseOrdersManager, userID int64) {
+	ctx := context.Background()
+
+	var ids []int
+	for i := 0; i < 3; i++ {
+		id, err := manager.AddOrder(ctx, testOrderNumber(i), userID)
+		require.NoError(t, err)
+		ids = append(ids, int(id))
+	}
+	invalid := data.Order{ID: ids[1], Status: "INVALID"}
+	require.NoError(t, manager.UpdateOrder(ctx, &invalid))
+
+	filter := data.Filter{UserID: userID, Sort: keyset.SortNewestFirst, Limit: 2}
+	orders, err := manager.GetOrders(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, ids[2], orders[0].ID)
+	assert.Equal(t, ids[1], orders[1].ID)
+
+	cursor := data.CursorOf(&orders[1])
+	filter.After = &cursor
+	orders, err = manager.GetOrders(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, ids[0], orders[0].ID)
+
+	orders, err = manager.GetOrders(ctx, data.Filter{UserID: userID, Statuses: []string{"NEW"}, Sort: keyset.SortOldestFirst})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, ids[0], orders[0].ID)
+	assert.Equal(t, ids[2], orders[1].ID)
+}
+
+// testGetOrdersInTimeZone checks keyset pagination by cursor round-tripped through its token and upload period bound
+// in client's offset, while process time zone isn't UTC. Orders are uploaded with process local time.
+func testGetOrdersInTimeZone(t *testing.T, manager BaseOrdersManager, userID int64) {
+	setLocalTimeZone(t, time.FixedZone("UTC-5", -5*60*60))
+	ctx := context.Background()
+
+	var ids []int
+	for i := 0; i < 3; i++ {
+		id, err := manager.AddOrder(ctx, testOrderNumber(i), userID)
+		require.NoError(t, err)
+		ids = append(ids, int(id))
+	}
+
+	filter := data.Filter{UserID: userID, Sort: keyset.SortNewestFirst, Limit: 1}
+	orders, err := manager.GetOrders(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, ids[2], orders[0].ID)
+
+	cursor, err := keyset.ParseCursor(data.CursorOf(&orders[0]).String())
+	require.NoError(t, err)
+	filter.After, filter.Limit = cursor, 2
+	orders, err = manager.GetOrders(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, ids[1], orders[0].ID)
+	assert.Equal(t, ids[0], orders[1].ID)
+
+	from := orders[0].UploadedAt.In(time.FixedZone("UTC+9", 9*60*60))
+	orders, err = manager.GetOrders(ctx, data.Filter{UserID: userID, UploadedFrom: from, Sort: keyset.SortOldestFirst})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, ids[1], orders[0].ID)
+	assert.Equal(t, ids[2], orders[1].ID)
+}
+
+// testStatusChangedEventUser checks that status change event is addressed to order's owner even if updated order
+// doesn't carry user, like orders reset by administrator. userEvents returns outbox events of user.
+func testStatusChangedEventUser(t *testing.T, manager BaseOrdersManager, userID int64, userEvents func(userID int64) []outboxData.Event) {
+	ctx := context.Background()
+
+	id, err := manager.AddOrder(ctx, testOrderNumber(0), userID)
+	require.NoError(t, err)
+	require.NoError(t, manager.UpdateOrder(ctx, &data.Order{ID: int(id), Status: "INVALID"}))
+
+	events := userEvents(userID)
+	require.Len(t, events, 1)
+	assert.Equal(t, outboxData.TypeOrderStatusChanged, events[0].Type)
+	assert.Empty(t, userEvents(0))
+}
+
+// testResetOrder checks that reset order takes its accrual back and is polled again, unless the accrual is spent.
+func testResetOrder(t *testing.T, manager BaseOrdersManager, bonusesManager bonusesManagers.BaseBonusesManager, userID int64) {
+	ctx := context.Background()
+
+	id, err := manager.AddOrder(ctx, testOrderNumber(0), userID)
+	require.NoError(t, err)
+	processed := data.Order{ID: int(id), UserID: userID, Status: "PROCESSED", Accrual: money.New(500, 0)}
+	require.NoError(t, manager.UpdateOrder(ctx, &processed))
+
+	require.NoError(t, manager.ResetOrder(ctx, &processed))
+
+	orders, err := manager.GetOrders(ctx, data.Filter{IDs: []int{int(id)}})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "NEW", orders[0].Status)
+	assert.Equal(t, money.Amount(0), orders[0].Accrual)
+
+	balance, err := bonusesManager.GetBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, money.Amount(0), balance.Current)
+
+	jobs, err := manager.ClaimAccrualJobs(ctx, 10000, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(jobs, func(job data.AccrualJob) bool { return job.Order.ID == int(id) }))
+
+	// accrual can't be taken back after user has spent it.
+	require.NoError(t, manager.UpdateOrder(ctx, &processed))
+	withdrawal := &bonusesData.Withdrawal{UserID: userID, Order: testOrderNumber(1), Sum: money.New(300, 0), ProcessedAt: time.Now()}
+	require.NoError(t, bonusesManager.WithdrawBonuses(ctx, withdrawal))
+
+	// reset order may not carry user, accrual is checked against owner's balance.
+	assert.ErrorIs(t, manager.ResetOrder(ctx, &data.Order{ID: int(id)}), data.ErrAccrualSpent)
+
+	orders, err = manager.GetOrders(ctx, data.Filter{IDs: []int{int(id)}})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "PROCESSED", orders[0].Status)
+	assert.Equal(t, money.New(500, 0), orders[0].Accrual)
+}
+
+// setLocalTimeZone replaces process time zone till the end of test.
+func setLocalTimeZone(t *testing.T, location *time.Location) {
+	t.Helper()
+
+	local := time.Local
+	time.Local = location
+	t.Cleanup(func() {
+		time.Local = local
+	})
+}
+
+// testOrderNumber generates order number unique between tests runs.
+func testOrderNumber(i int) string {
+	return fmt.Sprintf("%d%02d", time.Now().UnixNano(), i)
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
@@ -39,11 +40,53 @@ func (s *Storage) UpdateOrder(ctx context.Context, order *data.Order) error {
 	return nil
 }
 
-func (s *Storage) GetOrders(ctx context.Context, filters map[string]interface{}) ([]data.Order, error) {
-	orders, err := s.manager.GetOrders(ctx, filters)
+func (s *Storage) UpdateOrders(ctx context.Context, orders []data.Order) error {
+	if err := s.manager.UpdateOrders(ctx, orders); err != nil {
+		return fmt.Errorf("update orders in storage: %w", err)
+	}
+
+	return nil
+}
+
+// ResetOrder returns order to accrual system polling from the start. Order's accrual is taken back from user's balance.
+func (s *Storage) ResetOrder(ctx context.Context, order *data.Order) error {
+	if err := s.manager.ResetOrder(ctx, order); err != nil {
+		return fmt.Errorf("reset order in storage: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetOrders(ctx context.Context, filter data.Filter) ([]data.Order, error) {
+	orders, err := s.manager.GetOrders(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get orders from storage: %w", err)
 	}
 
 	return orders, nil
 }
+
+func (s *Storage) ClaimAccrualJobs(ctx context.Context, limit int, lease time.Duration) ([]data.AccrualJob, error) {
+	jobs, err := s.manager.ClaimAccrualJobs(ctx, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claim accrual jobs in storage: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *Storage) RescheduleAccrualJob(ctx context.Context, job *data.AccrualJob, delay time.Duration) error {
+	if err := s.manager.RescheduleAccrualJob(ctx, job, delay); err != nil {
+		return fmt.Errorf("reschedule accrual job in storage: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteAccrualJob(ctx context.Context, orderID int64) error {
+	if err := s.manager.DeleteAccrualJob(ctx, orderID); err != nil {
+		return fmt.Errorf("delete accrual job in storage: %w", err)
+	}
+
+	return nil
+}
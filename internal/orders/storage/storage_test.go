@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/erupshis/bonusbridge/internal/logger"
 	"github.com/erupshis/bonusbridge/internal/orders/data"
@@ -146,6 +147,71 @@ func TestStorage_UpdateOrder(t *testing.T) {
 	}
 }
 
+func TestStorage_UpdateOrders(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockBaseOrdersManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().UpdateOrders(gomock.Any(), gomock.Any()).Return(nil),
+		mockManager.EXPECT().UpdateOrders(gomock.Any(), gomock.Any()).Return(fmt.Errorf("manager error")),
+	)
+
+	type fields struct {
+		manager managers.BaseOrdersManager
+		log     logger.BaseLogger
+	}
+	type args struct {
+		ctx    context.Context
+		orders []data.Order
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			fields: fields{
+				manager: mockManager,
+				log:     log,
+			},
+			args: args{
+				ctx:    context.Background(),
+				orders: []data.Order{{ID: 1}, {ID: 2}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "manager error",
+			fields: fields{
+				manager: mockManager,
+				log:     log,
+			},
+			args: args{
+				ctx:    context.Background(),
+				orders: []data.Order{{ID: 1}, {ID: 2}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Storage{
+				manager: tt.fields.manager,
+				log:     tt.fields.log,
+			}
+			if err := s.UpdateOrders(tt.args.ctx, tt.args.orders); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateOrders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestStorage_GetOrders(t *testing.T) {
 	log, _ := logger.CreateZapLogger("info")
 	defer log.Sync()
@@ -171,8 +237,8 @@ func TestStorage_GetOrders(t *testing.T) {
 		log     logger.BaseLogger
 	}
 	type args struct {
-		ctx     context.Context
-		filters map[string]interface{}
+		ctx    context.Context
+		filter data.Filter
 	}
 	tests := []struct {
 		name    string
@@ -188,8 +254,8 @@ func TestStorage_GetOrders(t *testing.T) {
 				log:     log,
 			},
 			args: args{
-				ctx:     context.Background(),
-				filters: nil,
+				ctx:    context.Background(),
+				filter: data.Filter{},
 			},
 			want:    orders,
 			wantErr: false,
@@ -201,8 +267,8 @@ func TestStorage_GetOrders(t *testing.T) {
 				log:     log,
 			},
 			args: args{
-				ctx:     context.Background(),
-				filters: nil,
+				ctx:    context.Background(),
+				filter: data.Filter{},
 			},
 			want:    nil,
 			wantErr: true,
@@ -214,7 +280,7 @@ func TestStorage_GetOrders(t *testing.T) {
 				manager: tt.fields.manager,
 				log:     tt.fields.log,
 			}
-			got, err := s.GetOrders(tt.args.ctx, tt.args.filters)
+			got, err := s.GetOrders(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetOrders() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -225,3 +291,86 @@ func TestStorage_GetOrders(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_ClaimAccrualJobs(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobs := []data.AccrualJob{
+		{
+			Order:    data.Order{ID: 1, Number: "2377225624", Status: "NEW"},
+			Attempts: 2,
+		},
+	}
+
+	mockManager := mocks.NewMockBaseOrdersManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().ClaimAccrualJobs(gomock.Any(), 10, time.Minute).Return(jobs, nil),
+		mockManager.EXPECT().ClaimAccrualJobs(gomock.Any(), 10, time.Minute).Return(nil, fmt.Errorf("manager error")),
+	)
+
+	type fields struct {
+		manager managers.BaseOrdersManager
+		log     logger.BaseLogger
+	}
+	type args struct {
+		ctx   context.Context
+		limit int
+		lease time.Duration
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []data.AccrualJob
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			fields: fields{
+				manager: mockManager,
+				log:     log,
+			},
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+				lease: time.Minute,
+			},
+			want:    jobs,
+			wantErr: false,
+		},
+		{
+			name: "manager error",
+			fields: fields{
+				manager: mockManager,
+				log:     log,
+			},
+			args: args{
+				ctx:   context.Background(),
+				limit: 10,
+				lease: time.Minute,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Storage{
+				manager: tt.fields.manager,
+				log:     tt.fields.log,
+			}
+			got, err := s.ClaimAccrualJobs(tt.args.ctx, tt.args.limit, tt.args.lease)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ClaimAccrualJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClaimAccrualJobs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
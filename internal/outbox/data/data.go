@@ -0,0 +1,66 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/money"
+	"github.com/mailru/easyjson"
+)
+
+const (
+	TypeOrderStatusChanged = "order.status_changed"
+	TypeBonusesWithdrawn   = "bonuses.withdrawn"
+	TypeBonusesAdjusted    = "bonuses.adjusted"
+)
+
+// Event domain event stored in outbox in the same transaction as the change itself.
+// Event may be delivered more than once, consumers should deduplicate it by ID.
+//
+//go:generate easyjson -all data.go
+type Event struct {
+	ID        int64           `json:"id"`
+	UserID    int64           `json:"user_id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"-"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// OrderStatusChanged payload of TypeOrderStatusChanged event.
+type OrderStatusChanged struct {
+	Number         string       `json:"number"`
+	PreviousStatus string       `json:"previous_status"`
+	Status         string       `json:"status"`
+	Accrual        money.Amount `json:"accrual"`
+}
+
+// BonusesWithdrawn payload of TypeBonusesWithdrawn event.
+type BonusesWithdrawn struct {
+	Order       string       `json:"order"`
+	Sum         money.Amount `json:"sum"`
+	ProcessedAt time.Time    `json:"processed_at"`
+}
+
+// BonusesAdjusted payload of TypeBonusesAdjusted event.
+type BonusesAdjusted struct {
+	Sum       money.Amount `json:"sum"`
+	Reason    string       `json:"reason"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// CreateEvent creates new event with serialized payload.
+func CreateEvent(userID int64, eventType string, payload easyjson.Marshaler) (*Event, error) {
+	payloadBytes, err := easyjson.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal '%s' event payload: %w", eventType, err)
+	}
+
+	return &Event{
+		UserID:    userID,
+		Type:      eventType,
+		Payload:   payloadBytes,
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package managers
+
+import (
+	"context"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// PublishFunc delivers event to external system.
+type PublishFunc func(ctx context.Context, event *data.Event) error
+
+//go:generate mockgen -destination=../../../mocks/mock_BaseOutboxManager.go -package=mocks github.com/erupshis/bonusbridge/internal/outbox/managers BaseOutboxManager
+type BaseOutboxManager interface {
+	PublishEvents(ctx context.Context, limit int, publish PublishFunc) (int, error)
+	PruneEvents(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,106 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+type memoryManager struct {
+	store *memory.Store
+
+	// publishMu serializes relays instead of events rows locks.
+	publishMu sync.Mutex
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation reading events from in-memory store.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseOutboxManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+// PublishEvents passes up to limit oldest unpublished events to publish in order and marks delivered ones as published.
+// Stops on the first failed delivery to keep events order, failed event gets its attempts counter increased and is
+// dead lettered after maxPublishAttempts failures. Store isn't locked during publishing, so sinks may use the same store.
+func (p *memoryManager) PublishEvents(ctx context.Context, limit int, publish PublishFunc) (int, error) {
+	p.publishMu.Lock()
+	defer p.publishMu.Unlock()
+
+	events := p.selectUnpublished(limit)
+	published := 0
+	for i := range events {
+		if err := publish(ctx, &events[i]); err != nil {
+			p.updateEvent(events[i].ID, func(event *memory.Event) {
+				event.Attempts++
+				if event.Attempts >= maxPublishAttempts {
+					p.log.Info("[outbox:memoryManager:PublishEvents] event '%d' is dead lettered after '%d' failed attempts", event.ID, event.Attempts)
+					event.DeadLetteredAt = time.Now()
+				}
+			})
+			return published, fmt.Errorf("publish outbox events: event '%d': %w", events[i].ID, err)
+		}
+
+		p.updateEvent(events[i].ID, func(event *memory.Event) { event.PublishedAt = time.Now() })
+		published++
+	}
+
+	p.log.Info("[outbox:memoryManager:PublishEvents] '%d' events published", published)
+	return published, nil
+}
+
+// PruneEvents removes events published before the moment. Dead lettered events are kept.
+func (p *memoryManager) PruneEvents(_ context.Context, publishedBefore time.Time) (int64, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	kept := p.store.Events[:0]
+	for _, event := range p.store.Events {
+		if event.PublishedAt.IsZero() || !event.PublishedAt.Before(publishedBefore) {
+			kept = append(kept, event)
+		}
+	}
+
+	deleted := int64(len(p.store.Events) - len(kept))
+	p.store.Events = kept
+	p.log.Info("[outbox:memoryManager:PruneEvents] '%d' published events removed", deleted)
+	return deleted, nil
+}
+
+func (p *memoryManager) selectUnpublished(limit int) []data.Event {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Event
+	for _, event := range p.store.Events {
+		if len(res) == limit {
+			break
+		}
+
+		if event.PublishedAt.IsZero() && event.DeadLetteredAt.IsZero() {
+			res = append(res, event.Event)
+		}
+	}
+	return res
+}
+
+func (p *memoryManager) updateEvent(id int64, update func(event *memory.Event)) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.Events {
+		if p.store.Events[i].ID == id {
+			update(&p.store.Events[i])
+			return
+		}
+	}
+}
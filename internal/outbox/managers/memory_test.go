@@ -0,0 +1,72 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryManager_PublishEvents(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctx := context.Background()
+	store := memory.Create()
+	store.AddEvent(&data.Event{UserID: 1, Type: data.TypeOrderStatusChanged, Payload: []byte(`{"number":"1"}`)})
+	store.AddEvent(&data.Event{UserID: 1, Type: data.TypeOrderStatusChanged, Payload: []byte(`{"number":"2"}`)})
+	manager := CreateInMemory(store, log)
+
+	// the first event fails till it is dead lettered, the next one waits for it.
+	failFirst := func(_ context.Context, event *data.Event) error {
+		if event.ID == 1 {
+			return fmt.Errorf("sink error")
+		}
+		return nil
+	}
+	for i := 0; i < maxPublishAttempts; i++ {
+		published, err := manager.PublishEvents(ctx, 10, failFirst)
+		assert.Error(t, err)
+		assert.Equal(t, 0, published)
+	}
+	assert.False(t, store.Events[0].DeadLetteredAt.IsZero())
+
+	var publishedIDs []int64
+	published, err := manager.PublishEvents(ctx, 10, func(_ context.Context, event *data.Event) error {
+		publishedIDs = append(publishedIDs, event.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, []int64{2}, publishedIDs)
+}
+
+func TestMemoryManager_PruneEvents(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	now := time.Now()
+	store := memory.Create()
+	store.Events = []memory.Event{
+		{Event: data.Event{ID: 1}, PublishedAt: now.Add(-2 * time.Hour)},
+		{Event: data.Event{ID: 2}, DeadLetteredAt: now.Add(-2 * time.Hour)},
+		{Event: data.Event{ID: 3}, PublishedAt: now},
+		{Event: data.Event{ID: 4}},
+	}
+
+	deleted, err := CreateInMemory(store, log).PruneEvents(context.Background(), now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	var ids []int64
+	for _, event := range store.Events {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []int64{2, 3, 4}, ids)
+}
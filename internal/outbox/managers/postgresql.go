@@ -0,0 +1,161 @@
+package managers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries/outbox"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// claimLease time while events claimed by relay are hidden from other relays. Events are returned to outbox after
+// expiration if relay fails before publishing them.
+const claimLease = time.Minute
+
+// maxPublishAttempts count of failed publishing attempts after which event is dead lettered. Dead lettered event is
+// kept in outbox for investigation, but isn't published anymore and doesn't block the events following it.
+const maxPublishAttempts = 10
+
+// manager storageManager implementation for PostgreSQL and SQLite.
+type manager struct {
+	*db.Conn
+
+	log logger.BaseLogger
+}
+
+// Create creates manager implementation.
+func Create(dbConn *db.Conn, log logger.BaseLogger) BaseOutboxManager {
+	return &manager{
+		Conn: dbConn,
+		log:  log,
+	}
+}
+
+// PublishEvents claims up to limit oldest unpublished events and passes them to publish in order. Claim is committed
+// before publishing, so slow sink neither keeps transaction open nor blocks other relays. Every delivered event
+// is marked as published in separate transaction.
+// Stops on the first failed delivery to keep events order, failed event gets its attempts counter increased,
+// it and the rest of claimed events are released for the next relay's run. Event is dead lettered after
+// maxPublishAttempts failures.
+// Returns number of published events.
+func (p *manager) PublishEvents(ctx context.Context, limit int, publish PublishFunc) (int, error) {
+	errMsg := "publish outbox events: %w"
+	events, err := p.claimEvents(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf(errMsg, err)
+	}
+
+	published := 0
+	for i := range events {
+		if errPublish := publish(ctx, &events[i]); errPublish != nil {
+			if err = p.releaseEvents(ctx, events[i:]); err != nil {
+				p.log.Info("[outbox:manager:PublishEvents] failed to release claimed events: %v", err)
+			}
+			return published, fmt.Errorf(errMsg, fmt.Errorf("event '%d': %w", events[i].ID, errPublish))
+		}
+
+		publishedAt := time.Now()
+		err = p.inTransaction(ctx, func(tx *sql.Tx) error {
+			return outbox.UpdateByID(ctx, tx, events[i].ID, outbox.Values{PublishedAt: &publishedAt}, p.log)
+		})
+		if err != nil {
+			return published, fmt.Errorf(errMsg, err)
+		}
+
+		published++
+	}
+
+	p.log.Info("[outbox:manager:PublishEvents] '%d' events published", published)
+	return published, nil
+}
+
+// claimEvents selects up to limit oldest unpublished events and hides them from other relays for claim lease.
+func (p *manager) claimEvents(ctx context.Context, limit int) ([]data.Event, error) {
+	p.log.Info("[outbox:manager:claimEvents] start transaction")
+	errMsg := "claim outbox events: %w"
+
+	var events []data.Event
+	err := p.inTransaction(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+		var err error
+		events, err = outbox.SelectUnpublished(ctx, tx, p.Dialect, now, limit, p.log)
+		if err != nil {
+			return err
+		}
+
+		lockedUntil := now.Add(claimLease)
+		for i := range events {
+			if err = outbox.UpdateByID(ctx, tx, events[i].ID, outbox.Values{LockedUntil: &lockedUntil}, p.log); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[outbox:manager:claimEvents] transaction successful, '%d' events claimed", len(events))
+	return events, nil
+}
+
+// releaseEvents returns claimed events to outbox. The first of them failed delivery and gets its attempts counter increased,
+// it is dead lettered when attempts are exhausted.
+func (p *manager) releaseEvents(ctx context.Context, events []data.Event) error {
+	return p.inTransaction(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+		for i := range events {
+			values := outbox.Values{LockedUntil: &now}
+			if i == 0 {
+				attempts := events[i].Attempts + 1
+				values.Attempts = &attempts
+				if attempts >= maxPublishAttempts {
+					p.log.Info("[outbox:manager:releaseEvents] event '%d' is dead lettered after '%d' failed attempts", events[i].ID, attempts)
+					values.DeadLetteredAt = &now
+				}
+			}
+
+			if err := outbox.UpdateByID(ctx, tx, events[i].ID, values, p.log); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PruneEvents removes events published before the moment. Dead lettered events are kept.
+// Returns number of removed events.
+func (p *manager) PruneEvents(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	var deleted int64
+	err := p.inTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		deleted, err = outbox.DeletePublished(ctx, tx, publishedBefore, p.log)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("prune outbox events: %w", err)
+	}
+
+	p.log.Info("[outbox:manager:PruneEvents] '%d' published events removed", deleted)
+	return deleted, nil
+}
+
+// inTransaction executes query in separate transaction.
+func (p *manager) inTransaction(ctx context.Context, query func(tx *sql.Tx) error) error {
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = query(tx); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/managers"
+	"github.com/erupshis/bonusbridge/internal/outbox/sinks"
+)
+
+var (
+	defPruneInterval      = time.Hour
+	defPublishedRetention = 7 * 24 * time.Hour
+)
+
+// Relay publishes outbox events to sink with at-least-once delivery. Published events are removed from outbox
+// after retention period.
+type Relay struct {
+	manager managers.BaseOutboxManager
+	sink    sinks.BaseSink
+
+	batchSize     int
+	pruneInterval time.Duration
+	retention     time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+
+	log logger.BaseLogger
+}
+
+func CreateRelay(manager managers.BaseOutboxManager, sink sinks.BaseSink, batchSize int, baseLogger logger.BaseLogger) Relay {
+	return Relay{
+		manager:       manager,
+		sink:          sink,
+		batchSize:     batchSize,
+		pruneInterval: defPruneInterval,
+		retention:     defPublishedRetention,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+		log:           baseLogger,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context, interval int) {
+	r.log.Info("[outbox:Relay:Run] start events relay, interval '%d' seconds", interval)
+
+	go r.relayEvents(ctx, time.Duration(interval))
+}
+
+// Shutdown stops relay after the current batch and waits for it till ctx is done.
+func (r *Relay) Shutdown(ctx context.Context) error {
+	close(r.stop)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.done:
+		return nil
+	}
+}
+
+func (r *Relay) relayEvents(ctx context.Context, interval time.Duration) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(interval * time.Second)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(r.pruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("[outbox:Relay:relayEvents] relay task is stopping by context")
+			return
+		case <-r.stop:
+			r.log.Info("[outbox:Relay:relayEvents] relay task is stopped")
+			return
+		case <-ticker.C:
+			r.publishEvents(ctx)
+		case <-pruneTicker.C:
+			r.pruneEvents(ctx)
+		}
+	}
+}
+
+// pruneEvents removes events published before retention period.
+func (r *Relay) pruneEvents(ctx context.Context) {
+	if _, err := r.manager.PruneEvents(ctx, time.Now().Add(-r.retention)); err != nil {
+		r.log.Info("[outbox:Relay:pruneEvents] failed to prune published events: %v", err)
+	}
+}
+
+// publishEvents drains outbox by batches until it is empty, delivery fails or relay is stopped.
+func (r *Relay) publishEvents(ctx context.Context) {
+	for ctx.Err() == nil && !r.isStopped() {
+		published, err := r.manager.PublishEvents(ctx, r.batchSize, r.sink.Publish)
+		if err != nil {
+			r.log.Info("[outbox:Relay:publishEvents] failed to publish events: %v", err)
+			return
+		}
+
+		if published < r.batchSize {
+			return
+		}
+	}
+}
+
+func (r *Relay) isStopped() bool {
+	select {
+	case <-r.stop:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/outbox/managers"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay_publishEvents(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := data.Event{ID: 1, UserID: 1, Type: data.TypeOrderStatusChanged, Payload: []byte(`{}`)}
+	publishBatch := func(count int) func(context.Context, int, managers.PublishFunc) (int, error) {
+		return func(ctx context.Context, _ int, publish managers.PublishFunc) (int, error) {
+			for i := 0; i < count; i++ {
+				if err := publish(ctx, &event); err != nil {
+					return i, err
+				}
+			}
+			return count, nil
+		}
+	}
+
+	mockSink := mocks.NewMockBaseSink(ctrl)
+	gomock.InOrder(
+		mockSink.EXPECT().Publish(gomock.Any(), &event).Return(nil).Times(6),
+		mockSink.EXPECT().Publish(gomock.Any(), &event).Return(fmt.Errorf("sink error")),
+	)
+
+	mockManager := mocks.NewMockBaseOutboxManager(ctrl)
+	gomock.InOrder(
+		// full batch, then partial batch - outbox is drained.
+		mockManager.EXPECT().PublishEvents(gomock.Any(), 2, gomock.Any()).DoAndReturn(publishBatch(2)),
+		mockManager.EXPECT().PublishEvents(gomock.Any(), 2, gomock.Any()).DoAndReturn(publishBatch(1)),
+		// empty outbox.
+		mockManager.EXPECT().PublishEvents(gomock.Any(), 2, gomock.Any()).Return(0, nil),
+		// full batch, then delivery failure stops relay till next tick.
+		mockManager.EXPECT().PublishEvents(gomock.Any(), 2, gomock.Any()).DoAndReturn(publishBatch(2)),
+		mockManager.EXPECT().PublishEvents(gomock.Any(), 2, gomock.Any()).DoAndReturn(publishBatch(2)),
+		// database error.
+		mockManager.EXPECT().PublishEvents(gomock.Any(), 2, gomock.Any()).Return(0, fmt.Errorf("db error")),
+	)
+
+	tests := []struct {
+		name string
+	}{
+		{name: "outbox is drained by batches"},
+		{name: "empty outbox"},
+		{name: "delivery failure"},
+		{name: "manager returns error"},
+	}
+	r := CreateRelay(mockManager, mockSink, 2, log)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				r.publishEvents(context.Background())
+			})
+		})
+	}
+}
+
+func TestRelay_pruneEvents(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockBaseOutboxManager(ctrl)
+	r := CreateRelay(mockManager, mocks.NewMockBaseSink(ctrl), 2, log)
+
+	// events published before retention period are removed.
+	gomock.InOrder(
+		mockManager.EXPECT().PruneEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, publishedBefore time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-r.retention), publishedBefore, time.Minute)
+			return 1, nil
+		}),
+		mockManager.EXPECT().PruneEvents(gomock.Any(), gomock.Any()).Return(int64(0), fmt.Errorf("db error")),
+	)
+
+	assert.NotPanics(t, func() {
+		r.pruneEvents(context.Background())
+		r.pruneEvents(context.Background())
+	})
+}
+
+func TestRelay_Shutdown(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := CreateRelay(mocks.NewMockBaseOutboxManager(ctrl), mocks.NewMockBaseSink(ctrl), 2, log)
+	r.Run(context.Background(), 1)
+
+	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	require.NoError(t, r.Shutdown(ctxShutdown))
+
+	// stopped relay doesn't start new batches.
+	r.publishEvents(context.Background())
+}
@@ -0,0 +1,31 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// fanOutSink publishes event to every sink. Event is considered published only if all sinks succeeded,
+// so sinks may receive the same event again.
+type fanOutSink struct {
+	sinks []BaseSink
+}
+
+func CreateFanOutSink(sinks ...BaseSink) BaseSink {
+	return &fanOutSink{
+		sinks: sinks,
+	}
+}
+
+func (s *fanOutSink) Publish(ctx context.Context, event *data.Event) error {
+	var errs []error
+	for _, sink := range s.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOutSink_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := &data.Event{ID: 1, UserID: 1}
+
+	first := mocks.NewMockBaseSink(ctrl)
+	second := mocks.NewMockBaseSink(ctrl)
+	gomock.InOrder(
+		first.EXPECT().Publish(gomock.Any(), event).Return(nil),
+		second.EXPECT().Publish(gomock.Any(), event).Return(nil),
+		first.EXPECT().Publish(gomock.Any(), event).Return(fmt.Errorf("sink error")),
+		second.EXPECT().Publish(gomock.Any(), event).Return(nil),
+	)
+
+	sink := CreateFanOutSink(first, second)
+	assert.NoError(t, sink.Publish(context.Background(), event))
+	assert.Error(t, sink.Publish(context.Background(), event), "failed sink fails publishing, the rest still receive event")
+}
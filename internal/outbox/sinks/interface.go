@@ -0,0 +1,14 @@
+package sinks
+
+import (
+	"context"
+
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+)
+
+// BaseSink external system which receives outbox events(webhook, message broker, etc.).
+//
+//go:generate mockgen -destination=../../../mocks/mock_BaseSink.go -package=mocks github.com/erupshis/bonusbridge/internal/outbox/sinks BaseSink
+type BaseSink interface {
+	Publish(ctx context.Context, event *data.Event) error
+}
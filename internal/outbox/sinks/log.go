@@ -0,0 +1,31 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/mailru/easyjson"
+)
+
+// logSink writes events into log. Used when no external system is configured.
+type logSink struct {
+	log logger.BaseLogger
+}
+
+func CreateLogSink(log logger.BaseLogger) BaseSink {
+	return &logSink{
+		log: log,
+	}
+}
+
+func (s *logSink) Publish(_ context.Context, event *data.Event) error {
+	body, err := easyjson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event '%d': %w", event.ID, err)
+	}
+
+	s.log.Printf("[outbox:logSink:Publish] %s", body)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package stream delivers outbox events to users connected to the server.
+package stream
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/mailru/easyjson"
+)
+
+// Broker fans out events of user to all user's subscriptions of this server instance.
+// Events relayed by another instance reach the broker through Notifier.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[int64]map[chan *data.Event]struct{}
+	closed      bool
+
+	bufferSize int
+
+	log logger.BaseLogger
+}
+
+// CreateBroker creates broker. bufferSize - count of events kept for slow subscriber, the next ones are dropped.
+func CreateBroker(bufferSize int, log logger.BaseLogger) *Broker {
+	return &Broker{
+		subscribers: map[int64]map[chan *data.Event]struct{}{},
+		bufferSize:  bufferSize,
+		log:         log,
+	}
+}
+
+// Subscribe creates user's subscription. Channel is closed by unsubscribe func or broker closing.
+func (b *Broker) Subscribe(userID int64) (<-chan *data.Event, func()) {
+	ch := make(chan *data.Event, b.bufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[chan *data.Event]struct{}{}
+	}
+	b.subscribers[userID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[userID][ch]; !ok {
+			return
+		}
+
+		delete(b.subscribers[userID], ch)
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends event to user's subscriptions without waiting for slow ones. Implements sinks.BaseSink.
+func (b *Broker) Publish(_ context.Context, event *data.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.UserID] {
+		select {
+		case ch <- event:
+		default:
+			b.log.Info("[stream:Broker:Publish] subscription of userID '%d' is full, event '%d' is dropped", event.UserID, event.ID)
+		}
+	}
+
+	return nil
+}
+
+// Close closes all subscriptions. New subscriptions are closed immediately.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+	for userID, channels := range b.subscribers {
+		for ch := range channels {
+			close(ch)
+		}
+		delete(b.subscribers, userID)
+	}
+}
+
+// WriteEvent writes event in Server-Sent Events format.
+func WriteEvent(w io.Writer, event *data.Event) error {
+	body, err := easyjson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event '%d': %w", event.ID, err)
+	}
+
+	if _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, body); err != nil {
+		return fmt.Errorf("write event '%d': %w", event.ID, err)
+	}
+
+	return nil
+}
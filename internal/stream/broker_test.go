@@ -0,0 +1,67 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	broker := CreateBroker(1, log)
+
+	first, unsubscribeFirst := broker.Subscribe(1)
+	second, unsubscribeSecond := broker.Subscribe(1)
+	another, unsubscribeAnother := broker.Subscribe(2)
+	defer unsubscribeAnother()
+
+	event := &data.Event{ID: 1, UserID: 1, Type: data.TypeOrderStatusChanged}
+	require.NoError(t, broker.Publish(context.Background(), event))
+
+	// event is fanned out to all user's subscriptions only.
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+	assert.Len(t, another, 0)
+
+	// full subscription doesn't block publisher.
+	require.NoError(t, broker.Publish(context.Background(), event))
+	require.NoError(t, broker.Publish(context.Background(), event))
+	assert.Len(t, first, 1)
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.True(t, ok, "buffered event is kept after unsubscribe")
+	_, ok = <-first
+	assert.False(t, ok)
+
+	broker.Close()
+	<-second
+	_, ok = <-second
+	assert.False(t, ok)
+	_, ok = <-another
+	assert.False(t, ok)
+	unsubscribeSecond()
+
+	// subscriptions after close are closed immediately.
+	closed, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+	_, ok = <-closed
+	assert.False(t, ok)
+	assert.NoError(t, broker.Publish(context.Background(), event))
+}
+
+func TestWriteEvent(t *testing.T) {
+	buf := bytes.Buffer{}
+	event := &data.Event{ID: 3, UserID: 1, Type: data.TypeBonusesWithdrawn, Payload: []byte(`{"order":"1"}`)}
+
+	require.NoError(t, WriteEvent(&buf, event))
+	assert.Equal(t, "id: 3\nevent: bonuses.withdrawn\ndata: {\"id\":3,\"user_id\":1,\"type\":\"bonuses.withdrawn\",\"payload\":{\"order\":\"1\"},\"created_at\":\"0001-01-01T00:00:00Z\"}\n\n", buf.String())
+}
@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/mailru/easyjson"
+)
+
+// notificationsChannel PostgreSQL channel of published outbox events.
+const notificationsChannel = "outbox_events"
+
+// reconnectPause pause before listening is restored after connection failure.
+const reconnectPause = time.Second
+
+// Notifier delivers outbox events to brokers of every server instance sharing PostgreSQL database. Outbox relay
+// publishes event to Notifier, which is claimed by one instance only, and every instance passes notification
+// to its local broker.
+type Notifier struct {
+	conn   *db.Conn
+	broker *Broker
+
+	stop chan struct{}
+	done chan struct{}
+
+	log logger.BaseLogger
+}
+
+// CreateNotifier creates notifier feeding local broker.
+func CreateNotifier(conn *db.Conn, broker *Broker, log logger.BaseLogger) *Notifier {
+	return &Notifier{
+		conn:   conn,
+		broker: broker,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		log:    log,
+	}
+}
+
+// Publish sends event to all listening instances. Implements sinks.BaseSink.
+func (n *Notifier) Publish(ctx context.Context, event *data.Event) error {
+	body, err := easyjson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event '%d': %w", event.ID, err)
+	}
+
+	if _, err = n.conn.ExecContext(ctx, "SELECT pg_notify($1, $2)", notificationsChannel, string(body)); err != nil {
+		return fmt.Errorf("notify event '%d': %w", event.ID, err)
+	}
+
+	return nil
+}
+
+// Run starts listening of notifications. Events published while connection is restored are not streamed.
+func (n *Notifier) Run(ctx context.Context) {
+	n.log.Info("[stream:Notifier:Run] start listening channel '%s'", notificationsChannel)
+
+	go n.listenNotifications(ctx)
+}
+
+// Shutdown stops listening and waits till listening connection is closed or ctx is done.
+func (n *Notifier) Shutdown(ctx context.Context) error {
+	close(n.stop)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-n.done:
+		return nil
+	}
+}
+
+func (n *Notifier) listenNotifications(ctx context.Context) {
+	defer close(n.done)
+
+	// listening is blocked on connection, it is interrupted by context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-n.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		err := n.listen(ctx)
+
+		select {
+		case <-ctx.Done():
+			n.log.Info("[stream:Notifier:listenNotifications] listening task is stopped")
+			return
+		case <-time.After(reconnectPause):
+			n.log.Info("[stream:Notifier:listenNotifications] listening is interrupted, reconnect: %v", err)
+		}
+	}
+}
+
+// listen passes notifications to broker till connection failure.
+func (n *Notifier) listen(ctx context.Context) error {
+	conn, err := n.conn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	return conn.Raw(func(driverConn any) error {
+		pgxConn := driverConn.(*stdlib.Conn).Conn()
+		if _, err := pgxConn.Exec(ctx, "LISTEN "+notificationsChannel); err != nil {
+			// listening connection is never returned to pool.
+			return fmt.Errorf("%w: listen: %v", driver.ErrBadConn, err)
+		}
+
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: wait for notification: %v", driver.ErrBadConn, err)
+			}
+
+			event := &data.Event{}
+			if err = easyjson.Unmarshal([]byte(notification.Payload), event); err != nil {
+				n.log.Info("[stream:Notifier:listen] failed to parse notification: %v", err)
+				continue
+			}
+
+			_ = n.broker.Publish(ctx, event)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db/dbtest"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	conn := dbtest.CreatePostgresConnection(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// two server instances with their own brokers, the first one relays outbox.
+	relayBroker, anotherBroker := CreateBroker(16, log), CreateBroker(16, log)
+	relayNotifier, anotherNotifier := CreateNotifier(conn, relayBroker, log), CreateNotifier(conn, anotherBroker, log)
+	relayNotifier.Run(ctx)
+	anotherNotifier.Run(ctx)
+
+	relayEvents, unsubscribeRelay := relayBroker.Subscribe(1)
+	defer unsubscribeRelay()
+	anotherEvents, unsubscribeAnother := anotherBroker.Subscribe(1)
+	defer unsubscribeAnother()
+
+	event := &data.Event{ID: 1, UserID: 1, Type: data.TypeOrderStatusChanged, Payload: []byte(`{"number":"1"}`)}
+
+	// notifications sent before listening is started are lost, so event is repeated till both instances get it.
+	var relayGot, anotherGot bool
+	require.Eventually(t, func() bool {
+		require.NoError(t, relayNotifier.Publish(ctx, event))
+		for {
+			select {
+			case got := <-relayEvents:
+				assert.Equal(t, event.ID, got.ID)
+				relayGot = true
+			case got := <-anotherEvents:
+				assert.Equal(t, event.ID, got.ID)
+				anotherGot = true
+			case <-time.After(50 * time.Millisecond):
+				return relayGot && anotherGot
+			}
+		}
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// listening connections are released on shutdown.
+	ctxShutdown, cancelShutdown := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelShutdown()
+	require.NoError(t, relayNotifier.Shutdown(ctxShutdown))
+	require.NoError(t, anotherNotifier.Shutdown(ctxShutdown))
+}
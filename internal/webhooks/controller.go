@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/handlers"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+	"github.com/go-chi/chi/v5"
+)
+
+type Controller struct {
+	storage storage.BaseWebhooksStorage
+
+	log logger.BaseLogger
+}
+
+func CreateController(storage storage.BaseWebhooksStorage, baseLogger logger.BaseLogger) Controller {
+	return Controller{
+		storage: storage,
+		log:     baseLogger,
+	}
+}
+
+func (c *Controller) Route() *chi.Mux {
+	r := chi.NewRouter()
+	r.Post("/", handlers.AddWebhook(c.storage, c.log))
+	r.Get("/", handlers.GetWebhooks(c.storage, c.log))
+	r.Get("/deliveries", handlers.GetDeliveries(c.storage, c.log))
+	r.Delete("/{"+handlers.URLParamWebhookID+"}", handlers.DeleteWebhook(c.storage, c.log))
+
+	return r
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Bonusbridge-Event"
+	HeaderEventID   = "X-Bonusbridge-Event-Id"
+	HeaderSignature = "X-Bonusbridge-Signature"
+	HeaderTimestamp = "X-Bonusbridge-Timestamp"
+
+	// SignaturePrefix prefix of HeaderSignature value, followed by hex encoded HMAC-SHA256 of HeaderTimestamp value,
+	// '.' and request body. Receivers reject deliveries with stale timestamp to prevent replays.
+	SignaturePrefix = "sha256="
+)
+
+var ErrInvalidURL = fmt.Errorf("invalid webhook url")
+var ErrWebhookExists = fmt.Errorf("webhook with the same url has already been added")
+var ErrWebhookNotFound = fmt.Errorf("webhook not found")
+var ErrWebhooksMissing = fmt.Errorf("user doesn't have any webhook")
+var ErrDeliveriesMissing = fmt.Errorf("user doesn't have any webhook delivery")
+
+//go:generate easyjson -all data.go
+type Webhook struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"-"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookRequest struct {
+	URL string `json:"url"`
+}
+
+// Delivery outbox event delivery to user's webhook. Delivery is queued till it succeeds or attempts are exhausted.
+type Delivery struct {
+	ID            int64      `json:"id"`
+	WebhookID     int64      `json:"webhook_id"`
+	UserID        int64      `json:"-"`
+	EventID       int64      `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	Payload       []byte     `json:"-"`
+	Attempts      int        `json:"attempts"`
+	StatusCode    int        `json:"status_code,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	Delivered     bool       `json:"delivered"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` // NextAttemptAt is nil for finished deliveries.
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// DeliveryJob queued delivery with its webhook destination.
+type DeliveryJob struct {
+	Delivery Delivery
+	Webhook  Webhook
+}
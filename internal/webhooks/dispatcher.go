@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+)
+
+// claimLease time for claimed delivery to be sent and saved before it is returned to queue.
+const claimLease = time.Minute
+
+var (
+	defDeliveryTimeout = 5 * time.Second
+	defDeliveryPauses  = []time.Duration{10 * time.Second, time.Minute, 5 * time.Minute, 30 * time.Minute}
+)
+
+// Dispatcher sends queued webhooks deliveries. Every delivery is sent once per claim, failed deliveries
+// are rescheduled with growing pause till attempts are exhausted.
+type Dispatcher struct {
+	storage storage.BaseWebhooksStorage
+	client  *http.Client
+
+	batchSize int
+	timeout   time.Duration
+	pauses    []time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+
+	log logger.BaseLogger
+}
+
+func CreateDispatcher(storage storage.BaseWebhooksStorage, client *http.Client, batchSize int, baseLogger logger.BaseLogger) Dispatcher {
+	return Dispatcher{
+		storage:   storage,
+		client:    client,
+		batchSize: batchSize,
+		timeout:   defDeliveryTimeout,
+		pauses:    defDeliveryPauses,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		log:       baseLogger,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context, interval int) {
+	d.log.Info("[webhooks:Dispatcher:Run] start deliveries dispatcher, interval '%d' seconds", interval)
+
+	go d.dispatchDeliveries(ctx, time.Duration(interval))
+}
+
+// Shutdown stops dispatcher after the current batch and waits for it till ctx is done.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	close(d.stop)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-d.done:
+		return nil
+	}
+}
+
+func (d *Dispatcher) dispatchDeliveries(ctx context.Context, interval time.Duration) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(interval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.log.Info("[webhooks:Dispatcher:dispatchDeliveries] dispatcher task is stopping by context")
+			return
+		case <-d.stop:
+			d.log.Info("[webhooks:Dispatcher:dispatchDeliveries] dispatcher task is stopped")
+			return
+		case <-ticker.C:
+			d.sendDeliveries(ctx)
+		}
+	}
+}
+
+// sendDeliveries drains due deliveries by batches till dispatcher is stopped. Deliveries of batch are sent concurrently.
+func (d *Dispatcher) sendDeliveries(ctx context.Context) {
+	for ctx.Err() == nil && !d.isStopped() {
+		jobs, err := d.storage.ClaimDeliveries(ctx, d.batchSize, claimLease)
+		if err != nil {
+			d.log.Info("[webhooks:Dispatcher:sendDeliveries] failed to claim deliveries: %v", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range jobs {
+			wg.Add(1)
+			go func(job *data.DeliveryJob) {
+				defer wg.Done()
+				d.send(ctx, job)
+			}(&jobs[i])
+		}
+		wg.Wait()
+
+		if len(jobs) < d.batchSize {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) isStopped() bool {
+	select {
+	case <-d.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// send makes single delivery attempt and saves its result.
+func (d *Dispatcher) send(ctx context.Context, job *data.DeliveryJob) {
+	delivery := &job.Delivery
+	if job.Webhook.ID == 0 {
+		// webhook was deleted with its deliveries after claim.
+		return
+	}
+
+	statusCode, err := d.post(ctx, &job.Webhook, delivery)
+	if ctx.Err() != nil {
+		// claim lease expires and delivery is sent again later.
+		return
+	}
+
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	delivery.Error = ""
+	delivery.Delivered = false
+	delivery.NextAttemptAt = nil
+
+	switch {
+	case err != nil || statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests:
+		if err != nil {
+			delivery.Error = err.Error()
+		} else {
+			delivery.Error = fmt.Sprintf("webhook responded with status '%d'", statusCode)
+		}
+
+		if delivery.Attempts <= len(d.pauses) {
+			nextAttemptAt := time.Now().Add(d.pauses[delivery.Attempts-1])
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+	case statusCode >= http.StatusBadRequest:
+		// client error isn't repeated.
+		delivery.Error = fmt.Sprintf("webhook rejected delivery with status '%d'", statusCode)
+	default:
+		delivery.Delivered = true
+	}
+
+	if !delivery.Delivered {
+		d.log.Info("[webhooks:Dispatcher:send] failed to deliver event '%d' to webhook '%d', attempt '%d': %s", delivery.EventID, delivery.WebhookID, delivery.Attempts, delivery.Error)
+	}
+
+	if err = d.storage.UpdateDelivery(ctx, delivery); err != nil {
+		d.log.Info("[webhooks:Dispatcher:send] failed to save delivery '%d' result: %v", delivery.ID, err)
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, webhook *data.Webhook, delivery *data.Delivery) (int, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctxWithTimeout, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(data.HeaderEvent, delivery.EventType)
+	req.Header.Set(data.HeaderEventID, strconv.FormatInt(delivery.EventID, 10))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(data.HeaderTimestamp, timestamp)
+	req.Header.Set(data.HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request: %w", err)
+	}
+	defer func() {
+		if err = resp.Body.Close(); err != nil {
+			d.log.Info("[webhooks:Dispatcher:post] failed to close response body: %v", err)
+		}
+	}()
+
+	return resp.StatusCode, nil
+}
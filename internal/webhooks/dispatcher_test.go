@@ -0,0 +1,131 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_sendDeliveries(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := []byte(`{"id":7}`)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(data.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+		assert.Equal(t, Sign("secret", r.Header.Get(data.HeaderTimestamp), reqBody), r.Header.Get(data.HeaderSignature))
+		assert.Equal(t, outboxData.TypeOrderStatusChanged, r.Header.Get(data.HeaderEvent))
+		assert.Equal(t, "7", r.Header.Get(data.HeaderEventID))
+
+		switch r.URL.Path {
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/rejected":
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	createJob := func(id int64, path string, attempts int) data.DeliveryJob {
+		return data.DeliveryJob{
+			Delivery: data.Delivery{ID: id, WebhookID: id, UserID: 1, EventID: 7, EventType: outboxData.TypeOrderStatusChanged, Payload: body, Attempts: attempts},
+			Webhook:  data.Webhook{ID: id, UserID: 1, URL: ts.URL + path, Secret: "secret"},
+		}
+	}
+	jobs := []data.DeliveryJob{
+		createJob(1, "/ok", 0),
+		createJob(2, "/unavailable", 0),
+		createJob(3, "/unavailable", 2),
+		createJob(4, "/rejected", 0),
+		{Delivery: data.Delivery{ID: 5, WebhookID: 5}},
+	}
+
+	var mu sync.Mutex
+	updated := make(map[int64]data.Delivery)
+	updateDelivery := func(_ context.Context, delivery *data.Delivery) error {
+		mu.Lock()
+		defer mu.Unlock()
+		updated[delivery.ID] = *delivery
+		return nil
+	}
+
+	mockStorage := mocks.NewMockBaseWebhooksStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().ClaimDeliveries(gomock.Any(), 10, claimLease).Return(jobs, nil),
+		mockStorage.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(updateDelivery).Times(4),
+	)
+
+	d := &Dispatcher{
+		storage:   mockStorage,
+		client:    ts.Client(),
+		batchSize: 10,
+		timeout:   time.Second,
+		pauses:    []time.Duration{time.Minute, time.Hour},
+		log:       log,
+	}
+	d.sendDeliveries(context.Background())
+
+	require.Len(t, updated, 4)
+
+	assert.True(t, updated[1].Delivered)
+	assert.Equal(t, 1, updated[1].Attempts)
+	assert.Equal(t, http.StatusOK, updated[1].StatusCode)
+	assert.Nil(t, updated[1].NextAttemptAt)
+
+	// server error is rescheduled with pause of the attempt.
+	assert.False(t, updated[2].Delivered)
+	assert.Equal(t, 1, updated[2].Attempts)
+	assert.NotEmpty(t, updated[2].Error)
+	require.NotNil(t, updated[2].NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), *updated[2].NextAttemptAt, 5*time.Second)
+
+	// attempts are exhausted.
+	assert.False(t, updated[3].Delivered)
+	assert.Equal(t, 3, updated[3].Attempts)
+	assert.Nil(t, updated[3].NextAttemptAt)
+
+	// client error isn't repeated.
+	assert.False(t, updated[4].Delivered)
+	assert.Equal(t, http.StatusGone, updated[4].StatusCode)
+	assert.Nil(t, updated[4].NextAttemptAt)
+}
+
+func TestDispatcher_Shutdown(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	d := CreateDispatcher(mocks.NewMockBaseWebhooksStorage(ctrl), http.DefaultClient, 2, log)
+	d.Run(context.Background(), 1)
+
+	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	require.NoError(t, d.Shutdown(ctxShutdown))
+
+	// stopped dispatcher doesn't claim new batches.
+	d.sendDeliveries(context.Background())
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+)
+
+// AddWebhook registers user's webhook. Response contains signing secret, it is not shown later.
+func AddWebhook(strg storage.BaseWebhooksStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := auth.GetUserIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[webhooks:handlers:AddWebhook] failed to extract userID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		buf := bytes.Buffer{}
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			log.Info("[webhooks:handlers:AddWebhook] failed to read request body: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer helpers.ExecuteWithLogError(r.Body.Close, log)
+
+		var webhookReq data.WebhookRequest
+		if err = json.Unmarshal(buf.Bytes(), &webhookReq); err != nil {
+			log.Info("[webhooks:handlers:AddWebhook] failed to unmarshal request body: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := strg.AddWebhook(r.Context(), userID, webhookReq.URL)
+		if err != nil {
+			switch {
+			case errors.Is(err, data.ErrInvalidURL):
+				w.WriteHeader(http.StatusBadRequest)
+			case errors.Is(err, data.ErrWebhookExists):
+				w.WriteHeader(http.StatusConflict)
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[webhooks:handlers:AddWebhook] failed to add webhook: %v", err)
+			return
+		}
+
+		respBody, err := json.Marshal(webhook)
+		if err != nil {
+			log.Info("[webhooks:handlers:AddWebhook] failed convert webhook to JSON: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(respBody)))
+		w.WriteHeader(http.StatusCreated)
+		if _, err := w.Write(respBody); err != nil {
+			log.Info("[webhooks:handlers:AddWebhook] failed to write response body: %v", err)
+		}
+	}
+}
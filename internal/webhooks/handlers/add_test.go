@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddWebhook(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhook := &data.Webhook{
+		ID:        1,
+		UserID:    1,
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	mockStorage := mocks.NewMockBaseWebhooksStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().AddWebhook(gomock.Any(), int64(1), "https://example.com/hook").Return(webhook, nil),
+		mockStorage.EXPECT().AddWebhook(gomock.Any(), int64(1), "ftp://example.com").Return(nil, fmt.Errorf("add: %w", data.ErrInvalidURL)),
+		mockStorage.EXPECT().AddWebhook(gomock.Any(), int64(1), "https://example.com/hook").Return(nil, fmt.Errorf("add: %w", data.ErrWebhookExists)),
+		mockStorage.EXPECT().AddWebhook(gomock.Any(), int64(1), "https://example.com/hook").Return(nil, fmt.Errorf("storage error")),
+	)
+
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		AddWebhook(mockStorage, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+
+	type args struct {
+		withUserIDinContext bool
+		body                []byte
+	}
+	type want struct {
+		statusCode int
+		body       []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte(`{"url":"https://example.com/hook"}`),
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				body:       []byte(`{"id":1,"url":"https://example.com/hook","secret":"secret","created_at":"2024-01-01T00:00:00Z"}`),
+			},
+		},
+		{
+			name: "without userID in context",
+			args: args{
+				withUserIDinContext: false,
+				body:                []byte(`{"url":"https://example.com/hook"}`),
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "incorrect request body",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte(`{"url":`),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "invalid url",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte(`{"url":"ftp://example.com"}`),
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "webhook exists",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte(`{"url":"https://example.com/hook"}`),
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "storage error",
+			args: args{
+				withUserIDinContext: true,
+				body:                []byte(`{"url":"https://example.com/hook"}`),
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts *httptest.Server
+			if tt.args.withUserIDinContext {
+				ts = httptest.NewServer(handlerFunc)
+			} else {
+				ts = httptest.NewServer(AddWebhook(mockStorage, log))
+			}
+			defer ts.Close()
+
+			req, errReq := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBuffer(tt.args.body))
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.want.body), string(respBody))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+	"github.com/go-chi/chi/v5"
+)
+
+const URLParamWebhookID = "id"
+
+func DeleteWebhook(strg storage.BaseWebhooksStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := auth.GetUserIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[webhooks:handlers:DeleteWebhook] failed to extract userID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, URLParamWebhookID), 10, 64)
+		if err != nil {
+			log.Info("[webhooks:handlers:DeleteWebhook] failed to parse webhook id: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err = strg.DeleteWebhook(r.Context(), userID, id); err != nil {
+			if errors.Is(err, data.ErrWebhookNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[webhooks:handlers:DeleteWebhook] failed to delete webhook: %v", err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
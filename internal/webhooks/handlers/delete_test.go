@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteWebhook(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockBaseWebhooksStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().DeleteWebhook(gomock.Any(), int64(1), int64(3)).Return(nil),
+		mockStorage.EXPECT().DeleteWebhook(gomock.Any(), int64(1), int64(4)).Return(fmt.Errorf("delete: %w", data.ErrWebhookNotFound)),
+		mockStorage.EXPECT().DeleteWebhook(gomock.Any(), int64(1), int64(3)).Return(fmt.Errorf("storage error")),
+	)
+
+	router := chi.NewRouter()
+	router.Delete("/{"+URLParamWebhookID+"}", func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		DeleteWebhook(mockStorage, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		id             string
+		wantStatusCode int
+	}{
+		{
+			name:           "valid",
+			id:             "3",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "incorrect id",
+			id:             "abc",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "missing webhook",
+			id:             "4",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "storage error",
+			id:             "3",
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, errReq := http.NewRequest(http.MethodDelete, ts.URL+"/"+tt.id, nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+		})
+	}
+}
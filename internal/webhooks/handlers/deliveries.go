@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+)
+
+func GetDeliveries(strg storage.BaseWebhooksStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := auth.GetUserIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[webhooks:handlers:GetDeliveries] failed to extract userID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		deliveries, err := strg.GetDeliveries(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, data.ErrDeliveriesMissing) {
+				w.WriteHeader(http.StatusNoContent)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[webhooks:handlers:GetDeliveries] failed to get deliveries: %v", err)
+			return
+		}
+
+		respBody, err := json.Marshal(deliveries)
+		if err != nil {
+			log.Info("[webhooks:handlers:GetDeliveries] failed convert deliveries to JSON: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
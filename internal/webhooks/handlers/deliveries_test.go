@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDeliveries(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveries := []data.Delivery{
+		{
+			ID:         2,
+			WebhookID:  1,
+			UserID:     1,
+			EventID:    10,
+			EventType:  "order.status_changed",
+			Attempts:   1,
+			StatusCode: http.StatusOK,
+			Delivered:  true,
+			CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	mockStorage := mocks.NewMockBaseWebhooksStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().GetDeliveries(gomock.Any(), int64(1)).Return(deliveries, nil),
+		mockStorage.EXPECT().GetDeliveries(gomock.Any(), int64(1)).Return(nil, data.ErrDeliveriesMissing),
+		mockStorage.EXPECT().GetDeliveries(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("storage error")),
+	)
+
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		GetDeliveries(mockStorage, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+
+	type args struct {
+		withUserIDinContext bool
+	}
+	type want struct {
+		statusCode int
+		body       []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid",
+			args: args{
+				withUserIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       []byte(`[{"id":2,"webhook_id":1,"event_id":10,"event_type":"order.status_changed","attempts":1,"status_code":200,"delivered":true,"created_at":"2024-01-01T00:00:00Z"}]`),
+			},
+		},
+		{
+			name: "without userID in context",
+			args: args{
+				withUserIDinContext: false,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "user without deliveries",
+			args: args{
+				withUserIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusNoContent,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "storage error",
+			args: args{
+				withUserIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts *httptest.Server
+			if tt.args.withUserIDinContext {
+				ts = httptest.NewServer(handlerFunc)
+			} else {
+				ts = httptest.NewServer(GetDeliveries(mockStorage, log))
+			}
+			defer ts.Close()
+
+			req, errReq := http.NewRequest(http.MethodGet, ts.URL, nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.want.body), string(respBody))
+		})
+	}
+}
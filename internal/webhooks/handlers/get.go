@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/erupshis/bonusbridge/internal/auth"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+)
+
+func GetWebhooks(strg storage.BaseWebhooksStorage, log logger.BaseLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := auth.GetUserIDFromContext(r.Context())
+		if err != nil {
+			log.Info("[webhooks:handlers:GetWebhooks] failed to extract userID: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		webhooks, err := strg.GetWebhooks(r.Context(), userID)
+		if err != nil {
+			if errors.Is(err, data.ErrWebhooksMissing) {
+				w.WriteHeader(http.StatusNoContent)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			log.Info("[webhooks:handlers:GetWebhooks] failed to get webhooks: %v", err)
+			return
+		}
+
+		for i := range webhooks {
+			webhooks[i].Secret = ""
+		}
+
+		respBody, err := json.Marshal(webhooks)
+		if err != nil {
+			log.Info("[webhooks:handlers:GetWebhooks] failed convert webhooks to JSON: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, respBody, log)
+	}
+}
+
+// writeJSON writes response body with status OK.
+func writeJSON(w http.ResponseWriter, respBody []byte, log logger.BaseLogger) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(respBody)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(respBody); err != nil {
+		log.Info("[webhooks:handlers:writeJSON] failed to write response body: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/auth/middleware"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWebhooks(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhooks := []data.Webhook{
+		{
+			ID:        1,
+			UserID:    1,
+			URL:       "https://example.com/hook",
+			Secret:    "secret",
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	mockStorage := mocks.NewMockBaseWebhooksStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().GetWebhooks(gomock.Any(), int64(1)).Return(webhooks, nil),
+		mockStorage.EXPECT().GetWebhooks(gomock.Any(), int64(1)).Return(nil, data.ErrWebhooksMissing),
+		mockStorage.EXPECT().GetWebhooks(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("storage error")),
+	)
+
+	handlerFunc := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxWithValue := context.WithValue(r.Context(), middleware.ContextString("userID"), fmt.Sprintf("%d", 1))
+		GetWebhooks(mockStorage, log).ServeHTTP(w, r.WithContext(ctxWithValue))
+	})
+
+	type args struct {
+		withUserIDinContext bool
+	}
+	type want struct {
+		statusCode int
+		body       []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "valid, secret is hidden",
+			args: args{
+				withUserIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       []byte(`[{"id":1,"url":"https://example.com/hook","created_at":"2024-01-01T00:00:00Z"}]`),
+			},
+		},
+		{
+			name: "without userID in context",
+			args: args{
+				withUserIDinContext: false,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "user without webhooks",
+			args: args{
+				withUserIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusNoContent,
+				body:       []byte(""),
+			},
+		},
+		{
+			name: "storage error",
+			args: args{
+				withUserIDinContext: true,
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       []byte(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts *httptest.Server
+			if tt.args.withUserIDinContext {
+				ts = httptest.NewServer(handlerFunc)
+			} else {
+				ts = httptest.NewServer(GetWebhooks(mockStorage, log))
+			}
+			defer ts.Close()
+
+			req, errReq := http.NewRequest(http.MethodGet, ts.URL, nil)
+			require.NoError(t, errReq)
+
+			resp, errResp := ts.Client().Do(req)
+			require.NoError(t, errResp)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode)
+
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.want.body), string(respBody))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package managers
+
+import (
+	"context"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+//go:generate mockgen -destination=../../../mocks/mock_BaseWebhooksManager.go -package=mocks github.com/erupshis/bonusbridge/internal/webhooks/managers BaseWebhooksManager
+type BaseWebhooksManager interface {
+	AddWebhook(ctx context.Context, webhook *data.Webhook) (int64, error)
+	GetWebhooks(ctx context.Context, userID int64) ([]data.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID int64, id int64) (bool, error)
+
+	AddDeliveries(ctx context.Context, deliveries []data.Delivery) error
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]data.DeliveryJob, error)
+	UpdateDelivery(ctx context.Context, delivery *data.Delivery) error
+	GetDeliveries(ctx context.Context, userID int64, limit int) ([]data.Delivery, error)
+}
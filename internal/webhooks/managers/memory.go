@@ -0,0 +1,189 @@
+package managers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db/memory"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+// memoryManager storageManager implementation over in-memory store.
+type memoryManager struct {
+	store *memory.Store
+
+	log logger.BaseLogger
+}
+
+// CreateInMemory creates manager implementation keeping webhooks in memory.
+func CreateInMemory(store *memory.Store, log logger.BaseLogger) BaseWebhooksManager {
+	return &memoryManager{
+		store: store,
+		log:   log,
+	}
+}
+
+// AddWebhook registers user's webhook. Returns -1 if user has already added webhook with the same url.
+func (p *memoryManager) AddWebhook(_ context.Context, webhook *data.Webhook) (int64, error) {
+	p.log.Info("[webhooks:memoryManager:AddWebhook] add webhook for userID '%d'", webhook.UserID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for _, existing := range p.store.Webhooks {
+		if existing.UserID == webhook.UserID && existing.URL == webhook.URL {
+			return -1, nil
+		}
+	}
+
+	newWebhook := *webhook
+	newWebhook.ID = p.store.NextID("webhooks")
+	p.store.Webhooks = append(p.store.Webhooks, newWebhook)
+	return newWebhook.ID, nil
+}
+
+func (p *memoryManager) GetWebhooks(_ context.Context, userID int64) ([]data.Webhook, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Webhook
+	for _, webhook := range p.store.Webhooks {
+		if webhook.UserID == userID {
+			res = append(res, webhook)
+		}
+	}
+	return res, nil
+}
+
+// DeleteWebhook removes user's webhook with its deliveries. Returns false if user doesn't have webhook with such id.
+func (p *memoryManager) DeleteWebhook(_ context.Context, userID int64, id int64) (bool, error) {
+	p.log.Info("[webhooks:memoryManager:DeleteWebhook] delete webhook '%d' of userID '%d'", id, userID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i, webhook := range p.store.Webhooks {
+		if webhook.ID != id || webhook.UserID != userID {
+			continue
+		}
+
+		p.store.Webhooks = append(p.store.Webhooks[:i], p.store.Webhooks[i+1:]...)
+
+		deliveries := p.store.Deliveries[:0]
+		for _, delivery := range p.store.Deliveries {
+			if delivery.WebhookID != id {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		p.store.Deliveries = deliveries
+		return true, nil
+	}
+	return false, nil
+}
+
+// AddDeliveries queues deliveries. Deliveries of event already queued for the webhook are skipped.
+func (p *memoryManager) AddDeliveries(_ context.Context, deliveries []data.Delivery) error {
+	p.log.Info("[webhooks:memoryManager:AddDeliveries] add '%d' deliveries", len(deliveries))
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range deliveries {
+		deliveries[i].ID = -1
+		if p.findDelivery(deliveries[i].WebhookID, deliveries[i].EventID) != -1 {
+			continue
+		}
+
+		deliveries[i].ID = p.store.NextID("webhook_deliveries")
+		p.store.Deliveries = append(p.store.Deliveries, deliveries[i])
+	}
+	return nil
+}
+
+// ClaimDeliveries selects up to limit queued deliveries which attempt time has come and postpones their next attempt
+// for lease.
+func (p *memoryManager) ClaimDeliveries(_ context.Context, limit int, lease time.Duration) ([]data.DeliveryJob, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	now := time.Now()
+	var due []*data.Delivery
+	for i := range p.store.Deliveries {
+		if nextAttemptAt := p.store.Deliveries[i].NextAttemptAt; nextAttemptAt != nil && !nextAttemptAt.After(now) {
+			due = append(due, &p.store.Deliveries[i])
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(*due[j].NextAttemptAt) })
+
+	var res []data.DeliveryJob
+	for _, delivery := range due {
+		if len(res) == limit {
+			break
+		}
+
+		leaseEnd := now.Add(lease)
+		delivery.NextAttemptAt = &leaseEnd
+		res = append(res, data.DeliveryJob{Delivery: *delivery, Webhook: p.findWebhook(delivery.WebhookID)})
+	}
+
+	p.log.Info("[webhooks:memoryManager:ClaimDeliveries] '%d' deliveries claimed", len(res))
+	return res, nil
+}
+
+// UpdateDelivery saves delivery attempt result. Delivery without next attempt time is removed from queue.
+func (p *memoryManager) UpdateDelivery(_ context.Context, delivery *data.Delivery) error {
+	p.log.Info("[webhooks:memoryManager:UpdateDelivery] update delivery '%d'", delivery.ID)
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	for i := range p.store.Deliveries {
+		if stored := &p.store.Deliveries[i]; stored.ID == delivery.ID {
+			stored.Attempts = delivery.Attempts
+			stored.StatusCode = delivery.StatusCode
+			stored.Error = delivery.Error
+			stored.Delivered = delivery.Delivered
+			stored.NextAttemptAt = delivery.NextAttemptAt
+			break
+		}
+	}
+	return nil
+}
+
+// GetDeliveries returns up to limit latest user's deliveries. Zero limit means no limit.
+func (p *memoryManager) GetDeliveries(_ context.Context, userID int64, limit int) ([]data.Delivery, error) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	var res []data.Delivery
+	for i := len(p.store.Deliveries) - 1; i >= 0; i-- {
+		if limit > 0 && len(res) == limit {
+			break
+		}
+
+		if p.store.Deliveries[i].UserID == userID {
+			delivery := p.store.Deliveries[i]
+			delivery.Payload = nil
+			res = append(res, delivery)
+		}
+	}
+	return res, nil
+}
+
+// findDelivery returns index of webhook's delivery of event or -1. Must be called under lock.
+func (p *memoryManager) findDelivery(webhookID int64, eventID int64) int {
+	for i := range p.store.Deliveries {
+		if p.store.Deliveries[i].WebhookID == webhookID && p.store.Deliveries[i].EventID == eventID {
+			return i
+		}
+	}
+	return -1
+}
+
+// findWebhook returns webhook by id. Must be called under lock.
+func (p *memoryManager) findWebhook(id int64) data.Webhook {
+	for _, webhook := range p.store.Webhooks {
+		if webhook.ID == id {
+			return webhook
+		}
+	}
+	return data.Webhook{}
+}
@@ -0,0 +1,233 @@
+package managers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/db"
+	"github.com/erupshis/bonusbridge/internal/db/queries/webhooks"
+	"github.com/erupshis/bonusbridge/internal/helpers"
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+// manager storageManager implementation for PostgreSQL.
+type manager struct {
+	*db.Conn
+
+	log logger.BaseLogger
+}
+
+// Create creates manager implementation.
+func Create(dbConn *db.Conn, log logger.BaseLogger) BaseWebhooksManager {
+	return &manager{
+		Conn: dbConn,
+		log:  log,
+	}
+}
+
+// AddWebhook adds user's webhook. Returns -1 if user already has webhook with the same url.
+func (p *manager) AddWebhook(ctx context.Context, webhook *data.Webhook) (int64, error) {
+	p.log.Info("[webhooks:manager:AddWebhook] start transaction for userID '%d'", webhook.UserID)
+	errMsg := "add webhook in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	id, err := webhooks.Insert(ctx, tx, webhook, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return -1, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:AddWebhook] transaction successful")
+	return id, nil
+}
+
+func (p *manager) GetWebhooks(ctx context.Context, userID int64) ([]data.Webhook, error) {
+	p.log.Info("[webhooks:manager:GetWebhooks] start transaction for userID '%d'", userID)
+	errMsg := "get webhooks from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	webhooksArr, err := webhooks.SelectByUserID(ctx, tx, userID, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:GetWebhooks] transaction successful")
+	return webhooksArr, nil
+}
+
+// DeleteWebhook removes user's webhook. Returns false if user doesn't have webhook with such id.
+func (p *manager) DeleteWebhook(ctx context.Context, userID int64, id int64) (bool, error) {
+	p.log.Info("[webhooks:manager:DeleteWebhook] start transaction for userID '%d', webhook '%d'", userID, id)
+	errMsg := "delete webhook from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	deleted, err := webhooks.DeleteByID(ctx, tx, userID, id, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:DeleteWebhook] transaction successful")
+	return deleted, nil
+}
+
+// AddDeliveries queues deliveries. Deliveries of event already queued for the webhook are skipped.
+func (p *manager) AddDeliveries(ctx context.Context, deliveries []data.Delivery) error {
+	p.log.Info("[webhooks:manager:AddDeliveries] start transaction for '%d' deliveries", len(deliveries))
+	errMsg := "add webhooks deliveries in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	for i := range deliveries {
+		deliveries[i].ID, err = webhooks.InsertDelivery(ctx, tx, &deliveries[i], p.log)
+		if err != nil {
+			helpers.ExecuteWithLogError(tx.Rollback, p.log)
+			return fmt.Errorf(errMsg, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:AddDeliveries] transaction successful")
+	return nil
+}
+
+// ClaimDeliveries selects up to limit queued deliveries which attempt time has come and postpones their next attempt
+// for lease. Claimed deliveries are skipped by other instances till the lease expires or delivery is updated.
+func (p *manager) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]data.DeliveryJob, error) {
+	p.log.Info("[webhooks:manager:ClaimDeliveries] start transaction")
+	errMsg := "claim webhooks deliveries in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	now := time.Now()
+	deliveries, err := webhooks.SelectDueDeliveries(ctx, tx, p.Dialect, now, limit, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if len(deliveries) == 0 {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, nil
+	}
+
+	leaseEnd := sql.NullTime{Time: now.Add(lease), Valid: true}
+	webhooksIDs := make([]int64, 0, len(deliveries))
+	for i := range deliveries {
+		if err = webhooks.UpdateDeliveryByID(ctx, tx, deliveries[i].ID, webhooks.DeliveryValues{NextAttemptAt: &leaseEnd}, p.log); err != nil {
+			helpers.ExecuteWithLogError(tx.Rollback, p.log)
+			return nil, fmt.Errorf(errMsg, err)
+		}
+
+		webhooksIDs = append(webhooksIDs, deliveries[i].WebhookID)
+	}
+
+	webhooksSelected, err := webhooks.SelectByIDs(ctx, tx, webhooksIDs, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	webhooksByID := make(map[int64]data.Webhook, len(webhooksSelected))
+	for _, webhook := range webhooksSelected {
+		webhooksByID[webhook.ID] = webhook
+	}
+	jobs := make([]data.DeliveryJob, 0, len(deliveries))
+	for i := range deliveries {
+		jobs = append(jobs, data.DeliveryJob{Delivery: deliveries[i], Webhook: webhooksByID[deliveries[i].WebhookID]})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:ClaimDeliveries] transaction successful, '%d' deliveries claimed", len(jobs))
+	return jobs, nil
+}
+
+// UpdateDelivery saves delivery attempt result. Delivery without next attempt time is removed from queue.
+func (p *manager) UpdateDelivery(ctx context.Context, delivery *data.Delivery) error {
+	p.log.Info("[webhooks:manager:UpdateDelivery] start transaction for delivery '%d'", delivery.ID)
+	errMsg := "update webhook delivery in db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	nextAttemptAt := sql.NullTime{}
+	if delivery.NextAttemptAt != nil {
+		nextAttemptAt = sql.NullTime{Time: *delivery.NextAttemptAt, Valid: true}
+	}
+	values := webhooks.DeliveryValues{
+		Attempts:      &delivery.Attempts,
+		StatusCode:    &delivery.StatusCode,
+		Error:         &delivery.Error,
+		Delivered:     &delivery.Delivered,
+		NextAttemptAt: &nextAttemptAt,
+	}
+	if err = webhooks.UpdateDeliveryByID(ctx, tx, delivery.ID, values, p.log); err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:UpdateDelivery] transaction successful")
+	return nil
+}
+
+func (p *manager) GetDeliveries(ctx context.Context, userID int64, limit int) ([]data.Delivery, error) {
+	p.log.Info("[webhooks:manager:GetDeliveries] start transaction for userID '%d'", userID)
+	errMsg := "get webhooks deliveries from db: %w"
+	tx, err := p.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	deliveries, err := webhooks.SelectDeliveriesByUserID(ctx, tx, userID, limit, p.log)
+	if err != nil {
+		helpers.ExecuteWithLogError(tx.Rollback, p.log)
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	p.log.Info("[webhooks:manager:GetDeliveries] transaction successful")
+	return deliveries, nil
+}
@@ -0,0 +1,78 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/outbox/sinks"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/storage"
+	"github.com/mailru/easyjson"
+)
+
+// sink queues outbox events deliveries to webhooks of event's user. Queued deliveries are sent by Dispatcher.
+type sink struct {
+	storage storage.BaseWebhooksStorage
+
+	log logger.BaseLogger
+}
+
+func CreateSink(storage storage.BaseWebhooksStorage, log logger.BaseLogger) sinks.BaseSink {
+	return &sink{
+		storage: storage,
+		log:     log,
+	}
+}
+
+// Publish queues event delivery to every user's webhook. Webhooks are not requested here, so slow or unavailable
+// webhook doesn't hold outbox relay.
+func (s *sink) Publish(ctx context.Context, event *outboxData.Event) error {
+	webhooks, err := s.storage.GetWebhooks(ctx, event.UserID)
+	if err != nil {
+		if errors.Is(err, data.ErrWebhooksMissing) {
+			return nil
+		}
+		return fmt.Errorf("publish event '%d' to webhooks: %w", event.ID, err)
+	}
+
+	body, err := easyjson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event '%d': %w", event.ID, err)
+	}
+
+	now := time.Now()
+	deliveries := make([]data.Delivery, 0, len(webhooks))
+	for i := range webhooks {
+		deliveries = append(deliveries, data.Delivery{
+			WebhookID:     webhooks[i].ID,
+			UserID:        webhooks[i].UserID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       body,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		})
+	}
+
+	if err = s.storage.AddDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("publish event '%d' to webhooks: %w", event.ID, err)
+	}
+
+	return nil
+}
+
+// Sign generates HeaderSignature value for request body sent at timestamp (HeaderTimestamp value).
+// Receivers verify it with their webhook secret.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return data.SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	outboxData "github.com/erupshis/bonusbridge/internal/outbox/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink_Publish(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := &outboxData.Event{ID: 7, UserID: 1, Type: outboxData.TypeOrderStatusChanged, Payload: []byte(`{"number":"1"}`)}
+	body, err := easyjson.Marshal(event)
+	require.NoError(t, err)
+
+	webhooks := []data.Webhook{
+		{ID: 1, UserID: 1, URL: "https://example.com/ok", Secret: "secret"},
+		{ID: 2, UserID: 1, URL: "https://example.com/rejected", Secret: "secret"},
+	}
+
+	var deliveries []data.Delivery
+	addDeliveries := func(_ context.Context, added []data.Delivery) error {
+		deliveries = append(deliveries, added...)
+		return nil
+	}
+
+	mockStorage := mocks.NewMockBaseWebhooksStorage(ctrl)
+	gomock.InOrder(
+		mockStorage.EXPECT().GetWebhooks(gomock.Any(), int64(1)).Return(webhooks, nil),
+		mockStorage.EXPECT().AddDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(addDeliveries),
+
+		mockStorage.EXPECT().GetWebhooks(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("get: %w", data.ErrWebhooksMissing)),
+
+		mockStorage.EXPECT().GetWebhooks(gomock.Any(), int64(1)).Return(webhooks[:1], nil),
+		mockStorage.EXPECT().AddDeliveries(gomock.Any(), gomock.Any()).Return(fmt.Errorf("storage error")),
+	)
+
+	s := &sink{
+		storage: mockStorage,
+		log:     log,
+	}
+
+	// deliveries are queued for every webhook, nothing is sent.
+	require.NoError(t, s.Publish(context.Background(), event))
+	require.Len(t, deliveries, 2)
+	for i, delivery := range deliveries {
+		assert.Equal(t, webhooks[i].ID, delivery.WebhookID)
+		assert.Equal(t, int64(7), delivery.EventID)
+		assert.Equal(t, event.Type, delivery.EventType)
+		assert.Equal(t, body, delivery.Payload)
+		assert.Zero(t, delivery.Attempts)
+		assert.False(t, delivery.Delivered)
+		require.NotNil(t, delivery.NextAttemptAt)
+	}
+
+	// user without webhooks.
+	assert.NoError(t, s.Publish(context.Background(), event))
+
+	// queue failure is returned to outbox relay.
+	assert.Error(t, s.Publish(context.Background(), event))
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":7}`)
+
+	signature := Sign("secret", "1700000000", body)
+	assert.Equal(t, "sha256=26dca72ca0eb8becc44b5f7ac37ee5f37b669a7b6c18a3ea312ca3e1d15b6767", signature)
+
+	// replayed body with another timestamp doesn't match the signature.
+	assert.NotEqual(t, signature, Sign("secret", "1700000300", body))
+}
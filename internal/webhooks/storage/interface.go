@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+)
+
+//go:generate mockgen -destination=../../../mocks/mock_BaseWebhooksStorage.go -package=mocks github.com/erupshis/bonusbridge/internal/webhooks/storage BaseWebhooksStorage
+type BaseWebhooksStorage interface {
+	AddWebhook(ctx context.Context, userID int64, webhookURL string) (*data.Webhook, error)
+	GetWebhooks(ctx context.Context, userID int64) ([]data.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID int64, id int64) error
+
+	AddDeliveries(ctx context.Context, deliveries []data.Delivery) error
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]data.DeliveryJob, error)
+	UpdateDelivery(ctx context.Context, delivery *data.Delivery) error
+	GetDeliveries(ctx context.Context, userID int64) ([]data.Delivery, error)
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/internal/webhooks/managers"
+	"github.com/erupshis/bonusbridge/internal/webhooks/target"
+)
+
+// deliveriesLimit count of latest deliveries returned to user.
+const deliveriesLimit = 100
+
+// secretLength length of webhook signing secret in bytes.
+const secretLength = 32
+
+type Storage struct {
+	manager  managers.BaseWebhooksManager
+	resolver target.Resolver
+
+	log logger.BaseLogger
+}
+
+func Create(manager managers.BaseWebhooksManager, baseLogger logger.BaseLogger) BaseWebhooksStorage {
+	return &Storage{
+		manager:  manager,
+		resolver: net.DefaultResolver,
+		log:      baseLogger,
+	}
+}
+
+// AddWebhook registers user's webhook with newly generated signing secret.
+func (s *Storage) AddWebhook(ctx context.Context, userID int64, webhookURL string) (*data.Webhook, error) {
+	errMsg := fmt.Sprintf("add userID '%d' webhook", userID) + ": %w"
+
+	if err := target.CheckURL(ctx, s.resolver, webhookURL); err != nil {
+		return nil, fmt.Errorf(errMsg, fmt.Errorf("'%s': %w: %v", webhookURL, data.ErrInvalidURL, err))
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	webhook := &data.Webhook{
+		UserID:    userID,
+		URL:       webhookURL,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+	webhook.ID, err = s.manager.AddWebhook(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf(errMsg, err)
+	}
+
+	if webhook.ID == -1 {
+		return nil, fmt.Errorf(errMsg, data.ErrWebhookExists)
+	}
+
+	return webhook, nil
+}
+
+func (s *Storage) GetWebhooks(ctx context.Context, userID int64) ([]data.Webhook, error) {
+	webhooks, err := s.manager.GetWebhooks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get userID '%d' webhooks: %w", userID, err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil, fmt.Errorf("get userID '%d' webhooks: %w", userID, data.ErrWebhooksMissing)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Storage) DeleteWebhook(ctx context.Context, userID int64, id int64) error {
+	deleted, err := s.manager.DeleteWebhook(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("delete userID '%d' webhook '%d': %w", userID, id, err)
+	}
+
+	if !deleted {
+		return fmt.Errorf("delete userID '%d' webhook '%d': %w", userID, id, data.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// AddDeliveries queues event's deliveries to webhooks.
+func (s *Storage) AddDeliveries(ctx context.Context, deliveries []data.Delivery) error {
+	if err := s.manager.AddDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("add webhooks deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDeliveries returns up to limit queued deliveries which attempt time has come. Claimed deliveries
+// are not returned again till lease expires.
+func (s *Storage) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]data.DeliveryJob, error) {
+	jobs, err := s.manager.ClaimDeliveries(ctx, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claim webhooks deliveries: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// UpdateDelivery saves delivery attempt result and its next attempt time.
+func (s *Storage) UpdateDelivery(ctx context.Context, delivery *data.Delivery) error {
+	if err := s.manager.UpdateDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("update webhook '%d' delivery '%d': %w", delivery.WebhookID, delivery.ID, err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns user's latest webhooks deliveries, the newest first.
+func (s *Storage) GetDeliveries(ctx context.Context, userID int64) ([]data.Delivery, error) {
+	deliveries, err := s.manager.GetDeliveries(ctx, userID, deliveriesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("get userID '%d' webhooks deliveries: %w", userID, err)
+	}
+
+	if len(deliveries) == 0 {
+		return nil, fmt.Errorf("get userID '%d' webhooks deliveries: %w", userID, data.ErrDeliveriesMissing)
+	}
+
+	return deliveries, nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
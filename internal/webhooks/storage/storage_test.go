@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/erupshis/bonusbridge/internal/logger"
+	"github.com/erupshis/bonusbridge/internal/webhooks/data"
+	"github.com/erupshis/bonusbridge/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResolver map[string]string
+
+func (r stubResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ip, ok := r[host]
+	if !ok {
+		return nil, fmt.Errorf("no such host")
+	}
+	return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+}
+
+func TestStorage_AddWebhook(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockBaseWebhooksManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).Return(int64(1), nil),
+		mockManager.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).Return(int64(-1), nil),
+		mockManager.EXPECT().AddWebhook(gomock.Any(), gomock.Any()).Return(int64(-1), fmt.Errorf("manager error")),
+	)
+
+	tests := []struct {
+		name      string
+		url       string
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name:    "valid",
+			url:     "https://example.com/hook",
+			wantErr: false,
+		},
+		{
+			name:      "webhook exists",
+			url:       "https://example.com/hook",
+			wantErr:   true,
+			wantErrIs: data.ErrWebhookExists,
+		},
+		{
+			name:    "manager error",
+			url:     "http://example.com/hook",
+			wantErr: true,
+		},
+		{
+			name:      "unsupported scheme",
+			url:       "ftp://example.com/hook",
+			wantErr:   true,
+			wantErrIs: data.ErrInvalidURL,
+		},
+		{
+			name:      "relative url",
+			url:       "/hook",
+			wantErr:   true,
+			wantErrIs: data.ErrInvalidURL,
+		},
+		{
+			name:      "empty url",
+			url:       "",
+			wantErr:   true,
+			wantErrIs: data.ErrInvalidURL,
+		},
+		{
+			name:      "loopback host",
+			url:       "http://localhost:8080/hook",
+			wantErr:   true,
+			wantErrIs: data.ErrInvalidURL,
+		},
+		{
+			name:      "cloud metadata address",
+			url:       "http://169.254.169.254/latest/meta-data",
+			wantErr:   true,
+			wantErrIs: data.ErrInvalidURL,
+		},
+		{
+			name:      "private network address",
+			url:       "http://192.168.0.10/hook",
+			wantErr:   true,
+			wantErrIs: data.ErrInvalidURL,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Storage{
+				manager:  mockManager,
+				resolver: stubResolver{"example.com": "93.184.216.34", "localhost": "127.0.0.1"},
+				log:      log,
+			}
+			webhook, err := s.AddWebhook(context.Background(), 1, tt.url)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), webhook.ID)
+			assert.Equal(t, tt.url, webhook.URL)
+			assert.Len(t, webhook.Secret, 2*secretLength)
+		})
+	}
+}
+
+func TestStorage_DeleteWebhook(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockBaseWebhooksManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().DeleteWebhook(gomock.Any(), int64(1), int64(2)).Return(true, nil),
+		mockManager.EXPECT().DeleteWebhook(gomock.Any(), int64(1), int64(2)).Return(false, nil),
+		mockManager.EXPECT().DeleteWebhook(gomock.Any(), int64(1), int64(2)).Return(false, fmt.Errorf("manager error")),
+	)
+
+	s := Create(mockManager, log)
+	assert.NoError(t, s.DeleteWebhook(context.Background(), 1, 2))
+	assert.ErrorIs(t, s.DeleteWebhook(context.Background(), 1, 2), data.ErrWebhookNotFound)
+	assert.Error(t, s.DeleteWebhook(context.Background(), 1, 2))
+}
+
+func TestStorage_GetDeliveries(t *testing.T) {
+	log, _ := logger.CreateZapLogger("info")
+	defer log.Sync()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveries := []data.Delivery{{ID: 1, WebhookID: 1, UserID: 1, EventID: 1, Delivered: true}}
+
+	mockManager := mocks.NewMockBaseWebhooksManager(ctrl)
+	gomock.InOrder(
+		mockManager.EXPECT().GetDeliveries(gomock.Any(), int64(1), deliveriesLimit).Return(deliveries, nil),
+		mockManager.EXPECT().GetDeliveries(gomock.Any(), int64(1), deliveriesLimit).Return(nil, nil),
+		mockManager.EXPECT().GetDeliveries(gomock.Any(), int64(1), deliveriesLimit).Return(nil, fmt.Errorf("manager error")),
+	)
+
+	s := Create(mockManager, log)
+
+	got, err := s.GetDeliveries(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, deliveries, got)
+
+	_, err = s.GetDeliveries(context.Background(), 1)
+	assert.ErrorIs(t, err, data.ErrDeliveriesMissing)
+
+	_, err = s.GetDeliveries(context.Background(), 1)
+	assert.Error(t, err)
+}
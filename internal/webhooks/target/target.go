@@ -0,0 +1,110 @@
+// Package target restricts webhooks destinations to public internet addresses.
+// Checks are done on webhook registration and on every connection, so DNS rebinding doesn't bypass them.
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress webhook points to loopback, private, link-local or unspecified address.
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// Resolver resolves webhook host into IP addresses. Implemented by *net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// IsAllowed checks if ip belongs to public unicast addresses.
+func IsAllowed(ip net.IP) bool {
+	return ip != nil &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// CheckURL validates webhook url: http/https scheme, non-empty host and public addresses only.
+// All addresses of host are checked, url is rejected if any of them is not allowed.
+func CheckURL(ctx context.Context, resolver Resolver, webhookURL string) error {
+	parsedURL, err := url.ParseRequestURI(webhookURL)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme '%s'", parsedURL.Scheme)
+	}
+
+	host := parsedURL.Hostname()
+	if host == "" {
+		return fmt.Errorf("empty host")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(ip)
+	}
+
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("resolve host '%s': %w", host, err)
+	}
+
+	if len(addrs) == 0 {
+		return fmt.Errorf("host '%s' doesn't have any address", host)
+	}
+
+	for _, addr := range addrs {
+		if err = checkIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateClient creates http client for webhooks deliveries. Connections to not allowed addresses are refused
+// after host resolution. Redirects are not followed, redirect response is treated as delivery result.
+func CreateClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("parse dial address '%s': %w", address, err)
+			}
+
+			return checkIP(net.ParseIP(host))
+		},
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func checkIP(ip net.IP) error {
+	if !IsAllowed(ip) {
+		return fmt.Errorf("'%s': %w", ip, ErrForbiddenAddress)
+	}
+
+	return nil
+}
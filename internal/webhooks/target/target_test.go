@@ -0,0 +1,103 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResolver map[string][]string
+
+func (r stubResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, fmt.Errorf("no such host")
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestCheckURL(t *testing.T) {
+	resolver := stubResolver{
+		"example.com":  {"93.184.216.34"},
+		"localhost":    {"127.0.0.1", "::1"},
+		"internal.lan": {"10.0.0.5"},
+		"mixed.com":    {"93.184.216.34", "192.168.1.1"},
+	}
+
+	tests := []struct {
+		name          string
+		url           string
+		wantErr       bool
+		wantForbidden bool
+	}{
+		{name: "public host", url: "https://example.com/hook"},
+		{name: "public ip", url: "http://93.184.216.34:8080/hook"},
+		{name: "unsupported scheme", url: "ftp://example.com/hook", wantErr: true},
+		{name: "relative url", url: "/hook", wantErr: true},
+		{name: "empty url", url: "", wantErr: true},
+		{name: "unresolved host", url: "https://unknown.com/hook", wantErr: true},
+		{name: "localhost", url: "http://localhost:8080/hook", wantErr: true, wantForbidden: true},
+		{name: "loopback ip", url: "http://127.0.0.1/hook", wantErr: true, wantForbidden: true},
+		{name: "loopback ipv6", url: "http://[::1]/hook", wantErr: true, wantForbidden: true},
+		{name: "private ip", url: "http://10.1.2.3/hook", wantErr: true, wantForbidden: true},
+		{name: "private host", url: "http://internal.lan/hook", wantErr: true, wantForbidden: true},
+		{name: "one of addresses is private", url: "http://mixed.com/hook", wantErr: true, wantForbidden: true},
+		{name: "metadata service", url: "http://169.254.169.254/latest/meta-data", wantErr: true, wantForbidden: true},
+		{name: "unspecified ip", url: "http://0.0.0.0/hook", wantErr: true, wantForbidden: true},
+		{name: "ipv4 mapped loopback", url: "http://[::ffff:127.0.0.1]/hook", wantErr: true, wantForbidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckURL(context.Background(), resolver, tt.url)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			if tt.wantForbidden {
+				assert.ErrorIs(t, err, ErrForbiddenAddress)
+			}
+		})
+	}
+}
+
+func TestCreateClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := CreateClient(time.Second)
+	_, err := client.Get(ts.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+}
+
+func TestCreateClient_Redirect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer ts.Close()
+
+	// loopback test server is reachable by default transport only, redirect policy is checked separately.
+	client := CreateClient(time.Second)
+	client.Transport = http.DefaultTransport
+
+	resp, err := client.Get(ts.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+}
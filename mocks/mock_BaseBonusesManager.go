@@ -35,38 +35,82 @@ func (m *MockBaseBonusesManager) EXPECT() *MockBaseBonusesManagerMockRecorder {
 	return m.recorder
 }
 
+// AdjustBonuses mocks base method.
+func (m *MockBaseBonusesManager) AdjustBonuses(arg0 context.Context, arg1 *data.Adjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBonuses", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustBonuses indicates an expected call of AdjustBonuses.
+func (mr *MockBaseBonusesManagerMockRecorder) AdjustBonuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBonuses", reflect.TypeOf((*MockBaseBonusesManager)(nil).AdjustBonuses), arg0, arg1)
+}
+
+// GetAdjustments mocks base method.
+func (m *MockBaseBonusesManager) GetAdjustments(arg0 context.Context, arg1 int64) ([]data.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustments", arg0, arg1)
+	ret0, _ := ret[0].([]data.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustments indicates an expected call of GetAdjustments.
+func (mr *MockBaseBonusesManagerMockRecorder) GetAdjustments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustments", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetAdjustments), arg0, arg1)
+}
+
 // GetBalance mocks base method.
-func (m *MockBaseBonusesManager) GetBalance(arg0 context.Context, arg1 bool, arg2 int64) (float32, error) {
+func (m *MockBaseBonusesManager) GetBalance(arg0 context.Context, arg1 int64) (*data.Balance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBalance", arg0, arg1, arg2)
-	ret0, _ := ret[0].(float32)
+	ret := m.ctrl.Call(m, "GetBalance", arg0, arg1)
+	ret0, _ := ret[0].(*data.Balance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBalance indicates an expected call of GetBalance.
-func (mr *MockBaseBonusesManagerMockRecorder) GetBalance(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBaseBonusesManagerMockRecorder) GetBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetBalance), arg0, arg1)
+}
+
+// GetBalanceMismatches mocks base method.
+func (m *MockBaseBonusesManager) GetBalanceMismatches(arg0 context.Context) ([]data.BalanceMismatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceMismatches", arg0)
+	ret0, _ := ret[0].([]data.BalanceMismatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceMismatches indicates an expected call of GetBalanceMismatches.
+func (mr *MockBaseBonusesManagerMockRecorder) GetBalanceMismatches(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetBalance), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceMismatches", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetBalanceMismatches), arg0)
 }
 
-// GetBalanceDif mocks base method.
-func (m *MockBaseBonusesManager) GetBalanceDif(arg0 context.Context, arg1 int64) (float32, error) {
+// GetBonuses mocks base method.
+func (m *MockBaseBonusesManager) GetBonuses(arg0 context.Context, arg1 int64) ([]data.Bonus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBalanceDif", arg0, arg1)
-	ret0, _ := ret[0].(float32)
+	ret := m.ctrl.Call(m, "GetBonuses", arg0, arg1)
+	ret0, _ := ret[0].([]data.Bonus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBalanceDif indicates an expected call of GetBalanceDif.
-func (mr *MockBaseBonusesManagerMockRecorder) GetBalanceDif(arg0, arg1 interface{}) *gomock.Call {
+// GetBonuses indicates an expected call of GetBonuses.
+func (mr *MockBaseBonusesManagerMockRecorder) GetBonuses(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceDif", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetBalanceDif), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBonuses", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetBonuses), arg0, arg1)
 }
 
 // GetWithdrawals mocks base method.
-func (m *MockBaseBonusesManager) GetWithdrawals(arg0 context.Context, arg1 int64) ([]data.Withdrawal, error) {
+func (m *MockBaseBonusesManager) GetWithdrawals(arg0 context.Context, arg1 data.WithdrawalsFilter) ([]data.Withdrawal, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetWithdrawals", arg0, arg1)
 	ret0, _ := ret[0].([]data.Withdrawal)
@@ -80,6 +124,36 @@ func (mr *MockBaseBonusesManagerMockRecorder) GetWithdrawals(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawals", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetWithdrawals), arg0, arg1)
 }
 
+// GetWithdrawalsSummary mocks base method.
+func (m *MockBaseBonusesManager) GetWithdrawalsSummary(arg0 context.Context, arg1 data.WithdrawalsFilter) (*data.WithdrawalsSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalsSummary", arg0, arg1)
+	ret0, _ := ret[0].(*data.WithdrawalsSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalsSummary indicates an expected call of GetWithdrawalsSummary.
+func (mr *MockBaseBonusesManagerMockRecorder) GetWithdrawalsSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalsSummary", reflect.TypeOf((*MockBaseBonusesManager)(nil).GetWithdrawalsSummary), arg0, arg1)
+}
+
+// RepairBalance mocks base method.
+func (m *MockBaseBonusesManager) RepairBalance(arg0 context.Context, arg1 int64) (*data.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairBalance", arg0, arg1)
+	ret0, _ := ret[0].(*data.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairBalance indicates an expected call of RepairBalance.
+func (mr *MockBaseBonusesManagerMockRecorder) RepairBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairBalance", reflect.TypeOf((*MockBaseBonusesManager)(nil).RepairBalance), arg0, arg1)
+}
+
 // WithdrawBonuses mocks base method.
 func (m *MockBaseBonusesManager) WithdrawBonuses(arg0 context.Context, arg1 *data.Withdrawal) error {
 	m.ctrl.T.Helper()
@@ -35,6 +35,35 @@ func (m *MockBaseBonusesStorage) EXPECT() *MockBaseBonusesStorageMockRecorder {
 	return m.recorder
 }
 
+// AdjustBonuses mocks base method.
+func (m *MockBaseBonusesStorage) AdjustBonuses(arg0 context.Context, arg1 *data.Adjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBonuses", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustBonuses indicates an expected call of AdjustBonuses.
+func (mr *MockBaseBonusesStorageMockRecorder) AdjustBonuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBonuses", reflect.TypeOf((*MockBaseBonusesStorage)(nil).AdjustBonuses), arg0, arg1)
+}
+
+// GetAdjustments mocks base method.
+func (m *MockBaseBonusesStorage) GetAdjustments(arg0 context.Context, arg1 int64) ([]data.Adjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdjustments", arg0, arg1)
+	ret0, _ := ret[0].([]data.Adjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdjustments indicates an expected call of GetAdjustments.
+func (mr *MockBaseBonusesStorageMockRecorder) GetAdjustments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdjustments", reflect.TypeOf((*MockBaseBonusesStorage)(nil).GetAdjustments), arg0, arg1)
+}
+
 // GetBalance mocks base method.
 func (m *MockBaseBonusesStorage) GetBalance(arg0 context.Context, arg1 int64) (*data.Balance, error) {
 	m.ctrl.T.Helper()
@@ -50,8 +79,23 @@ func (mr *MockBaseBonusesStorageMockRecorder) GetBalance(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockBaseBonusesStorage)(nil).GetBalance), arg0, arg1)
 }
 
+// GetBonuses mocks base method.
+func (m *MockBaseBonusesStorage) GetBonuses(arg0 context.Context, arg1 int64) ([]data.Bonus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBonuses", arg0, arg1)
+	ret0, _ := ret[0].([]data.Bonus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBonuses indicates an expected call of GetBonuses.
+func (mr *MockBaseBonusesStorageMockRecorder) GetBonuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBonuses", reflect.TypeOf((*MockBaseBonusesStorage)(nil).GetBonuses), arg0, arg1)
+}
+
 // GetWithdrawals mocks base method.
-func (m *MockBaseBonusesStorage) GetWithdrawals(arg0 context.Context, arg1 int64) ([]data.Withdrawal, error) {
+func (m *MockBaseBonusesStorage) GetWithdrawals(arg0 context.Context, arg1 data.WithdrawalsFilter) ([]data.Withdrawal, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetWithdrawals", arg0, arg1)
 	ret0, _ := ret[0].([]data.Withdrawal)
@@ -65,6 +109,36 @@ func (mr *MockBaseBonusesStorageMockRecorder) GetWithdrawals(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawals", reflect.TypeOf((*MockBaseBonusesStorage)(nil).GetWithdrawals), arg0, arg1)
 }
 
+// GetWithdrawalsSummary mocks base method.
+func (m *MockBaseBonusesStorage) GetWithdrawalsSummary(arg0 context.Context, arg1 data.WithdrawalsFilter) (*data.WithdrawalsSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalsSummary", arg0, arg1)
+	ret0, _ := ret[0].(*data.WithdrawalsSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalsSummary indicates an expected call of GetWithdrawalsSummary.
+func (mr *MockBaseBonusesStorageMockRecorder) GetWithdrawalsSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalsSummary", reflect.TypeOf((*MockBaseBonusesStorage)(nil).GetWithdrawalsSummary), arg0, arg1)
+}
+
+// ReconcileBalances mocks base method.
+func (m *MockBaseBonusesStorage) ReconcileBalances(arg0 context.Context) ([]data.BalanceMismatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileBalances", arg0)
+	ret0, _ := ret[0].([]data.BalanceMismatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileBalances indicates an expected call of ReconcileBalances.
+func (mr *MockBaseBonusesStorageMockRecorder) ReconcileBalances(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileBalances", reflect.TypeOf((*MockBaseBonusesStorage)(nil).ReconcileBalances), arg0)
+}
+
 // WithdrawBonuses mocks base method.
 func (m *MockBaseBonusesStorage) WithdrawBonuses(arg0 context.Context, arg1 *data.Withdrawal) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/accrual/client (interfaces: BaseClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	client "github.com/erupshis/bonusbridge/internal/accrual/client"
+	data "github.com/erupshis/bonusbridge/internal/orders/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseClient is a mock of BaseClient interface.
+type MockBaseClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseClientMockRecorder
+}
+
+// MockBaseClientMockRecorder is the mock recorder for MockBaseClient.
+type MockBaseClientMockRecorder struct {
+	mock *MockBaseClient
+}
+
+// NewMockBaseClient creates a new mock instance.
+func NewMockBaseClient(ctrl *gomock.Controller) *MockBaseClient {
+	mock := &MockBaseClient{ctrl: ctrl}
+	mock.recorder = &MockBaseClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseClient) EXPECT() *MockBaseClientMockRecorder {
+	return m.recorder
+}
+
+// RequestCalculationResult mocks base method.
+func (m *MockBaseClient) RequestCalculationResult(arg0 context.Context, arg1 string, arg2 *data.Order) (client.ResponseStatus, client.Throttling, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCalculationResult", arg0, arg1, arg2)
+	ret0, _ := ret[0].(client.ResponseStatus)
+	ret1, _ := ret[1].(client.Throttling)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RequestCalculationResult indicates an expected call of RequestCalculationResult.
+func (mr *MockBaseClientMockRecorder) RequestCalculationResult(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCalculationResult", reflect.TypeOf((*MockBaseClient)(nil).RequestCalculationResult), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/idempotency/managers (interfaces: BaseIdempotencyManager)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	data "github.com/erupshis/bonusbridge/internal/idempotency/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseIdempotencyManager is a mock of BaseIdempotencyManager interface.
+type MockBaseIdempotencyManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseIdempotencyManagerMockRecorder
+}
+
+// MockBaseIdempotencyManagerMockRecorder is the mock recorder for MockBaseIdempotencyManager.
+type MockBaseIdempotencyManagerMockRecorder struct {
+	mock *MockBaseIdempotencyManager
+}
+
+// NewMockBaseIdempotencyManager creates a new mock instance.
+func NewMockBaseIdempotencyManager(ctrl *gomock.Controller) *MockBaseIdempotencyManager {
+	mock := &MockBaseIdempotencyManager{ctrl: ctrl}
+	mock.recorder = &MockBaseIdempotencyManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseIdempotencyManager) EXPECT() *MockBaseIdempotencyManagerMockRecorder {
+	return m.recorder
+}
+
+// CompleteRecord mocks base method.
+func (m *MockBaseIdempotencyManager) CompleteRecord(arg0 context.Context, arg1 *data.Record) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRecord", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteRecord indicates an expected call of CompleteRecord.
+func (mr *MockBaseIdempotencyManagerMockRecorder) CompleteRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRecord", reflect.TypeOf((*MockBaseIdempotencyManager)(nil).CompleteRecord), arg0, arg1)
+}
+
+// DeleteRecord mocks base method.
+func (m *MockBaseIdempotencyManager) DeleteRecord(arg0 context.Context, arg1 *data.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecord indicates an expected call of DeleteRecord.
+func (mr *MockBaseIdempotencyManagerMockRecorder) DeleteRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecord", reflect.TypeOf((*MockBaseIdempotencyManager)(nil).DeleteRecord), arg0, arg1)
+}
+
+// GetRecord mocks base method.
+func (m *MockBaseIdempotencyManager) GetRecord(arg0 context.Context, arg1 int64, arg2 string) (*data.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecord indicates an expected call of GetRecord.
+func (mr *MockBaseIdempotencyManagerMockRecorder) GetRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecord", reflect.TypeOf((*MockBaseIdempotencyManager)(nil).GetRecord), arg0, arg1, arg2)
+}
+
+// ReserveKey mocks base method.
+func (m *MockBaseIdempotencyManager) ReserveKey(arg0 context.Context, arg1 *data.Record) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveKey", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveKey indicates an expected call of ReserveKey.
+func (mr *MockBaseIdempotencyManagerMockRecorder) ReserveKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveKey", reflect.TypeOf((*MockBaseIdempotencyManager)(nil).ReserveKey), arg0, arg1)
+}
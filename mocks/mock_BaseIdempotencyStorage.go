@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/idempotency/storage (interfaces: BaseIdempotencyStorage)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	data "github.com/erupshis/bonusbridge/internal/idempotency/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseIdempotencyStorage is a mock of BaseIdempotencyStorage interface.
+type MockBaseIdempotencyStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseIdempotencyStorageMockRecorder
+}
+
+// MockBaseIdempotencyStorageMockRecorder is the mock recorder for MockBaseIdempotencyStorage.
+type MockBaseIdempotencyStorageMockRecorder struct {
+	mock *MockBaseIdempotencyStorage
+}
+
+// NewMockBaseIdempotencyStorage creates a new mock instance.
+func NewMockBaseIdempotencyStorage(ctrl *gomock.Controller) *MockBaseIdempotencyStorage {
+	mock := &MockBaseIdempotencyStorage{ctrl: ctrl}
+	mock.recorder = &MockBaseIdempotencyStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseIdempotencyStorage) EXPECT() *MockBaseIdempotencyStorageMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockBaseIdempotencyStorage) Begin(arg0 context.Context, arg1 int64, arg2 string, arg3 string) (*data.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*data.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockBaseIdempotencyStorageMockRecorder) Begin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockBaseIdempotencyStorage)(nil).Begin), arg0, arg1, arg2, arg3)
+}
+
+// Complete mocks base method.
+func (m *MockBaseIdempotencyStorage) Complete(arg0 context.Context, arg1 *data.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockBaseIdempotencyStorageMockRecorder) Complete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockBaseIdempotencyStorage)(nil).Complete), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *MockBaseIdempotencyStorage) Release(arg0 context.Context, arg1 *data.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockBaseIdempotencyStorageMockRecorder) Release(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockBaseIdempotencyStorage)(nil).Release), arg0, arg1)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	data "github.com/erupshis/bonusbridge/internal/orders/data"
 	gomock "github.com/golang/mock/gomock"
@@ -50,8 +51,37 @@ func (mr *MockBaseOrdersManagerMockRecorder) AddOrder(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockBaseOrdersManager)(nil).AddOrder), arg0, arg1, arg2)
 }
 
+// ClaimAccrualJobs mocks base method.
+func (m *MockBaseOrdersManager) ClaimAccrualJobs(arg0 context.Context, arg1 int, arg2 time.Duration) ([]data.AccrualJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAccrualJobs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]data.AccrualJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAccrualJobs indicates an expected call of ClaimAccrualJobs.
+func (mr *MockBaseOrdersManagerMockRecorder) ClaimAccrualJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAccrualJobs", reflect.TypeOf((*MockBaseOrdersManager)(nil).ClaimAccrualJobs), arg0, arg1, arg2)
+}
+
+// DeleteAccrualJob mocks base method.
+func (m *MockBaseOrdersManager) DeleteAccrualJob(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccrualJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccrualJob indicates an expected call of DeleteAccrualJob.
+func (mr *MockBaseOrdersManagerMockRecorder) DeleteAccrualJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccrualJob", reflect.TypeOf((*MockBaseOrdersManager)(nil).DeleteAccrualJob), arg0, arg1)
+}
+
 // GetOrders mocks base method.
-func (m *MockBaseOrdersManager) GetOrders(arg0 context.Context, arg1 map[string]interface{}) ([]data.Order, error) {
+func (m *MockBaseOrdersManager) GetOrders(arg0 context.Context, arg1 data.Filter) ([]data.Order, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOrders", arg0, arg1)
 	ret0, _ := ret[0].([]data.Order)
@@ -65,6 +95,34 @@ func (mr *MockBaseOrdersManagerMockRecorder) GetOrders(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockBaseOrdersManager)(nil).GetOrders), arg0, arg1)
 }
 
+// RescheduleAccrualJob mocks base method.
+func (m *MockBaseOrdersManager) RescheduleAccrualJob(arg0 context.Context, arg1 *data.AccrualJob, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleAccrualJob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleAccrualJob indicates an expected call of RescheduleAccrualJob.
+func (mr *MockBaseOrdersManagerMockRecorder) RescheduleAccrualJob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleAccrualJob", reflect.TypeOf((*MockBaseOrdersManager)(nil).RescheduleAccrualJob), arg0, arg1, arg2)
+}
+
+// ResetOrder mocks base method.
+func (m *MockBaseOrdersManager) ResetOrder(arg0 context.Context, arg1 *data.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetOrder indicates an expected call of ResetOrder.
+func (mr *MockBaseOrdersManagerMockRecorder) ResetOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetOrder", reflect.TypeOf((*MockBaseOrdersManager)(nil).ResetOrder), arg0, arg1)
+}
+
 // UpdateOrder mocks base method.
 func (m *MockBaseOrdersManager) UpdateOrder(arg0 context.Context, arg1 *data.Order) error {
 	m.ctrl.T.Helper()
@@ -78,3 +136,17 @@ func (mr *MockBaseOrdersManagerMockRecorder) UpdateOrder(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockBaseOrdersManager)(nil).UpdateOrder), arg0, arg1)
 }
+
+// UpdateOrders mocks base method.
+func (m *MockBaseOrdersManager) UpdateOrders(arg0 context.Context, arg1 []data.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrders indicates an expected call of UpdateOrders.
+func (mr *MockBaseOrdersManagerMockRecorder) UpdateOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrders", reflect.TypeOf((*MockBaseOrdersManager)(nil).UpdateOrders), arg0, arg1)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	data "github.com/erupshis/bonusbridge/internal/orders/data"
 	gomock "github.com/golang/mock/gomock"
@@ -49,8 +50,37 @@ func (mr *MockBaseOrdersStorageMockRecorder) AddOrder(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockBaseOrdersStorage)(nil).AddOrder), arg0, arg1, arg2)
 }
 
+// ClaimAccrualJobs mocks base method.
+func (m *MockBaseOrdersStorage) ClaimAccrualJobs(arg0 context.Context, arg1 int, arg2 time.Duration) ([]data.AccrualJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAccrualJobs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]data.AccrualJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAccrualJobs indicates an expected call of ClaimAccrualJobs.
+func (mr *MockBaseOrdersStorageMockRecorder) ClaimAccrualJobs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAccrualJobs", reflect.TypeOf((*MockBaseOrdersStorage)(nil).ClaimAccrualJobs), arg0, arg1, arg2)
+}
+
+// DeleteAccrualJob mocks base method.
+func (m *MockBaseOrdersStorage) DeleteAccrualJob(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccrualJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccrualJob indicates an expected call of DeleteAccrualJob.
+func (mr *MockBaseOrdersStorageMockRecorder) DeleteAccrualJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccrualJob", reflect.TypeOf((*MockBaseOrdersStorage)(nil).DeleteAccrualJob), arg0, arg1)
+}
+
 // GetOrders mocks base method.
-func (m *MockBaseOrdersStorage) GetOrders(arg0 context.Context, arg1 map[string]interface{}) ([]data.Order, error) {
+func (m *MockBaseOrdersStorage) GetOrders(arg0 context.Context, arg1 data.Filter) ([]data.Order, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOrders", arg0, arg1)
 	ret0, _ := ret[0].([]data.Order)
@@ -64,6 +94,34 @@ func (mr *MockBaseOrdersStorageMockRecorder) GetOrders(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockBaseOrdersStorage)(nil).GetOrders), arg0, arg1)
 }
 
+// RescheduleAccrualJob mocks base method.
+func (m *MockBaseOrdersStorage) RescheduleAccrualJob(arg0 context.Context, arg1 *data.AccrualJob, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleAccrualJob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleAccrualJob indicates an expected call of RescheduleAccrualJob.
+func (mr *MockBaseOrdersStorageMockRecorder) RescheduleAccrualJob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleAccrualJob", reflect.TypeOf((*MockBaseOrdersStorage)(nil).RescheduleAccrualJob), arg0, arg1, arg2)
+}
+
+// ResetOrder mocks base method.
+func (m *MockBaseOrdersStorage) ResetOrder(arg0 context.Context, arg1 *data.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetOrder indicates an expected call of ResetOrder.
+func (mr *MockBaseOrdersStorageMockRecorder) ResetOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetOrder", reflect.TypeOf((*MockBaseOrdersStorage)(nil).ResetOrder), arg0, arg1)
+}
+
 // UpdateOrder mocks base method.
 func (m *MockBaseOrdersStorage) UpdateOrder(arg0 context.Context, arg1 *data.Order) error {
 	m.ctrl.T.Helper()
@@ -77,3 +135,17 @@ func (mr *MockBaseOrdersStorageMockRecorder) UpdateOrder(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockBaseOrdersStorage)(nil).UpdateOrder), arg0, arg1)
 }
+
+// UpdateOrders mocks base method.
+func (m *MockBaseOrdersStorage) UpdateOrders(arg0 context.Context, arg1 []data.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrders indicates an expected call of UpdateOrders.
+func (mr *MockBaseOrdersStorageMockRecorder) UpdateOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrders", reflect.TypeOf((*MockBaseOrdersStorage)(nil).UpdateOrders), arg0, arg1)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/outbox/managers (interfaces: BaseOutboxManager)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	managers "github.com/erupshis/bonusbridge/internal/outbox/managers"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseOutboxManager is a mock of BaseOutboxManager interface.
+type MockBaseOutboxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseOutboxManagerMockRecorder
+}
+
+// MockBaseOutboxManagerMockRecorder is the mock recorder for MockBaseOutboxManager.
+type MockBaseOutboxManagerMockRecorder struct {
+	mock *MockBaseOutboxManager
+}
+
+// NewMockBaseOutboxManager creates a new mock instance.
+func NewMockBaseOutboxManager(ctrl *gomock.Controller) *MockBaseOutboxManager {
+	mock := &MockBaseOutboxManager{ctrl: ctrl}
+	mock.recorder = &MockBaseOutboxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseOutboxManager) EXPECT() *MockBaseOutboxManagerMockRecorder {
+	return m.recorder
+}
+
+// PruneEvents mocks base method.
+func (m *MockBaseOutboxManager) PruneEvents(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneEvents indicates an expected call of PruneEvents.
+func (mr *MockBaseOutboxManagerMockRecorder) PruneEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneEvents", reflect.TypeOf((*MockBaseOutboxManager)(nil).PruneEvents), arg0, arg1)
+}
+
+// PublishEvents mocks base method.
+func (m *MockBaseOutboxManager) PublishEvents(arg0 context.Context, arg1 int, arg2 managers.PublishFunc) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishEvents indicates an expected call of PublishEvents.
+func (mr *MockBaseOutboxManagerMockRecorder) PublishEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvents", reflect.TypeOf((*MockBaseOutboxManager)(nil).PublishEvents), arg0, arg1, arg2)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/auth/sessions/managers (interfaces: BaseSessionsManager)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	data "github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseSessionsManager is a mock of BaseSessionsManager interface.
+type MockBaseSessionsManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseSessionsManagerMockRecorder
+}
+
+// MockBaseSessionsManagerMockRecorder is the mock recorder for MockBaseSessionsManager.
+type MockBaseSessionsManagerMockRecorder struct {
+	mock *MockBaseSessionsManager
+}
+
+// NewMockBaseSessionsManager creates a new mock instance.
+func NewMockBaseSessionsManager(ctrl *gomock.Controller) *MockBaseSessionsManager {
+	mock := &MockBaseSessionsManager{ctrl: ctrl}
+	mock.recorder = &MockBaseSessionsManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseSessionsManager) EXPECT() *MockBaseSessionsManagerMockRecorder {
+	return m.recorder
+}
+
+// AddSession mocks base method.
+func (m *MockBaseSessionsManager) AddSession(arg0 context.Context, arg1 *data.Session) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockBaseSessionsManagerMockRecorder) AddSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockBaseSessionsManager)(nil).AddSession), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockBaseSessionsManager) GetSession(arg0 context.Context, arg1 int64) (*data.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*data.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockBaseSessionsManagerMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockBaseSessionsManager)(nil).GetSession), arg0, arg1)
+}
+
+// GetSessionByRefreshToken mocks base method.
+func (m *MockBaseSessionsManager) GetSessionByRefreshToken(arg0 context.Context, arg1 string) (*data.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(*data.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByRefreshToken indicates an expected call of GetSessionByRefreshToken.
+func (mr *MockBaseSessionsManagerMockRecorder) GetSessionByRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByRefreshToken", reflect.TypeOf((*MockBaseSessionsManager)(nil).GetSessionByRefreshToken), arg0, arg1)
+}
+
+// GetSessionBySupersededRefreshToken mocks base method.
+func (m *MockBaseSessionsManager) GetSessionBySupersededRefreshToken(arg0 context.Context, arg1 string) (*data.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionBySupersededRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(*data.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionBySupersededRefreshToken indicates an expected call of GetSessionBySupersededRefreshToken.
+func (mr *MockBaseSessionsManagerMockRecorder) GetSessionBySupersededRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionBySupersededRefreshToken", reflect.TypeOf((*MockBaseSessionsManager)(nil).GetSessionBySupersededRefreshToken), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockBaseSessionsManager) RevokeSession(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockBaseSessionsManagerMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockBaseSessionsManager)(nil).RevokeSession), arg0, arg1)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockBaseSessionsManager) RotateRefreshToken(arg0 context.Context, arg1 int64, arg2, arg3 string, arg4 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockBaseSessionsManagerMockRecorder) RotateRefreshToken(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockBaseSessionsManager)(nil).RotateRefreshToken), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/auth/sessions/storage (interfaces: BaseSessionsStorage)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	data "github.com/erupshis/bonusbridge/internal/auth/sessions/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseSessionsStorage is a mock of BaseSessionsStorage interface.
+type MockBaseSessionsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseSessionsStorageMockRecorder
+}
+
+// MockBaseSessionsStorageMockRecorder is the mock recorder for MockBaseSessionsStorage.
+type MockBaseSessionsStorageMockRecorder struct {
+	mock *MockBaseSessionsStorage
+}
+
+// NewMockBaseSessionsStorage creates a new mock instance.
+func NewMockBaseSessionsStorage(ctrl *gomock.Controller) *MockBaseSessionsStorage {
+	mock := &MockBaseSessionsStorage{ctrl: ctrl}
+	mock.recorder = &MockBaseSessionsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseSessionsStorage) EXPECT() *MockBaseSessionsStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockBaseSessionsStorage) CreateSession(arg0 context.Context, arg1 int64) (*data.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(*data.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockBaseSessionsStorageMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockBaseSessionsStorage)(nil).CreateSession), arg0, arg1)
+}
+
+// IsSessionActive mocks base method.
+func (m *MockBaseSessionsStorage) IsSessionActive(arg0 context.Context, arg1 int64, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionActive", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionActive indicates an expected call of IsSessionActive.
+func (mr *MockBaseSessionsStorageMockRecorder) IsSessionActive(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionActive", reflect.TypeOf((*MockBaseSessionsStorage)(nil).IsSessionActive), arg0, arg1, arg2)
+}
+
+// RefreshSession mocks base method.
+func (m *MockBaseSessionsStorage) RefreshSession(arg0 context.Context, arg1 string) (*data.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", arg0, arg1)
+	ret0, _ := ret[0].(*data.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockBaseSessionsStorageMockRecorder) RefreshSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockBaseSessionsStorage)(nil).RefreshSession), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockBaseSessionsStorage) RevokeSession(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockBaseSessionsStorageMockRecorder) RevokeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockBaseSessionsStorage)(nil).RevokeSession), arg0, arg1)
+}
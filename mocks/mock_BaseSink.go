@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/outbox/sinks (interfaces: BaseSink)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	data "github.com/erupshis/bonusbridge/internal/outbox/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseSink is a mock of BaseSink interface.
+type MockBaseSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseSinkMockRecorder
+}
+
+// MockBaseSinkMockRecorder is the mock recorder for MockBaseSink.
+type MockBaseSinkMockRecorder struct {
+	mock *MockBaseSink
+}
+
+// NewMockBaseSink creates a new mock instance.
+func NewMockBaseSink(ctrl *gomock.Controller) *MockBaseSink {
+	mock := &MockBaseSink{ctrl: ctrl}
+	mock.recorder = &MockBaseSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseSink) EXPECT() *MockBaseSinkMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockBaseSink) Publish(arg0 context.Context, arg1 *data.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockBaseSinkMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockBaseSink)(nil).Publish), arg0, arg1)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/webhooks/managers (interfaces: BaseWebhooksManager)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	data "github.com/erupshis/bonusbridge/internal/webhooks/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseWebhooksManager is a mock of BaseWebhooksManager interface.
+type MockBaseWebhooksManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseWebhooksManagerMockRecorder
+}
+
+// MockBaseWebhooksManagerMockRecorder is the mock recorder for MockBaseWebhooksManager.
+type MockBaseWebhooksManagerMockRecorder struct {
+	mock *MockBaseWebhooksManager
+}
+
+// NewMockBaseWebhooksManager creates a new mock instance.
+func NewMockBaseWebhooksManager(ctrl *gomock.Controller) *MockBaseWebhooksManager {
+	mock := &MockBaseWebhooksManager{ctrl: ctrl}
+	mock.recorder = &MockBaseWebhooksManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseWebhooksManager) EXPECT() *MockBaseWebhooksManagerMockRecorder {
+	return m.recorder
+}
+
+// AddDeliveries mocks base method.
+func (m *MockBaseWebhooksManager) AddDeliveries(arg0 context.Context, arg1 []data.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeliveries indicates an expected call of AddDeliveries.
+func (mr *MockBaseWebhooksManagerMockRecorder) AddDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveries", reflect.TypeOf((*MockBaseWebhooksManager)(nil).AddDeliveries), arg0, arg1)
+}
+
+// AddWebhook mocks base method.
+func (m *MockBaseWebhooksManager) AddWebhook(arg0 context.Context, arg1 *data.Webhook) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockBaseWebhooksManagerMockRecorder) AddWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockBaseWebhooksManager)(nil).AddWebhook), arg0, arg1)
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockBaseWebhooksManager) ClaimDeliveries(arg0 context.Context, arg1 int, arg2 time.Duration) ([]data.DeliveryJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]data.DeliveryJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockBaseWebhooksManagerMockRecorder) ClaimDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockBaseWebhooksManager)(nil).ClaimDeliveries), arg0, arg1, arg2)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockBaseWebhooksManager) DeleteWebhook(arg0 context.Context, arg1, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockBaseWebhooksManagerMockRecorder) DeleteWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockBaseWebhooksManager)(nil).DeleteWebhook), arg0, arg1, arg2)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockBaseWebhooksManager) GetDeliveries(arg0 context.Context, arg1 int64, arg2 int) ([]data.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]data.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockBaseWebhooksManagerMockRecorder) GetDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockBaseWebhooksManager)(nil).GetDeliveries), arg0, arg1, arg2)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockBaseWebhooksManager) GetWebhooks(arg0 context.Context, arg1 int64) ([]data.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", arg0, arg1)
+	ret0, _ := ret[0].([]data.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockBaseWebhooksManagerMockRecorder) GetWebhooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockBaseWebhooksManager)(nil).GetWebhooks), arg0, arg1)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockBaseWebhooksManager) UpdateDelivery(arg0 context.Context, arg1 *data.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockBaseWebhooksManagerMockRecorder) UpdateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockBaseWebhooksManager)(nil).UpdateDelivery), arg0, arg1)
+}
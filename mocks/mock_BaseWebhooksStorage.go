@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erupshis/bonusbridge/internal/webhooks/storage (interfaces: BaseWebhooksStorage)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	data "github.com/erupshis/bonusbridge/internal/webhooks/data"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBaseWebhooksStorage is a mock of BaseWebhooksStorage interface.
+type MockBaseWebhooksStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBaseWebhooksStorageMockRecorder
+}
+
+// MockBaseWebhooksStorageMockRecorder is the mock recorder for MockBaseWebhooksStorage.
+type MockBaseWebhooksStorageMockRecorder struct {
+	mock *MockBaseWebhooksStorage
+}
+
+// NewMockBaseWebhooksStorage creates a new mock instance.
+func NewMockBaseWebhooksStorage(ctrl *gomock.Controller) *MockBaseWebhooksStorage {
+	mock := &MockBaseWebhooksStorage{ctrl: ctrl}
+	mock.recorder = &MockBaseWebhooksStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBaseWebhooksStorage) EXPECT() *MockBaseWebhooksStorageMockRecorder {
+	return m.recorder
+}
+
+// AddDeliveries mocks base method.
+func (m *MockBaseWebhooksStorage) AddDeliveries(arg0 context.Context, arg1 []data.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeliveries indicates an expected call of AddDeliveries.
+func (mr *MockBaseWebhooksStorageMockRecorder) AddDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveries", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).AddDeliveries), arg0, arg1)
+}
+
+// AddWebhook mocks base method.
+func (m *MockBaseWebhooksStorage) AddWebhook(arg0 context.Context, arg1 int64, arg2 string) (*data.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*data.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockBaseWebhooksStorageMockRecorder) AddWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).AddWebhook), arg0, arg1, arg2)
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockBaseWebhooksStorage) ClaimDeliveries(arg0 context.Context, arg1 int, arg2 time.Duration) ([]data.DeliveryJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]data.DeliveryJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockBaseWebhooksStorageMockRecorder) ClaimDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).ClaimDeliveries), arg0, arg1, arg2)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockBaseWebhooksStorage) DeleteWebhook(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockBaseWebhooksStorageMockRecorder) DeleteWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).DeleteWebhook), arg0, arg1, arg2)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockBaseWebhooksStorage) GetDeliveries(arg0 context.Context, arg1 int64) ([]data.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]data.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockBaseWebhooksStorageMockRecorder) GetDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).GetDeliveries), arg0, arg1)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockBaseWebhooksStorage) GetWebhooks(arg0 context.Context, arg1 int64) ([]data.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", arg0, arg1)
+	ret0, _ := ret[0].([]data.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockBaseWebhooksStorageMockRecorder) GetWebhooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).GetWebhooks), arg0, arg1)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockBaseWebhooksStorage) UpdateDelivery(arg0 context.Context, arg1 *data.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockBaseWebhooksStorageMockRecorder) UpdateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockBaseWebhooksStorage)(nil).UpdateDelivery), arg0, arg1)
+}